@@ -0,0 +1,92 @@
+// +build linux
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/codegangsta/cli"
+	"github.com/opencontainers/runc/libcontainer/devices"
+)
+
+var deviceCommand = cli.Command{
+	Name:  "device",
+	Usage: "add or remove devices of a running container",
+	Subcommands: []cli.Command{
+		deviceAddCommand,
+		deviceRemoveCommand,
+	},
+}
+
+var deviceAddCommand = cli.Command{
+	Name:  "add",
+	Usage: "add a device of the host to a running container",
+	ArgsUsage: `<container-id> <host-path>
+
+Where "<container-id>" is the name for the instance of the container and
+"<host-path>" is the path of the device node on the host, created at the same
+path inside the container.
+
+For example, to give the container "ubuntu01" read and write access to
+/dev/fuse:
+
+       # runc device add --permissions rw ubuntu01 /dev/fuse`,
+	Description: `The add command allows the device in the devices cgroup of the container and
+creates its node inside the rootfs. Under user namespaces, the node of the host
+is bind mounted instead.`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "permissions",
+			Value: "rwm",
+			Usage: "cgroup permissions of the device: any of r (read), w (write) and m (mknod)",
+		},
+	},
+	Action: func(context *cli.Context) {
+		container, err := getContainer(context)
+		if err != nil {
+			fatal(err)
+		}
+		path := context.Args().Get(1)
+		if path == "" {
+			fatal(fmt.Errorf("path of the device must be specified"))
+		}
+		permissions := context.String("permissions")
+		for _, p := range permissions {
+			if p != 'r' && p != 'w' && p != 'm' {
+				fatalf("invalid device permissions %q", permissions)
+			}
+		}
+		device, err := devices.DeviceFromPath(path, permissions)
+		if err != nil {
+			fatal(err)
+		}
+		if err := container.AddDevice(device); err != nil {
+			fatal(err)
+		}
+	},
+}
+
+var deviceRemoveCommand = cli.Command{
+	Name:  "remove",
+	Usage: "remove a device from a running container",
+	ArgsUsage: `<container-id> <path>
+
+Where "<container-id>" is the name for the instance of the container and
+"<path>" is the path of the device node inside the container.`,
+	Description: `The remove command denies the device in the devices cgroup of the container
+and removes its node from the rootfs. The devices created in every container,
+such as /dev/null, cannot be removed.`,
+	Action: func(context *cli.Context) {
+		container, err := getContainer(context)
+		if err != nil {
+			fatal(err)
+		}
+		path := context.Args().Get(1)
+		if path == "" {
+			fatal(fmt.Errorf("path of the device must be specified"))
+		}
+		if err := container.RemoveDevice(path); err != nil {
+			fatal(err)
+		}
+	},
+}
@@ -4,7 +4,9 @@ package main
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
+	"path/filepath"
 	"sync"
 	"time"
 
@@ -28,6 +30,17 @@ type stats struct {
 	Pids    pids               `json:"pids"`
 	Blkio   blkio              `json:"blkio"`
 	Hugetlb map[string]hugetlb `json:"hugetlb"`
+	Perf    *perf              `json:"perf,omitempty"`
+}
+
+type perf struct {
+	// Units: nanoseconds.
+	TaskClock       uint64 `json:"taskClock"`
+	ContextSwitches uint64 `json:"contextSwitches"`
+	CpuMigrations   uint64 `json:"cpuMigrations"`
+	PageFaults      uint64 `json:"pageFaults"`
+	MajorFaults     uint64 `json:"majorFaults"`
+	MinorFaults     uint64 `json:"minorFaults"`
 }
 
 type hugetlb struct {
@@ -43,15 +56,32 @@ type blkioEntry struct {
 	Value uint64 `json:"value,omitempty"`
 }
 
+// blkioDevice are the IO stats of a block device, with the rates of its IO
+// per second since the previous stats, which the first stats have none of.
+type blkioDevice struct {
+	Major          uint64   `json:"major"`
+	Minor          uint64   `json:"minor"`
+	Name           string   `json:"name,omitempty"`
+	ReadBytes      uint64   `json:"readBytes"`
+	WriteBytes     uint64   `json:"writeBytes"`
+	ReadIOs        uint64   `json:"readIOs"`
+	WriteIOs       uint64   `json:"writeIOs"`
+	ReadBytesRate  *float64 `json:"readBytesRate,omitempty"`
+	WriteBytesRate *float64 `json:"writeBytesRate,omitempty"`
+	ReadIOPS       *float64 `json:"readIOPS,omitempty"`
+	WriteIOPS      *float64 `json:"writeIOPS,omitempty"`
+}
+
 type blkio struct {
-	IoServiceBytesRecursive []blkioEntry `json:"ioServiceBytesRecursive,omitempty"`
-	IoServicedRecursive     []blkioEntry `json:"ioServicedRecursive,omitempty"`
-	IoQueuedRecursive       []blkioEntry `json:"ioQueueRecursive,omitempty"`
-	IoServiceTimeRecursive  []blkioEntry `json:"ioServiceTimeRecursive,omitempty"`
-	IoWaitTimeRecursive     []blkioEntry `json:"ioWaitTimeRecursive,omitempty"`
-	IoMergedRecursive       []blkioEntry `json:"ioMergedRecursive,omitempty"`
-	IoTimeRecursive         []blkioEntry `json:"ioTimeRecursive,omitempty"`
-	SectorsRecursive        []blkioEntry `json:"sectorsRecursive,omitempty"`
+	Devices                 []blkioDevice `json:"devices,omitempty"`
+	IoServiceBytesRecursive []blkioEntry  `json:"ioServiceBytesRecursive,omitempty"`
+	IoServicedRecursive     []blkioEntry  `json:"ioServicedRecursive,omitempty"`
+	IoQueuedRecursive       []blkioEntry  `json:"ioQueueRecursive,omitempty"`
+	IoServiceTimeRecursive  []blkioEntry  `json:"ioServiceTimeRecursive,omitempty"`
+	IoWaitTimeRecursive     []blkioEntry  `json:"ioWaitTimeRecursive,omitempty"`
+	IoMergedRecursive       []blkioEntry  `json:"ioMergedRecursive,omitempty"`
+	IoTimeRecursive         []blkioEntry  `json:"ioTimeRecursive,omitempty"`
+	SectorsRecursive        []blkioEntry  `json:"sectorsRecursive,omitempty"`
 }
 
 type pids struct {
@@ -105,6 +135,7 @@ information is displayed once every 5 seconds.`,
 	Flags: []cli.Flag{
 		cli.DurationFlag{Name: "interval", Value: 5 * time.Second, Usage: "set the stats collection interval"},
 		cli.BoolFlag{Name: "stats", Usage: "display the container's stats then exit"},
+		cli.BoolFlag{Name: "perf", Usage: "include software perf counters of the container's cgroup in the stats, counting from the start of the command (with --stats, the stats are printed after one interval)"},
 	},
 	Action: func(context *cli.Context) {
 		container, err := getContainer(context)
@@ -118,6 +149,12 @@ information is displayed once every 5 seconds.`,
 		if status == libcontainer.Destroyed {
 			fatalf("container with id %s is not running", container.ID())
 		}
+		if context.Bool("perf") {
+			if err := container.OpenPerfCounters(); err != nil {
+				fatal(err)
+			}
+			defer container.ClosePerfCounters()
+		}
 		var (
 			stats  = make(chan *libcontainer.Stats, 1)
 			events = make(chan *event, 1024)
@@ -134,6 +171,10 @@ information is displayed once every 5 seconds.`,
 			}
 		}()
 		if context.Bool("stats") {
+			if context.Bool("perf") {
+				// Let the counters count the events of one interval.
+				time.Sleep(context.Duration("interval"))
+			}
 			s, err := container.Stats()
 			if err != nil {
 				fatal(err)
@@ -157,6 +198,10 @@ information is displayed once every 5 seconds.`,
 		if err != nil {
 			fatal(err)
 		}
+		var (
+			lastDevices []blkioDevice
+			lastTime    time.Time
+		)
 		for {
 			select {
 			case _, ok := <-n:
@@ -169,7 +214,13 @@ information is displayed once every 5 seconds.`,
 					n = nil
 				}
 			case s := <-stats:
-				events <- &event{Type: "stats", ID: container.ID(), Data: convertLibcontainerStats(s)}
+				now := time.Now()
+				cs := convertLibcontainerStats(s)
+				if cs != nil {
+					setBlkioRates(cs.Blkio.Devices, lastDevices, now.Sub(lastTime))
+					lastDevices, lastTime = cs.Blkio.Devices, now
+				}
+				events <- &event{Type: "stats", ID: container.ID(), Data: cs}
 			}
 			if n == nil {
 				close(events)
@@ -204,6 +255,7 @@ func convertLibcontainerStats(ls *libcontainer.Stats) *stats {
 	s.Memory.Usage = convertMemoryEntry(cg.MemoryStats.Usage)
 	s.Memory.Raw = cg.MemoryStats.Stats
 
+	s.Blkio.Devices = convertBlkioDevices(cg.BlkioStats)
 	s.Blkio.IoServiceBytesRecursive = convertBlkioEntry(cg.BlkioStats.IoServiceBytesRecursive)
 	s.Blkio.IoServicedRecursive = convertBlkioEntry(cg.BlkioStats.IoServicedRecursive)
 	s.Blkio.IoQueuedRecursive = convertBlkioEntry(cg.BlkioStats.IoQueuedRecursive)
@@ -217,6 +269,17 @@ func convertLibcontainerStats(ls *libcontainer.Stats) *stats {
 	for k, v := range cg.HugetlbStats {
 		s.Hugetlb[k] = convertHugtlb(v)
 	}
+
+	if p := cg.PerfEventStats; p != nil {
+		s.Perf = &perf{
+			TaskClock:       p.TaskClock,
+			ContextSwitches: p.ContextSwitches,
+			CpuMigrations:   p.CpuMigrations,
+			PageFaults:      p.PageFaults,
+			MajorFaults:     p.MajorFaults,
+			MinorFaults:     p.MinorFaults,
+		}
+	}
 	return &s
 }
 
@@ -249,3 +312,82 @@ func convertBlkioEntry(c []cgroups.BlkioStatEntry) []blkioEntry {
 	}
 	return out
 }
+
+// convertBlkioDevices returns the bytes and IOs read and written by each of the
+// devices of the blkio stats.
+func convertBlkioDevices(c cgroups.BlkioStats) []blkioDevice {
+	var (
+		devices []blkioDevice
+		index   = make(map[[2]uint64]int)
+	)
+	device := func(e cgroups.BlkioStatEntry) *blkioDevice {
+		key := [2]uint64{e.Major, e.Minor}
+		i, ok := index[key]
+		if !ok {
+			i = len(devices)
+			index[key] = i
+			devices = append(devices, blkioDevice{
+				Major: e.Major,
+				Minor: e.Minor,
+				Name:  blockDeviceName(e.Major, e.Minor),
+			})
+		}
+		return &devices[i]
+	}
+	for _, e := range c.IoServiceBytesRecursive {
+		switch e.Op {
+		case "Read":
+			device(e).ReadBytes = e.Value
+		case "Write":
+			device(e).WriteBytes = e.Value
+		}
+	}
+	for _, e := range c.IoServicedRecursive {
+		switch e.Op {
+		case "Read":
+			device(e).ReadIOs = e.Value
+		case "Write":
+			device(e).WriteIOs = e.Value
+		}
+	}
+	return devices
+}
+
+// setBlkioRates sets the IO rates of devices from the difference with their
+// last stats, taken elapsed before.
+func setBlkioRates(devices, last []blkioDevice, elapsed time.Duration) {
+	seconds := elapsed.Seconds()
+	if seconds <= 0 {
+		return
+	}
+	rate := func(cur, prev uint64) *float64 {
+		var r float64
+		// The stats may have been reset since.
+		if cur >= prev {
+			r = float64(cur-prev) / seconds
+		}
+		return &r
+	}
+	for i := range devices {
+		d := &devices[i]
+		for _, l := range last {
+			if l.Major == d.Major && l.Minor == d.Minor {
+				d.ReadBytesRate = rate(d.ReadBytes, l.ReadBytes)
+				d.WriteBytesRate = rate(d.WriteBytes, l.WriteBytes)
+				d.ReadIOPS = rate(d.ReadIOs, l.ReadIOs)
+				d.WriteIOPS = rate(d.WriteIOs, l.WriteIOs)
+				break
+			}
+		}
+	}
+}
+
+// blockDeviceName returns the name of the block device major:minor, such as
+// sda, from the link to it in /sys/dev/block, or "" if it has none.
+func blockDeviceName(major, minor uint64) string {
+	target, err := os.Readlink(fmt.Sprintf("/sys/dev/block/%d:%d", major, minor))
+	if err != nil {
+		return ""
+	}
+	return filepath.Base(target)
+}
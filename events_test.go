@@ -0,0 +1,38 @@
+// +build linux
+
+package main
+
+import (
+	"testing"
+
+	"github.com/opencontainers/runc/libcontainer"
+	"github.com/opencontainers/runc/libcontainer/cgroups"
+)
+
+func TestConvertLibcontainerStatsPerf(t *testing.T) {
+	ls := &libcontainer.Stats{CgroupStats: cgroups.NewStats()}
+	if s := convertLibcontainerStats(ls); s.Perf != nil {
+		t.Errorf("expected no perf stats without perf counters, got %+v", s.Perf)
+	}
+
+	ls.CgroupStats.PerfEventStats = &cgroups.PerfEventStats{
+		TaskClock:       1,
+		ContextSwitches: 2,
+		CpuMigrations:   3,
+		PageFaults:      4,
+		MajorFaults:     5,
+		MinorFaults:     6,
+	}
+	s := convertLibcontainerStats(ls)
+	expected := perf{
+		TaskClock:       1,
+		ContextSwitches: 2,
+		CpuMigrations:   3,
+		PageFaults:      4,
+		MajorFaults:     5,
+		MinorFaults:     6,
+	}
+	if s.Perf == nil || *s.Perf != expected {
+		t.Errorf("expected the perf stats %+v, got %+v", expected, s.Perf)
+	}
+}
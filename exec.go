@@ -10,6 +10,10 @@ import (
 	"strings"
 
 	"github.com/codegangsta/cli"
+	"github.com/docker/go-units"
+	"github.com/opencontainers/runc/libcontainer"
+	"github.com/opencontainers/runc/libcontainer/configs"
+	"github.com/opencontainers/runc/libcontainer/specconv"
 	"github.com/opencontainers/runc/libcontainer/utils"
 	"github.com/opencontainers/runtime-spec/specs-go"
 )
@@ -81,6 +85,34 @@ following will output a list of processes running in the container:
 			Name:  "no-subreaper",
 			Usage: "disable the use of the subreaper used to reap reparented processes",
 		},
+		cli.StringFlag{
+			Name:  "sched-policy",
+			Usage: "set the scheduling policy of the process (SCHED_OTHER, SCHED_BATCH, SCHED_IDLE, SCHED_FIFO, SCHED_RR or SCHED_DEADLINE)",
+		},
+		cli.IntFlag{
+			Name:  "sched-priority",
+			Usage: "set the static priority of the process, for SCHED_FIFO and SCHED_RR",
+		},
+		cli.IntFlag{
+			Name:  "nice",
+			Usage: "set the nice value of the process",
+		},
+		cli.StringFlag{
+			Name:  "ioprio-class",
+			Usage: "set the I/O scheduling class of the process (IOPRIO_CLASS_RT, IOPRIO_CLASS_BE or IOPRIO_CLASS_IDLE)",
+		},
+		cli.IntFlag{
+			Name:  "ioprio-level",
+			Usage: "set the I/O priority level of the process within its class, from 0 (highest) to 7",
+		},
+		cli.StringFlag{
+			Name:  "cpu-affinity",
+			Usage: "set the list of CPUs the process is allowed to run on (e.g. 0-3,7)",
+		},
+		cli.StringFlag{
+			Name:  "cgroup-limits",
+			Usage: "run the process in a sub-cgroup of the container with its own limits (e.g. name=debug,memory=256m,cpu-quota=50000,pids=64)",
+		},
 	},
 	Action: func(context *cli.Context) {
 		if os.Geteuid() != 0 {
@@ -109,7 +141,7 @@ func execProcess(context *cli.Context) (int, error) {
 		return -1, err
 	}
 	bundle := utils.SearchLabels(state.Config.Labels, "bundle")
-	p, err := getProcess(context, bundle)
+	p, cgroup, err := getProcess(context, bundle)
 	if err != nil {
 		return -1, err
 	}
@@ -121,29 +153,166 @@ func execProcess(context *cli.Context) (int, error) {
 		detach:          detach,
 		pidFile:         context.String("pid-file"),
 	}
+	if cgroup != nil {
+		// The sub-cgroup is removed by runc once the process exits.
+		if detach {
+			return -1, fmt.Errorf("a process in a sub-cgroup cannot be detached")
+		}
+		r.subCgroup = cgroup.subCgroup()
+	}
 	return r.run(p)
 }
 
-func getProcess(context *cli.Context, bundle string) (*specs.Process, error) {
+// execProcessSpec is the process.json of the process to execute, with the
+// sub-cgroup to run it in.
+type execProcessSpec struct {
+	specconv.Process
+	Cgroup *execCgroup `json:"cgroup,omitempty"`
+}
+
+// execCgroup is a sub-cgroup of the container, with memory, cpu and pids
+// limits as in the resources of the spec.
+type execCgroup struct {
+	Name   string        `json:"name,omitempty"`
+	Memory *specs.Memory `json:"memory,omitempty"`
+	CPU    *specs.CPU    `json:"cpu,omitempty"`
+	Pids   *specs.Pids   `json:"pids,omitempty"`
+}
+
+// parseCgroupLimits parses the limits of --cgroup-limits, a comma separated
+// list of key=value pairs.
+func parseCgroupLimits(limits string) (*execCgroup, error) {
+	c := &execCgroup{}
+	for _, limit := range strings.Split(limits, ",") {
+		kv := strings.SplitN(limit, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid cgroup limit %q, expected key=value", limit)
+		}
+		key, value := kv[0], kv[1]
+		if key == "name" {
+			c.Name = value
+			continue
+		}
+		if key == "pids" {
+			v, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid cgroup limit %q: %v", limit, err)
+			}
+			c.Pids = &specs.Pids{Limit: &v}
+			continue
+		}
+		var (
+			v   uint64
+			err error
+		)
+		if strings.HasPrefix(key, "memory") {
+			var n int64
+			if n, err = units.RAMInBytes(value); err == nil {
+				v = uint64(n)
+			}
+		} else {
+			v, err = strconv.ParseUint(value, 10, 64)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid cgroup limit %q: %v", limit, err)
+		}
+		if c.Memory == nil && strings.HasPrefix(key, "memory") {
+			c.Memory = &specs.Memory{}
+		}
+		if c.CPU == nil && strings.HasPrefix(key, "cpu") {
+			c.CPU = &specs.CPU{}
+		}
+		switch key {
+		case "memory":
+			c.Memory.Limit = &v
+		case "memory-reservation":
+			c.Memory.Reservation = &v
+		case "memory-swap":
+			c.Memory.Swap = &v
+		case "cpu-shares":
+			c.CPU.Shares = &v
+		case "cpu-quota":
+			c.CPU.Quota = &v
+		case "cpu-period":
+			c.CPU.Period = &v
+		default:
+			return nil, fmt.Errorf("unknown cgroup limit %q", key)
+		}
+	}
+	return c, nil
+}
+
+// subCgroup returns the libcontainer sub-cgroup for c. If c has no name, it is
+// named after the pid of runc, which is unique to the process.
+func (c *execCgroup) subCgroup() *libcontainer.SubCgroup {
+	sub := &libcontainer.SubCgroup{
+		Name:      c.Name,
+		Resources: &configs.Resources{},
+	}
+	if sub.Name == "" {
+		sub.Name = fmt.Sprintf("exec-%d", os.Getpid())
+	}
+	r := sub.Resources
+	if m := c.Memory; m != nil {
+		if m.Limit != nil {
+			r.Memory = int64(*m.Limit)
+		}
+		if m.Reservation != nil {
+			r.MemoryReservation = int64(*m.Reservation)
+		}
+		if m.Swap != nil {
+			r.MemorySwap = int64(*m.Swap)
+		}
+	}
+	if cpu := c.CPU; cpu != nil {
+		if cpu.Shares != nil {
+			r.CpuShares = int64(*cpu.Shares)
+		}
+		if cpu.Quota != nil {
+			r.CpuQuota = int64(*cpu.Quota)
+		}
+		if cpu.Period != nil {
+			r.CpuPeriod = int64(*cpu.Period)
+		}
+	}
+	if c.Pids != nil && c.Pids.Limit != nil {
+		r.PidsLimit = *c.Pids.Limit
+	}
+	return sub
+}
+
+func getProcess(context *cli.Context, bundle string) (*specconv.Process, *execCgroup, error) {
+	var (
+		cgroup *execCgroup
+		err    error
+	)
+	if limits := context.String("cgroup-limits"); limits != "" {
+		if cgroup, err = parseCgroupLimits(limits); err != nil {
+			return nil, nil, err
+		}
+	}
 	if path := context.String("process"); path != "" {
 		f, err := os.Open(path)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		defer f.Close()
-		var p specs.Process
+		var p execProcessSpec
 		if err := json.NewDecoder(f).Decode(&p); err != nil {
-			return nil, err
+			return nil, nil, err
+		}
+		if cgroup == nil {
+			cgroup = p.Cgroup
 		}
-		return &p, validateProcessSpec(&p)
+		return &p.Process, cgroup, validateProcessSpec(&p.Process.Process)
 	}
 	// process via cli flags
 	if err := os.Chdir(bundle); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	spec, err := loadSpec(specConfig)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	p := spec.Process
 	p.Args = context.Args()[1:]
@@ -171,21 +340,53 @@ func getProcess(context *cli.Context, bundle string) (*specs.Process, error) {
 	if context.IsSet("no-new-privs") {
 		p.NoNewPrivileges = context.Bool("no-new-privs")
 	}
+	if context.IsSet("sched-policy") || context.IsSet("sched-priority") || context.IsSet("nice") {
+		scheduler := specconv.Scheduler{Policy: specconv.SchedOther}
+		if p.Scheduler != nil {
+			scheduler = *p.Scheduler
+		}
+		if context.IsSet("sched-policy") {
+			scheduler.Policy = specconv.LinuxSchedulerPolicy(context.String("sched-policy"))
+		}
+		if context.IsSet("sched-priority") {
+			scheduler.Priority = int32(context.Int("sched-priority"))
+		}
+		if context.IsSet("nice") {
+			scheduler.Nice = int32(context.Int("nice"))
+		}
+		p.Scheduler = &scheduler
+	}
+	if context.IsSet("ioprio-class") || context.IsSet("ioprio-level") {
+		ioprio := specconv.LinuxIOPriority{Class: specconv.IOPRIO_CLASS_BE, Priority: 4}
+		if p.IOPriority != nil {
+			ioprio = *p.IOPriority
+		}
+		if context.IsSet("ioprio-class") {
+			ioprio.Class = specconv.IOPriorityClass(context.String("ioprio-class"))
+		}
+		if context.IsSet("ioprio-level") {
+			ioprio.Priority = context.Int("ioprio-level")
+		}
+		p.IOPriority = &ioprio
+	}
+	if cpus := context.String("cpu-affinity"); cpus != "" {
+		p.ExecCPUAffinity = &specconv.CPUAffinity{Final: cpus}
+	}
 	// override the user, if passed
 	if context.String("user") != "" {
 		u := strings.SplitN(context.String("user"), ":", 2)
 		if len(u) > 1 {
 			gid, err := strconv.Atoi(u[1])
 			if err != nil {
-				return nil, fmt.Errorf("parsing %s as int for gid failed: %v", u[1], err)
+				return nil, nil, fmt.Errorf("parsing %s as int for gid failed: %v", u[1], err)
 			}
 			p.User.GID = uint32(gid)
 		}
 		uid, err := strconv.Atoi(u[0])
 		if err != nil {
-			return nil, fmt.Errorf("parsing %s as int for uid failed: %v", u[0], err)
+			return nil, nil, fmt.Errorf("parsing %s as int for uid failed: %v", u[0], err)
 		}
 		p.User.UID = uint32(uid)
 	}
-	return &p, nil
+	return &p, cgroup, nil
 }
@@ -0,0 +1,279 @@
+// +build linux
+
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/codegangsta/cli"
+	"github.com/opencontainers/runc/libcontainer"
+	"github.com/opencontainers/runc/libcontainer/cgroups"
+	"github.com/opencontainers/runc/libcontainer/configs"
+)
+
+// gcGracePeriod is how long a container directory without a state has to be
+// left alone, as its container may be still starting.
+const gcGracePeriod = time.Minute
+
+var gcCommand = cli.Command{
+	Name:  "gc",
+	Usage: "clean up the resources left by containers whose init process is gone",
+	Description: `The gc command scans the containers of the root for the ones whose init
+process has exited, or has been replaced by another process with the same pid,
+without the container being deleted, such as after a host crash or a killed
+runc. For each of them it runs the poststop hooks and removes its cgroups and
+its state, as runc delete does, and the host interfaces of its veth networks
+that no other container uses. The directories of containers that failed to start
+before their state was stored are removed too, once they are a minute old, as
+are the CRIU work directories left in the state of running containers by past
+checkpoints and restores.
+
+Only the cgroups recorded in the state of a container are removed: cgroups of
+containers whose state is already gone cannot be told apart from the ones of
+other users of the hierarchy. Instead, the cgroups named after no container of
+the root are reported as possibly leaked, for an administrator to check, among
+the ones of the parent runc creates the cgroups of containers without a
+cgroupsPath in: system.slice with --systemd-cgroup, or else the cgroup runc gc
+runs in. CRIU work directories set with --work-path are left alone.`,
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "report what would be cleaned up without removing anything",
+		},
+	},
+	Action: func(context *cli.Context) {
+		factory, err := loadFactory(context)
+		if err != nil {
+			fatal(err)
+		}
+		root, err := filepath.Abs(context.GlobalString("root"))
+		if err != nil {
+			fatal(err)
+		}
+		list, err := ioutil.ReadDir(root)
+		if err != nil {
+			fatal(err)
+		}
+		var containers []os.FileInfo
+		for _, item := range list {
+			if item.IsDir() {
+				containers = append(containers, item)
+			}
+		}
+		inUse := interfacesInUse(factory, containers)
+		dryRun := context.Bool("dry-run")
+		failed := false
+		for _, item := range containers {
+			if err := gcContainer(factory, filepath.Join(root, item.Name()), item, inUse, dryRun); err != nil {
+				fmt.Fprintf(os.Stderr, "%s: %v\n", item.Name(), err)
+				failed = true
+			}
+		}
+		if err := reportLeakedCgroups(context, containers); err != nil {
+			fmt.Fprintf(os.Stderr, "reporting leaked cgroups: %v\n", err)
+			failed = true
+		}
+		if failed {
+			os.Exit(1)
+		}
+	},
+}
+
+// interfacesInUse returns the host interfaces of the veth networks of the
+// containers whose init process is still there, which the dead containers
+// may have had too, as their names are reused.
+func interfacesInUse(factory libcontainer.Factory, containers []os.FileInfo) map[string]bool {
+	inUse := make(map[string]bool)
+	for _, item := range containers {
+		container, err := factory.Load(item.Name())
+		if err != nil {
+			continue
+		}
+		if status, err := container.Status(); err != nil || status == libcontainer.Destroyed {
+			continue
+		}
+		for _, n := range container.Config().Networks {
+			if n.Type == "veth" && n.HostInterfaceName != "" {
+				inUse[n.HostInterfaceName] = true
+			}
+		}
+	}
+	return inUse
+}
+
+// gcContainer cleans up the container of the directory path if its init
+// process is gone, or its CRIU work directory if it is still there,
+// reporting what it does.
+func gcContainer(factory libcontainer.Factory, path string, info os.FileInfo, inUse map[string]bool, dryRun bool) error {
+	id := info.Name()
+	verb := "removed"
+	if dryRun {
+		verb = "would remove"
+	}
+	container, err := factory.Load(id)
+	if err != nil {
+		lerr, ok := err.(libcontainer.Error)
+		if !ok || lerr.Code() != libcontainer.ContainerNotExists {
+			return err
+		}
+		if time.Since(info.ModTime()) < gcGracePeriod {
+			return nil
+		}
+		if !dryRun {
+			if err := os.RemoveAll(path); err != nil {
+				return err
+			}
+		}
+		fmt.Printf("%s: %s directory %s without state\n", id, verb, path)
+		return nil
+	}
+	status, err := container.Status()
+	if err != nil {
+		return err
+	}
+	if status != libcontainer.Destroyed {
+		return gcCriuWorkDirectory(container, path, dryRun)
+	}
+	state, err := container.State()
+	if err != nil {
+		return err
+	}
+	// What is left of the container has to be found before it is removed.
+	var leftovers []string
+	for _, p := range state.CgroupPaths {
+		if _, err := os.Stat(p); err == nil {
+			leftovers = append(leftovers, "cgroup "+p)
+		}
+	}
+	sort.Strings(leftovers)
+	var networks []*configs.Network
+	for _, n := range state.Config.Networks {
+		if n.Type != "veth" || n.HostInterfaceName == "" || inUse[n.HostInterfaceName] {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join("/sys/class/net", n.HostInterfaceName)); err == nil {
+			networks = append(networks, n)
+		}
+	}
+	fmt.Printf("%s: init process %d is gone\n", id, state.InitProcessPid)
+	if !dryRun {
+		if err := libcontainer.DestroyNetworks(networks); err != nil {
+			return err
+		}
+		if err := container.Destroy(); err != nil {
+			return err
+		}
+	}
+	for _, n := range networks {
+		// An interface that is not the veth of the container is left alone.
+		if _, err := os.Stat(filepath.Join("/sys/class/net", n.HostInterfaceName)); err != nil || dryRun {
+			leftovers = append(leftovers, "interface "+n.HostInterfaceName)
+		}
+	}
+	leftovers = append(leftovers, "state "+path)
+	for _, l := range leftovers {
+		fmt.Printf("%s: %s %s\n", id, verb, l)
+	}
+	return nil
+}
+
+// gcCriuWorkDirectory removes the CRIU work directory of the running container
+// of the directory path, unless a checkpoint or restore of it is running.
+func gcCriuWorkDirectory(container libcontainer.Container, path string, dryRun bool) error {
+	id := container.ID()
+	if dryRun {
+		work := filepath.Join(path, libcontainer.DefaultCriuWorkDirectory)
+		if _, err := os.Stat(work); err == nil {
+			fmt.Printf("%s: would remove CRIU work directory %s\n", id, work)
+		}
+		return nil
+	}
+	work, err := container.RemoveCriuWorkDirectory()
+	if err != nil {
+		if lerr, ok := err.(libcontainer.Error); ok && lerr.Code() == libcontainer.ContainerLocked {
+			return nil
+		}
+		return err
+	}
+	if work != "" {
+		fmt.Printf("%s: removed CRIU work directory %s\n", id, work)
+	}
+	return nil
+}
+
+// reportLeakedCgroups reports the cgroups of the parent runc creates the
+// cgroups of containers without a cgroupsPath in, which are named after none
+// of containers. They may have been left by containers whose state is gone,
+// but they may as well belong to another user of the hierarchy, so they are
+// only reported, with or without --dry-run.
+func reportLeakedCgroups(context *cli.Context, containers []os.FileInfo) error {
+	ids := make(map[string]bool)
+	for _, item := range containers {
+		ids[item.Name()] = true
+	}
+	mounts, err := cgroups.GetCgroupMounts()
+	if err != nil {
+		return err
+	}
+	var (
+		parent string
+		// containerID returns the id of the container runc would have
+		// given the cgroup name to.
+		containerID func(name string) (string, bool)
+	)
+	if context.GlobalBool("systemd-cgroup") {
+		parent = "system.slice"
+		containerID = func(name string) (string, bool) {
+			if !strings.HasPrefix(name, "runc-") || !strings.HasSuffix(name, ".scope") {
+				return "", false
+			}
+			return strings.TrimSuffix(strings.TrimPrefix(name, "runc-"), ".scope"), true
+		}
+	} else {
+		if parent, err = cgroups.GetThisCgroupDir("devices"); err != nil {
+			return err
+		}
+		containerID = func(name string) (string, bool) {
+			// The units of systemd share the hierarchy.
+			for _, suffix := range []string{".slice", ".scope", ".service", ".mount"} {
+				if strings.HasSuffix(name, suffix) {
+					return "", false
+				}
+			}
+			return name, true
+		}
+	}
+	var leaked []string
+	seen := make(map[string]bool)
+	for _, m := range mounts {
+		dir := filepath.Join(m.Mountpoint, parent)
+		list, err := ioutil.ReadDir(dir)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return err
+		}
+		for _, item := range list {
+			path := filepath.Join(dir, item.Name())
+			if !item.IsDir() || seen[path] {
+				continue
+			}
+			seen[path] = true
+			if id, ok := containerID(item.Name()); ok && !ids[id] {
+				leaked = append(leaked, id+": possibly leaked cgroup "+path)
+			}
+		}
+	}
+	sort.Strings(leaked)
+	for _, l := range leaked {
+		fmt.Println(l)
+	}
+	return nil
+}
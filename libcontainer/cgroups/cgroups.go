@@ -39,6 +39,11 @@ type Manager interface {
 
 	// Set the cgroup as configured.
 	Set(container *configs.Config) error
+
+	// Creates the cgroup named name nested in the cgroup set with its own
+	// resources, moves the process with the specified pid to it and returns
+	// the paths of the created cgroups
+	ApplySubCgroup(name string, pid int, resources *configs.Resources) (map[string]string, error)
 }
 
 type NotFoundError struct {
@@ -256,6 +256,53 @@ func (m *Manager) GetAllPids() ([]int, error) {
 	return cgroups.GetAllPids(dir)
 }
 
+func (m *Manager) ApplySubCgroup(name string, pid int, resources *configs.Resources) (map[string]string, error) {
+	return ApplySubCgroup(m.GetPaths(), name, pid, resources)
+}
+
+// subCgroupSubsystems are the subsystems limiting the processes of a cgroup
+// nested in the cgroups of a container.
+var subCgroupSubsystems = []string{"memory", "cpu", "pids"}
+
+// ApplySubCgroup creates the cgroup named name nested in the memory, cpu and
+// pids cgroups at paths, sets resources on it and moves pid to it. The process
+// stays in the cgroups at paths of the other subsystems. It returns the paths
+// of the created cgroups.
+func ApplySubCgroup(paths map[string]string, name string, pid int, resources *configs.Resources) (_ map[string]string, err error) {
+	if name == "" || name != filepath.Base(name) || name == "." || name == ".." {
+		return nil, fmt.Errorf("invalid sub-cgroup name %q", name)
+	}
+	subPaths := make(map[string]string)
+	defer func() {
+		if err != nil {
+			cgroups.RemovePaths(subPaths)
+		}
+	}()
+	config := &configs.Cgroup{Resources: resources}
+	for _, sysName := range subCgroupSubsystems {
+		parent, ok := paths[sysName]
+		if !ok {
+			continue
+		}
+		sys, err := subsystems.Get(sysName)
+		if err != nil {
+			return nil, err
+		}
+		path := filepath.Join(parent, name)
+		if err := os.Mkdir(path, 0755); err != nil {
+			return nil, err
+		}
+		subPaths[sysName] = path
+		if err := sys.Set(path, config); err != nil {
+			return nil, err
+		}
+	}
+	if err := cgroups.EnterPid(subPaths, pid); err != nil {
+		return nil, err
+	}
+	return subPaths, nil
+}
+
 func getCgroupPath(c *configs.Cgroup) (string, error) {
 	d, err := getCgroupData(c, 0)
 	if err != nil {
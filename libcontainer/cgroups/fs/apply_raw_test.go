@@ -3,6 +3,8 @@
 package fs
 
 import (
+	"io/ioutil"
+	"os"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -270,3 +272,65 @@ func TestInvalidAbsoluteCgroupNameAndParent(t *testing.T) {
 		t.Errorf("SECURITY: cgroup path() is outside cgroup mountpoint!")
 	}
 }
+
+func TestApplySubCgroup(t *testing.T) {
+	root, err := ioutil.TempDir("", "cgroup_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	paths := map[string]string{
+		"memory":  filepath.Join(root, "memory"),
+		"pids":    filepath.Join(root, "pids"),
+		"devices": filepath.Join(root, "devices"),
+	}
+	for _, path := range paths {
+		if err := os.Mkdir(path, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, name := range []string{"", ".", "..", "a/b", "../a"} {
+		if _, err := ApplySubCgroup(paths, name, 1234, &configs.Resources{}); err == nil {
+			t.Errorf("expected an error for sub-cgroup name %q", name)
+		}
+	}
+
+	resources := &configs.Resources{
+		Memory:    268435456,
+		PidsLimit: 64,
+	}
+	subPaths, err := ApplySubCgroup(paths, "debug", 1234, resources)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(subPaths) != 2 {
+		t.Fatalf("expected sub-cgroups for memory and pids only, got %v", subPaths)
+	}
+	for sys, file := range map[string]string{
+		"memory": "memory.limit_in_bytes",
+		"pids":   "pids.max",
+	} {
+		if subPaths[sys] != filepath.Join(paths[sys], "debug") {
+			t.Errorf("unexpected path %s of the %s sub-cgroup", subPaths[sys], sys)
+		}
+		value, err := getCgroupParamString(subPaths[sys], file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := map[string]string{"memory": "268435456", "pids": "64"}[sys]
+		if value != expected {
+			t.Errorf("expected %s %s, got %s", file, expected, value)
+		}
+		procs, err := getCgroupParamString(subPaths[sys], CgroupProcesses)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if procs != "1234" {
+			t.Errorf("expected pid 1234 in the %s sub-cgroup, got %s", sys, procs)
+		}
+	}
+	if _, err := ApplySubCgroup(paths, "debug", 1234, resources); err == nil {
+		t.Error("expected an error for an existing sub-cgroup")
+	}
+}
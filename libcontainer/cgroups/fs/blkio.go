@@ -167,9 +167,13 @@ func getBlkioStat(path string) ([]cgroups.BlkioStatEntry, error) {
 func (s *BlkioGroup) GetStats(path string, stats *cgroups.Stats) error {
 	// Try to read CFQ stats available on all CFQ enabled kernels first
 	if blkioStats, err := getBlkioStat(filepath.Join(path, "blkio.io_serviced_recursive")); err == nil && blkioStats != nil {
-		return getCFQStats(path, stats)
+		if err := getCFQStats(path, stats); err != nil {
+			return err
+		}
 	}
-	return getStats(path, stats) // Use generic stats as fallback
+	// The throttling policy accounts the IO to all the devices, whatever their
+	// IO scheduler, so complete the stats with the devices not using CFQ.
+	return getThrottleStats(path, stats)
 }
 
 func getCFQStats(path string, stats *cgroups.Stats) error {
@@ -219,19 +223,43 @@ func getCFQStats(path string, stats *cgroups.Stats) error {
 	return nil
 }
 
-func getStats(path string, stats *cgroups.Stats) error {
+func getThrottleStats(path string, stats *cgroups.Stats) error {
 	var blkioStats []cgroups.BlkioStatEntry
 	var err error
 
-	if blkioStats, err = getBlkioStat(filepath.Join(path, "blkio.throttle.io_service_bytes")); err != nil {
+	if blkioStats, err = getThrottleStat(path, "blkio.throttle.io_service_bytes"); err != nil {
 		return err
 	}
-	stats.BlkioStats.IoServiceBytesRecursive = blkioStats
+	stats.BlkioStats.IoServiceBytesRecursive = mergeBlkioStats(stats.BlkioStats.IoServiceBytesRecursive, blkioStats)
 
-	if blkioStats, err = getBlkioStat(filepath.Join(path, "blkio.throttle.io_serviced")); err != nil {
+	if blkioStats, err = getThrottleStat(path, "blkio.throttle.io_serviced"); err != nil {
 		return err
 	}
-	stats.BlkioStats.IoServicedRecursive = blkioStats
+	stats.BlkioStats.IoServicedRecursive = mergeBlkioStats(stats.BlkioStats.IoServicedRecursive, blkioStats)
 
 	return nil
 }
+
+// getThrottleStat reads the throttle stats in file, from its recursive variant
+// accounting the IO of the sub-cgroups as well if the kernel provides it.
+func getThrottleStat(path, file string) ([]cgroups.BlkioStatEntry, error) {
+	if _, err := os.Stat(filepath.Join(path, file+"_recursive")); err == nil {
+		file += "_recursive"
+	}
+	return getBlkioStat(filepath.Join(path, file))
+}
+
+// mergeBlkioStats returns the entries of stats, followed by the ones of other
+// for the devices stats has no entries for.
+func mergeBlkioStats(stats, other []cgroups.BlkioStatEntry) []cgroups.BlkioStatEntry {
+	devices := make(map[[2]uint64]bool)
+	for _, e := range stats {
+		devices[[2]uint64{e.Major, e.Minor}] = true
+	}
+	for _, e := range other {
+		if !devices[[2]uint64{e.Major, e.Minor}] {
+			stats = append(stats, e)
+		}
+	}
+	return stats
+}
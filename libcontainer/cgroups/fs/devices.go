@@ -3,6 +3,11 @@
 package fs
 
 import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
 	"github.com/opencontainers/runc/libcontainer/cgroups"
 	"github.com/opencontainers/runc/libcontainer/configs"
 	"github.com/opencontainers/runc/libcontainer/system"
@@ -30,43 +35,252 @@ func (s *DevicesGroup) Set(path string, cgroup *configs.Cgroup) error {
 		return nil
 	}
 
-	devices := cgroup.Resources.Devices
-	if len(devices) > 0 {
-		for _, dev := range devices {
-			file := "devices.deny"
-			if dev.Allow {
-				file = "devices.allow"
-			}
-			if err := writeFile(path, file, dev.CgroupString()); err != nil {
-				return err
-			}
-		}
-		return nil
+	list, err := readFile(path, "devices.list")
+	if err != nil {
+		return err
+	}
+	current, err := parseDevicesList(list)
+	if err != nil {
+		return err
+	}
+	target := newDevicesEmulator()
+	for _, rule := range devicesRules(cgroup.Resources) {
+		target.apply(rule)
 	}
-	if !cgroup.Resources.AllowAllDevices {
-		if err := writeFile(path, "devices.deny", "a"); err != nil {
+	for _, rule := range current.transition(target) {
+		file := "devices.deny"
+		if rule.Allow {
+			file = "devices.allow"
+		}
+		if err := writeFile(path, file, rule.CgroupString()); err != nil {
 			return err
 		}
+	}
+	return nil
+}
+
+// devicesRules returns the rules of the devices cgroup configured in r, in
+// the order they are to be applied.
+func devicesRules(r *configs.Resources) []*configs.Device {
+	if len(r.Devices) > 0 {
+		return r.Devices
+	}
+	var (
+		rules  = []*configs.Device{allDevicesRule(r.AllowAllDevices)}
+		except = r.AllowedDevices
+	)
+	if r.AllowAllDevices {
+		except = r.DeniedDevices
+	}
+	for _, dev := range except {
+		rule := *dev
+		rule.Allow = !r.AllowAllDevices
+		rules = append(rules, &rule)
+	}
+	return rules
+}
 
-		for _, dev := range cgroup.Resources.AllowedDevices {
-			if err := writeFile(path, "devices.allow", dev.CgroupString()); err != nil {
-				return err
-			}
+// EmulateDevices returns the state the devices cgroup reaches once the rules
+// configured in r are applied: whether devices are allowed by default, and the
+// rules for the devices that are an exception to it, sorted by type, major and
+// minor.
+func EmulateDevices(r *configs.Resources) (defaultAllow bool, exceptions []*configs.Device) {
+	e := newDevicesEmulator()
+	for _, rule := range devicesRules(r) {
+		e.apply(rule)
+	}
+	for _, key := range sortedDeviceKeys(e.exceptions) {
+		exceptions = append(exceptions, exceptionRule(key, e.exceptions[key], !e.defaultAllow))
+	}
+	return e.defaultAllow, exceptions
+}
+
+func allDevicesRule(allow bool) *configs.Device {
+	return &configs.Device{
+		Type:        'a',
+		Major:       configs.Wildcard,
+		Minor:       configs.Wildcard,
+		Permissions: "rwm",
+		Allow:       allow,
+	}
+}
+
+// deviceKey identifies the devices an exception of the devices cgroup applies
+// to.
+type deviceKey struct {
+	typ   rune
+	major int64
+	minor int64
+}
+
+// devicesEmulator emulates the behaviour of the devices cgroup: a default
+// policy, and exceptions to it with their permissions.
+type devicesEmulator struct {
+	defaultAllow bool
+	exceptions   map[deviceKey]string
+}
+
+func newDevicesEmulator() *devicesEmulator {
+	return &devicesEmulator{
+		defaultAllow: true,
+		exceptions:   make(map[deviceKey]string),
+	}
+}
+
+// apply updates the emulator as the kernel does when rule is written to
+// devices.allow or devices.deny.
+func (e *devicesEmulator) apply(rule *configs.Device) {
+	if rule.Type == 'a' {
+		e.defaultAllow = rule.Allow
+		e.exceptions = make(map[deviceKey]string)
+		return
+	}
+	key := deviceKey{rule.Type, rule.Major, rule.Minor}
+	if rule.Allow == e.defaultAllow {
+		perms := permissionsDifference(e.exceptions[key], rule.Permissions)
+		if perms == "" {
+			delete(e.exceptions, key)
+		} else {
+			e.exceptions[key] = perms
 		}
-		return nil
+		return
+	}
+	e.exceptions[key] = permissionsUnion(e.exceptions[key], rule.Permissions)
+}
+
+// transition returns the rules to write for the cgroup in the state of e to
+// reach the state of target. Only a change of the default policy resets the
+// cgroup with a rule for all devices, which would otherwise deny access to
+// the allowed devices until they are allowed again. As devices.list does not
+// show the exceptions of a cgroup allowing all devices, it is also reset then,
+// which never denies any access.
+func (e *devicesEmulator) transition(target *devicesEmulator) []*configs.Device {
+	var (
+		rules []*configs.Device
+		old   = e.exceptions
+	)
+	if e.defaultAllow || e.defaultAllow != target.defaultAllow {
+		rules = append(rules, allDevicesRule(target.defaultAllow))
+		old = nil
 	}
+	for _, key := range sortedDeviceKeys(old) {
+		if perms := permissionsDifference(old[key], target.exceptions[key]); perms != "" {
+			rules = append(rules, exceptionRule(key, perms, target.defaultAllow))
+		}
+	}
+	for _, key := range sortedDeviceKeys(target.exceptions) {
+		if perms := permissionsDifference(target.exceptions[key], old[key]); perms != "" {
+			rules = append(rules, exceptionRule(key, perms, !target.defaultAllow))
+		}
+	}
+	return rules
+}
 
-	if err := writeFile(path, "devices.allow", "a"); err != nil {
-		return err
+func exceptionRule(key deviceKey, perms string, allow bool) *configs.Device {
+	return &configs.Device{
+		Type:        key.typ,
+		Major:       key.major,
+		Minor:       key.minor,
+		Permissions: perms,
+		Allow:       allow,
+	}
+}
+
+// deviceKeys sorts device keys by type, major and minor.
+type deviceKeys []deviceKey
+
+func (k deviceKeys) Len() int      { return len(k) }
+func (k deviceKeys) Swap(i, j int) { k[i], k[j] = k[j], k[i] }
+func (k deviceKeys) Less(i, j int) bool {
+	a, b := k[i], k[j]
+	if a.typ != b.typ {
+		return a.typ < b.typ
 	}
+	if a.major != b.major {
+		return a.major < b.major
+	}
+	return a.minor < b.minor
+}
 
-	for _, dev := range cgroup.Resources.DeniedDevices {
-		if err := writeFile(path, "devices.deny", dev.CgroupString()); err != nil {
-			return err
+func sortedDeviceKeys(exceptions map[deviceKey]string) []deviceKey {
+	var keys deviceKeys
+	for key := range exceptions {
+		keys = append(keys, key)
+	}
+	sort.Sort(keys)
+	return keys
+}
+
+// permissionsUnion returns the permissions in a or b, in rwm order.
+func permissionsUnion(a, b string) string {
+	var perms string
+	for _, p := range "rwm" {
+		if strings.ContainsRune(a, p) || strings.ContainsRune(b, p) {
+			perms += string(p)
 		}
 	}
+	return perms
+}
 
-	return nil
+// permissionsDifference returns the permissions in a and not in b, in rwm
+// order.
+func permissionsDifference(a, b string) string {
+	var perms string
+	for _, p := range "rwm" {
+		if strings.ContainsRune(a, p) && !strings.ContainsRune(b, p) {
+			perms += string(p)
+		}
+	}
+	return perms
+}
+
+// parseDevicesList returns an emulator in the state devices.list shows: a
+// single "a *:* rwm" entry if all devices are allowed, or the allowed ones.
+func parseDevicesList(list string) (*devicesEmulator, error) {
+	e := newDevicesEmulator()
+	e.defaultAllow = false
+	for _, line := range strings.Split(list, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		rule, err := parseDeviceRule(line)
+		if err != nil {
+			return nil, err
+		}
+		rule.Allow = true
+		e.apply(rule)
+	}
+	return e, nil
+}
+
+// parseDeviceRule parses a rule in the "type major:minor permissions" format
+// of devices.list.
+func parseDeviceRule(s string) (*configs.Device, error) {
+	fields := strings.Fields(s)
+	if len(fields) != 3 || len(fields[0]) != 1 {
+		return nil, fmt.Errorf("invalid devices cgroup rule %q", s)
+	}
+	numbers := strings.Split(fields[1], ":")
+	if len(numbers) != 2 {
+		return nil, fmt.Errorf("invalid devices cgroup rule %q", s)
+	}
+	rule := &configs.Device{
+		Type:        rune(fields[0][0]),
+		Permissions: fields[2],
+	}
+	for i, n := range []*int64{&rule.Major, &rule.Minor} {
+		if numbers[i] == "*" {
+			*n = configs.Wildcard
+			continue
+		}
+		v, err := strconv.ParseInt(numbers[i], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid devices cgroup rule %q: %v", s, err)
+		}
+		*n = v
+	}
+	return rule, nil
 }
 
 func (s *DevicesGroup) Remove(d *cgroupData) error {
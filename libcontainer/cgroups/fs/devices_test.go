@@ -3,6 +3,7 @@
 package fs
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/opencontainers/runc/libcontainer/configs"
@@ -39,6 +40,7 @@ func TestDevicesSetAllow(t *testing.T) {
 
 	helper.writeFileContents(map[string]string{
 		"devices.deny": "a",
+		"devices.list": "a *:* rwm",
 	})
 
 	helper.CgroupData.config.Resources.AllowAllDevices = false
@@ -64,6 +66,7 @@ func TestDevicesSetDeny(t *testing.T) {
 
 	helper.writeFileContents(map[string]string{
 		"devices.allow": "a",
+		"devices.list":  "a *:* rwm",
 	})
 
 	helper.CgroupData.config.Resources.AllowAllDevices = true
@@ -82,3 +85,84 @@ func TestDevicesSetDeny(t *testing.T) {
 		t.Fatal("Got the wrong value, set devices.deny failed.")
 	}
 }
+
+func TestDevicesSetMinimal(t *testing.T) {
+	helper := NewCgroupTestUtil("devices", t)
+	defer helper.cleanup()
+
+	helper.writeFileContents(map[string]string{
+		"devices.list": "c 1:5 rwm\nc 1:3 rwm\n",
+	})
+
+	helper.CgroupData.config.Resources.AllowAllDevices = false
+	helper.CgroupData.config.Resources.AllowedDevices = allowedDevices
+	devices := &DevicesGroup{}
+	if err := devices.Set(helper.CgroupPath, helper.CgroupData.config); err != nil {
+		t.Fatal(err)
+	}
+
+	value, err := getCgroupParamString(helper.CgroupPath, "devices.deny")
+	if err != nil {
+		t.Fatalf("Failed to parse devices.deny - %s", err)
+	}
+	if value != deniedList {
+		t.Fatalf("Got the wrong value %q, only /dev/null should have been denied.", value)
+	}
+	if _, err := getCgroupParamString(helper.CgroupPath, "devices.allow"); err == nil {
+		t.Fatal("No device should have been allowed.")
+	}
+}
+
+func TestDevicesTransition(t *testing.T) {
+	for _, test := range []struct {
+		list     string
+		rules    []*configs.Device
+		expected []string
+	}{
+		{
+			list:     "a *:* rwm",
+			rules:    []*configs.Device{allDevicesRule(false), {Type: 'c', Major: 1, Minor: 3, Permissions: "rwm", Allow: true}},
+			expected: []string{"deny a *:* rwm", "allow c 1:3 rwm"},
+		},
+		{
+			list:     "c 1:3 rwm\nc 1:5 rwm\nc *:* m",
+			rules:    []*configs.Device{allDevicesRule(false), {Type: 'c', Major: 1, Minor: 3, Permissions: "rwm", Allow: true}, {Type: 'c', Major: 1, Minor: 5, Permissions: "rw", Allow: true}, {Type: 'c', Major: 10, Minor: 229, Permissions: "rw", Allow: true}},
+			expected: []string{"deny c *:* m", "deny c 1:5 m", "allow c 10:229 rw"},
+		},
+		{
+			list:     "c 1:3 rwm",
+			rules:    []*configs.Device{allDevicesRule(false), {Type: 'c', Major: 1, Minor: 3, Permissions: "rwm", Allow: true}},
+			expected: nil,
+		},
+		{
+			list:     "c 1:3 rwm",
+			rules:    []*configs.Device{allDevicesRule(true), {Type: 'b', Major: 8, Minor: 0, Permissions: "w"}},
+			expected: []string{"allow a *:* rwm", "deny b 8:0 w"},
+		},
+		{
+			list:     "a *:* rwm",
+			rules:    []*configs.Device{allDevicesRule(true), {Type: 'b', Major: 8, Minor: 0, Permissions: "w"}},
+			expected: []string{"allow a *:* rwm", "deny b 8:0 w"},
+		},
+	} {
+		current, err := parseDevicesList(test.list)
+		if err != nil {
+			t.Fatal(err)
+		}
+		target := newDevicesEmulator()
+		for _, rule := range test.rules {
+			target.apply(rule)
+		}
+		var got []string
+		for _, rule := range current.transition(target) {
+			action := "deny"
+			if rule.Allow {
+				action = "allow"
+			}
+			got = append(got, action+" "+rule.CgroupString())
+		}
+		if !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("transition from %q: expected %q, got %q", test.list, test.expected, got)
+		}
+	}
+}
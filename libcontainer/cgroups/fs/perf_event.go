@@ -3,8 +3,14 @@
 package fs
 
 import (
+	"fmt"
+	"os"
+	"syscall"
+	"unsafe"
+
 	"github.com/opencontainers/runc/libcontainer/cgroups"
 	"github.com/opencontainers/runc/libcontainer/configs"
+	"github.com/opencontainers/runc/libcontainer/system"
 )
 
 type PerfEventGroup struct {
@@ -33,3 +39,91 @@ func (s *PerfEventGroup) Remove(d *cgroupData) error {
 func (s *PerfEventGroup) GetStats(path string, stats *cgroups.Stats) error {
 	return nil
 }
+
+// perfCounterEvents are the software events PerfCounters count, with the
+// stats they are added to.
+var perfCounterEvents = []struct {
+	config uint64
+	stat   func(*cgroups.PerfEventStats) *uint64
+}{
+	{system.PERF_COUNT_SW_TASK_CLOCK, func(s *cgroups.PerfEventStats) *uint64 { return &s.TaskClock }},
+	{system.PERF_COUNT_SW_CONTEXT_SWITCHES, func(s *cgroups.PerfEventStats) *uint64 { return &s.ContextSwitches }},
+	{system.PERF_COUNT_SW_CPU_MIGRATIONS, func(s *cgroups.PerfEventStats) *uint64 { return &s.CpuMigrations }},
+	{system.PERF_COUNT_SW_PAGE_FAULTS, func(s *cgroups.PerfEventStats) *uint64 { return &s.PageFaults }},
+	{system.PERF_COUNT_SW_PAGE_FAULTS_MAJ, func(s *cgroups.PerfEventStats) *uint64 { return &s.MajorFaults }},
+	{system.PERF_COUNT_SW_PAGE_FAULTS_MIN, func(s *cgroups.PerfEventStats) *uint64 { return &s.MinorFaults }},
+}
+
+// PerfCounters count software perf events of the tasks of a perf_event cgroup,
+// with counters opened in cgroup mode on each CPU, as the kernel only counts
+// the events of a cgroup per CPU.
+type PerfCounters struct {
+	// fds are the fds of the counters of each event, on each CPU.
+	fds [][]int
+}
+
+// OpenPerfCounters opens counters of the events of the tasks of the
+// perf_event cgroup at path on cpus, which count from then on.
+func OpenPerfCounters(path string, cpus []int) (_ *PerfCounters, err error) {
+	dir, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer dir.Close()
+	p := &PerfCounters{}
+	defer func() {
+		if err != nil {
+			p.Close()
+		}
+	}()
+	for _, event := range perfCounterEvents {
+		var fds []int
+		for _, cpu := range cpus {
+			attr := &system.PerfEventAttr{
+				Type:   system.PERF_TYPE_SOFTWARE,
+				Config: event.config,
+			}
+			fd, err := system.PerfEventOpen(attr, int(dir.Fd()), cpu, -1, system.PERF_FLAG_PID_CGROUP|system.PERF_FLAG_FD_CLOEXEC)
+			if err != nil {
+				p.fds = append(p.fds, fds)
+				return nil, fmt.Errorf("opening perf counter %d on cpu %d: %v", event.config, cpu, err)
+			}
+			fds = append(fds, fd)
+		}
+		p.fds = append(p.fds, fds)
+	}
+	return p, nil
+}
+
+// GetStats returns the counts of the events summed over all the CPUs.
+func (p *PerfCounters) GetStats() (*cgroups.PerfEventStats, error) {
+	var (
+		stats = &cgroups.PerfEventStats{}
+		count uint64
+	)
+	for i, event := range perfCounterEvents {
+		value := event.stat(stats)
+		for _, fd := range p.fds[i] {
+			// The count is read as a u64 in host byte order.
+			if _, err := syscall.Read(fd, (*[8]byte)(unsafe.Pointer(&count))[:]); err != nil {
+				return nil, fmt.Errorf("reading perf counter %d: %v", event.config, err)
+			}
+			*value += count
+		}
+	}
+	return stats, nil
+}
+
+// Close closes the counters.
+func (p *PerfCounters) Close() error {
+	var err error
+	for _, fds := range p.fds {
+		for _, fd := range fds {
+			if cerr := syscall.Close(fd); err == nil {
+				err = cerr
+			}
+		}
+	}
+	p.fds = nil
+	return err
+}
@@ -0,0 +1,87 @@
+// +build linux
+
+package fs
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"unsafe"
+
+	"github.com/opencontainers/runc/libcontainer/cgroups"
+)
+
+// counterFd returns the read end of a pipe holding count as a perf counter
+// does.
+func counterFd(t *testing.T, count uint64) int {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+	if _, err := w.Write((*[8]byte)(unsafe.Pointer(&count))[:]); err != nil {
+		t.Fatal(err)
+	}
+	fd, err := syscall.Dup(int(r.Fd()))
+	r.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fd
+}
+
+func TestPerfCountersGetStats(t *testing.T) {
+	// Two CPUs for each event, counting 1 and 2 for the first event, 10 and
+	// 20 for the second...
+	p := &PerfCounters{}
+	defer p.Close()
+	for i := range perfCounterEvents {
+		base := uint64(1)
+		for j := 0; j < i; j++ {
+			base *= 10
+		}
+		p.fds = append(p.fds, []int{counterFd(t, base), counterFd(t, 2*base)})
+	}
+	stats, err := p.GetStats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := cgroups.PerfEventStats{
+		TaskClock:       3,
+		ContextSwitches: 30,
+		CpuMigrations:   300,
+		PageFaults:      3000,
+		MajorFaults:     30000,
+		MinorFaults:     300000,
+	}
+	if *stats != expected {
+		t.Errorf("expected the counts summed over the CPUs %+v, got %+v", expected, *stats)
+	}
+}
+
+func TestPerfCountersOpen(t *testing.T) {
+	mnt, root, err := cgroups.FindCgroupMountpointAndRoot("perf_event")
+	if err != nil {
+		t.Skipf("no perf_event cgroup: %v", err)
+	}
+	dir, err := cgroups.GetThisCgroupDir("perf_event")
+	if err != nil {
+		t.Skipf("no perf_event cgroup: %v", err)
+	}
+	path := filepath.Join(mnt, strings.TrimPrefix(dir, root))
+	p, err := OpenPerfCounters(path, []int{0})
+	if err != nil {
+		t.Skipf("perf_event_open is not permitted: %v", err)
+	}
+	if _, err := p.GetStats(); err != nil {
+		t.Error(err)
+	}
+	if err := p.Close(); err != nil {
+		t.Error(err)
+	}
+	if p.fds != nil {
+		t.Error("expected the counters to be forgotten once closed")
+	}
+}
@@ -90,6 +90,18 @@ type HugetlbStats struct {
 	Failcnt uint64 `json:"failcnt"`
 }
 
+// PerfEventStats are the counts of software perf events of the tasks of a
+// cgroup, since the counters were opened.
+type PerfEventStats struct {
+	// Units: nanoseconds.
+	TaskClock       uint64 `json:"task_clock"`
+	ContextSwitches uint64 `json:"context_switches"`
+	CpuMigrations   uint64 `json:"cpu_migrations"`
+	PageFaults      uint64 `json:"page_faults"`
+	MajorFaults     uint64 `json:"major_faults"`
+	MinorFaults     uint64 `json:"minor_faults"`
+}
+
 type Stats struct {
 	CpuStats    CpuStats    `json:"cpu_stats,omitempty"`
 	MemoryStats MemoryStats `json:"memory_stats,omitempty"`
@@ -97,6 +109,8 @@ type Stats struct {
 	BlkioStats  BlkioStats  `json:"blkio_stats,omitempty"`
 	// the map is in the format "size of hugepage: stats of the hugepage"
 	HugetlbStats map[string]HugetlbStats `json:"hugetlb_stats,omitempty"`
+	// only set if perf counters were opened for the cgroup
+	PerfEventStats *PerfEventStats `json:"perf_event_stats,omitempty"`
 }
 
 func NewStats() *Stats {
@@ -46,6 +46,10 @@ func (m *Manager) Set(container *configs.Config) error {
 	return nil, fmt.Errorf("Systemd not supported")
 }
 
+func (m *Manager) ApplySubCgroup(name string, pid int, resources *configs.Resources) (map[string]string, error) {
+	return nil, fmt.Errorf("Systemd not supported")
+}
+
 func (m *Manager) Freeze(state configs.FreezerState) error {
 	return fmt.Errorf("Systemd not supported")
 }
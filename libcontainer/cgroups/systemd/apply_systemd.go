@@ -146,6 +146,8 @@ func UseSystemd() bool {
 
 		// Not critical because of the stop unit logic above.
 		theConn.StopUnit(scope, "replace", nil)
+
+		probeTransientProperties(scope)
 	}
 	return hasStartTransientUnit
 }
@@ -181,7 +183,7 @@ func (m *Manager) Apply(pid int) error {
 
 	properties = append(properties,
 		systemdDbus.PropSlice(slice),
-		systemdDbus.PropDescription("docker container "+c.Name),
+		systemdDbus.PropDescription("libcontainer container "+c.Name),
 		newProp("PIDs", []uint32{uint32(pid)}),
 		// This is only supported on systemd versions 218 and above.
 		newProp("Delegate", true),
@@ -199,20 +201,16 @@ func (m *Manager) Apply(pid int) error {
 			newProp("DefaultDependencies", false))
 	}
 
-	if c.Resources.Memory != 0 {
-		properties = append(properties,
-			newProp("MemoryLimit", uint64(c.Resources.Memory)))
-	}
-
-	if c.Resources.CpuShares != 0 {
-		properties = append(properties,
-			newProp("CPUShares", uint64(c.Resources.CpuShares)))
+	resources, err := resourcesProperties(c.Resources)
+	if err != nil {
+		return err
 	}
-
-	if c.Resources.BlkioWeight != 0 {
-		properties = append(properties,
-			newProp("BlockIOWeight", uint64(c.Resources.BlkioWeight)))
+	properties = append(properties, supportedProperties(resources)...)
+	extra, err := unitProperties(c)
+	if err != nil {
+		return err
 	}
+	properties = append(properties, extra...)
 
 	// We need to set kernel memory before processes join cgroup because
 	// kmem.limit_in_bytes can only be set when the cgroup is empty.
@@ -439,6 +437,17 @@ func (m *Manager) GetStats() (*cgroups.Stats, error) {
 }
 
 func (m *Manager) Set(container *configs.Config) error {
+	// Update the unit first, so that systemd does not revert the cgroups to
+	// the previous limits the next time it applies the settings of the unit.
+	if container.Cgroups.Paths == nil {
+		properties, err := resourcesProperties(container.Cgroups.Resources)
+		if err != nil {
+			return err
+		}
+		if err := theConn.SetUnitProperties(getUnitName(container.Cgroups), true, supportedProperties(properties)...); err != nil {
+			return err
+		}
+	}
 	for _, sys := range subsystems {
 		// Get the subsystem path, but don't error out for not found cgroups.
 		path, err := getSubsystemPath(container.Cgroups, sys.Name())
@@ -459,6 +468,12 @@ func (m *Manager) Set(container *configs.Config) error {
 	return nil
 }
 
+// ApplySubCgroup creates the cgroup in the cgroups of the unit of the
+// container, which are delegated to it.
+func (m *Manager) ApplySubCgroup(name string, pid int, resources *configs.Resources) (map[string]string, error) {
+	return fs.ApplySubCgroup(m.GetPaths(), name, pid, resources)
+}
+
 func getUnitName(c *configs.Cgroup) string {
 	return fmt.Sprintf("%s-%s.scope", c.ScopePrefix, c.Name)
 }
@@ -0,0 +1,312 @@
+// +build linux
+
+package systemd
+
+import (
+	"bufio"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/Sirupsen/logrus"
+	systemdDbus "github.com/coreos/go-systemd/dbus"
+	"github.com/godbus/dbus"
+	"github.com/opencontainers/runc/libcontainer/cgroups/fs"
+	"github.com/opencontainers/runc/libcontainer/configs"
+)
+
+// defaultCpuPeriod is the CFS period systemd applies CPUQuotaPerSecUSec with,
+// unless CPUQuotaPeriodUSec is set.
+const defaultCpuPeriod = 100000
+
+// probedProperties are the properties set by resourcesProperties that only
+// later versions of systemd support, with the values they are probed with.
+var probedProperties = []systemdDbus.Property{
+	// systemd 227
+	newProp("TasksAccounting", true),
+	newProp("TasksMax", uint64(math.MaxUint64)),
+	// systemd 242
+	newProp("CPUQuotaPeriodUSec", uint64(defaultCpuPeriod)),
+	// systemd 244
+	newProp("AllowedCPUs", []byte{0x01}),
+	newProp("AllowedMemoryNodes", []byte{0x01}),
+}
+
+// hasTransientProperty holds which of probedProperties systemd supports on
+// transient units, as probed by UseSystemd.
+var hasTransientProperty = make(map[string]bool)
+
+// probeTransientProperties fills hasTransientProperty by starting scope, which
+// must not exist, with each of probedProperties. Starting it fails anyway as
+// it has no PIDs, but only after systemd checks the properties.
+func probeTransientProperties(scope string) {
+	for _, p := range probedProperties {
+		hasTransientProperty[p.Name] = true
+		if _, err := theConn.StartTransientUnit(scope, "replace", []systemdDbus.Property{p}, nil); err != nil {
+			if dbusError, ok := err.(dbus.Error); ok {
+				if strings.Contains(dbusError.Name, "org.freedesktop.DBus.Error.PropertyReadOnly") {
+					hasTransientProperty[p.Name] = false
+				}
+			}
+		}
+		theConn.StopUnit(scope, "replace", nil)
+	}
+}
+
+// supportedProperties drops the properties of probedProperties that systemd
+// does not support, or that could not be probed, from properties, as systemd
+// would fail to start or update the unit with them. The settings are still
+// written to the cgroups by Set, but systemd may revert them.
+func supportedProperties(properties []systemdDbus.Property) []systemdDbus.Property {
+	var supported []systemdDbus.Property
+	for _, p := range properties {
+		if isProbedProperty(p.Name) && !hasTransientProperty[p.Name] {
+			logrus.Warnf("systemd does not support the %s property, it is only set in the cgroups", p.Name)
+			continue
+		}
+		supported = append(supported, p)
+	}
+	return supported
+}
+
+func isProbedProperty(name string) bool {
+	for _, p := range probedProperties {
+		if p.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// deviceAllow is an entry of the DeviceAllow property of a unit.
+type deviceAllow struct {
+	Path        string
+	Permissions string
+}
+
+// ioDeviceValue is an entry of the BlockIO device properties of a unit.
+type ioDeviceValue struct {
+	Path  string
+	Value uint64
+}
+
+// resourcesProperties returns the properties of the unit of the container
+// that systemd applies to its cgroups in place of r, so that they are not
+// reverted when systemd applies the settings of the unit again. Settings
+// without a systemd equivalent, such as IOPS limits of the blkio controller,
+// are only written to the cgroups.
+func resourcesProperties(r *configs.Resources) ([]systemdDbus.Property, error) {
+	var properties []systemdDbus.Property
+
+	if r.Memory != 0 {
+		properties = append(properties, newProp("MemoryLimit", uint64(r.Memory)))
+	}
+	if r.CpuShares != 0 {
+		properties = append(properties, newProp("CPUShares", uint64(r.CpuShares)))
+	}
+	if r.CpuQuota != 0 {
+		period := uint64(r.CpuPeriod)
+		if period == 0 {
+			period = defaultCpuPeriod
+		} else if period != defaultCpuPeriod {
+			properties = append(properties, newProp("CPUQuotaPeriodUSec", period))
+		}
+		quota := uint64(math.MaxUint64)
+		if r.CpuQuota > 0 {
+			quota = uint64(r.CpuQuota) * 1000000 / period
+		}
+		properties = append(properties, newProp("CPUQuotaPerSecUSec", quota))
+	}
+	if r.PidsLimit != 0 {
+		limit := uint64(math.MaxUint64)
+		if r.PidsLimit > 0 {
+			limit = uint64(r.PidsLimit)
+		}
+		properties = append(properties,
+			newProp("TasksAccounting", true),
+			newProp("TasksMax", limit))
+	}
+	for _, cpuset := range []struct {
+		name, list string
+	}{
+		{"AllowedCPUs", r.CpusetCpus},
+		{"AllowedMemoryNodes", r.CpusetMems},
+	} {
+		if cpuset.list == "" {
+			continue
+		}
+		mask, err := rangeListToMask(cpuset.list)
+		if err != nil {
+			return nil, err
+		}
+		properties = append(properties, newProp(cpuset.name, mask))
+	}
+
+	if r.BlkioWeight != 0 {
+		properties = append(properties, newProp("BlockIOWeight", uint64(r.BlkioWeight)))
+	}
+	var weights []ioDeviceValue
+	for _, wd := range r.BlkioWeightDevice {
+		if wd.Weight != 0 {
+			weights = append(weights, ioDeviceValue{blockDevicePath(wd.Major, wd.Minor), uint64(wd.Weight)})
+		}
+	}
+	if len(weights) > 0 {
+		properties = append(properties, newProp("BlockIODeviceWeight", weights))
+	}
+	for _, throttle := range []struct {
+		name    string
+		devices []*configs.ThrottleDevice
+	}{
+		{"BlockIOReadBandwidth", r.BlkioThrottleReadBpsDevice},
+		{"BlockIOWriteBandwidth", r.BlkioThrottleWriteBpsDevice},
+	} {
+		var values []ioDeviceValue
+		for _, td := range throttle.devices {
+			values = append(values, ioDeviceValue{blockDevicePath(td.Major, td.Minor), td.Rate})
+		}
+		if len(values) > 0 {
+			properties = append(properties, newProp(throttle.name, values))
+		}
+	}
+
+	devices, err := deviceProperties(r)
+	if err != nil {
+		return nil, err
+	}
+	return append(properties, devices...), nil
+}
+
+// deviceProperties returns the DevicePolicy and DeviceAllow properties for the
+// devices cgroup rules of r. As systemd can only allow devices, none are
+// returned for rules denying some devices out of all of them.
+func deviceProperties(r *configs.Resources) ([]systemdDbus.Property, error) {
+	defaultAllow, exceptions := fs.EmulateDevices(r)
+	if defaultAllow {
+		if len(exceptions) > 0 {
+			return nil, nil
+		}
+		return []systemdDbus.Property{
+			newProp("DevicePolicy", "auto"),
+			newProp("DeviceAllow", []deviceAllow{}),
+		}, nil
+	}
+	allow := []deviceAllow{}
+	for _, d := range exceptions {
+		path, err := deviceAllowPath(d)
+		if err != nil {
+			return nil, err
+		}
+		allow = append(allow, deviceAllow{path, d.Permissions})
+	}
+	return []systemdDbus.Property{
+		newProp("DevicePolicy", "strict"),
+		newProp("DeviceAllow", allow),
+	}, nil
+}
+
+// deviceAllowPath returns the path DeviceAllow identifies the devices of the
+// rule d with: the node of a single device, or all the devices of a driver.
+func deviceAllowPath(d *configs.Device) (string, error) {
+	class := "char"
+	if d.Type == 'b' {
+		class = "block"
+	}
+	switch {
+	case d.Major == configs.Wildcard:
+		return class + "-*", nil
+	case d.Minor == configs.Wildcard:
+		name, err := driverName(class, d.Major)
+		if err != nil {
+			return "", err
+		}
+		return class + "-" + name, nil
+	}
+	return fmt.Sprintf("/dev/%s/%d:%d", class, d.Major, d.Minor), nil
+}
+
+// procDevices lists the drivers of the devices of the host.
+var procDevices = "/proc/devices"
+
+// driverName returns the name of the driver of the devices of class with the
+// major number, as listed in /proc/devices.
+func driverName(class string, major int64) (string, error) {
+	f, err := os.Open(procDevices)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	var section string
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		switch line {
+		case "Character devices:":
+			section = "char"
+			continue
+		case "Block devices:":
+			section = "block"
+			continue
+		}
+		fields := strings.Fields(line)
+		if section != class || len(fields) != 2 || fields[0] != strconv.FormatInt(major, 10) {
+			continue
+		}
+		return fields[1], nil
+	}
+	if err := s.Err(); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("no %s device driver with major number %d", class, major)
+}
+
+func blockDevicePath(major, minor int64) string {
+	return fmt.Sprintf("/dev/block/%d:%d", major, minor)
+}
+
+// rangeListToMask converts a list of ranges, such as "0-3,8", to the bitmask
+// of the AllowedCPUs and AllowedMemoryNodes properties.
+func rangeListToMask(list string) ([]byte, error) {
+	var mask []byte
+	for _, r := range strings.Split(list, ",") {
+		bounds := strings.SplitN(strings.TrimSpace(r), "-", 2)
+		start, err := strconv.ParseUint(bounds[0], 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("invalid range list %q: %v", list, err)
+		}
+		end := start
+		if len(bounds) == 2 {
+			if end, err = strconv.ParseUint(bounds[1], 10, 16); err != nil {
+				return nil, fmt.Errorf("invalid range list %q: %v", list, err)
+			}
+		}
+		if end < start {
+			return nil, fmt.Errorf("invalid range list %q", list)
+		}
+		for i := start; i <= end; i++ {
+			for uint64(len(mask)) <= i/8 {
+				mask = append(mask, 0)
+			}
+			mask[i/8] |= 1 << (i % 8)
+		}
+	}
+	return mask, nil
+}
+
+// unitProperties returns the properties of c to pass as is to systemd.
+func unitProperties(c *configs.Cgroup) ([]systemdDbus.Property, error) {
+	var properties []systemdDbus.Property
+	for _, p := range c.SystemdProperties {
+		value, err := dbus.ParseVariant(p.Value, dbus.Signature{})
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q of systemd property %s: %v", p.Value, p.Name, err)
+		}
+		properties = append(properties, systemdDbus.Property{
+			Name:  p.Name,
+			Value: value,
+		})
+	}
+	return properties, nil
+}
@@ -0,0 +1,131 @@
+// +build linux
+
+package systemd
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	systemdDbus "github.com/coreos/go-systemd/dbus"
+	"github.com/opencontainers/runc/libcontainer/configs"
+)
+
+func TestRangeListToMask(t *testing.T) {
+	for list, expected := range map[string][]byte{
+		"0":       {0x01},
+		"0-3,8":   {0x0f, 0x01},
+		"1,3-4":   {0x1a},
+		"15":      {0x00, 0x80},
+		"2-2, 16": {0x04, 0x00, 0x01},
+	} {
+		mask, err := rangeListToMask(list)
+		if err != nil {
+			t.Errorf("%q: %v", list, err)
+			continue
+		}
+		if !bytes.Equal(mask, expected) {
+			t.Errorf("%q: expected mask %x, got %x", list, expected, mask)
+		}
+	}
+	for _, list := range []string{"", "a", "3-1", "1-", "0-65536"} {
+		if _, err := rangeListToMask(list); err == nil {
+			t.Errorf("%q: expected an error", list)
+		}
+	}
+}
+
+func TestResourcesProperties(t *testing.T) {
+	r := &configs.Resources{
+		CpuQuota:   50000,
+		CpuPeriod:  100000,
+		PidsLimit:  -1,
+		CpusetCpus: "0-1",
+		BlkioThrottleReadBpsDevice: []*configs.ThrottleDevice{
+			configs.NewThrottleDevice(8, 0, 1048576),
+		},
+		Devices: []*configs.Device{
+			{Type: 'a', Major: configs.Wildcard, Minor: configs.Wildcard, Permissions: "rwm", Allow: false},
+			{Type: 'c', Major: 1, Minor: 3, Permissions: "rwm", Allow: true},
+			{Type: 'c', Major: 1, Minor: 3, Permissions: "m", Allow: false},
+			{Type: 'b', Major: configs.Wildcard, Minor: configs.Wildcard, Permissions: "m", Allow: true},
+		},
+	}
+	properties, err := resourcesProperties(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	values := make(map[string]interface{})
+	for _, p := range properties {
+		values[p.Name] = p.Value.Value()
+	}
+	if v := values["CPUQuotaPerSecUSec"]; v != uint64(500000) {
+		t.Errorf("expected CPUQuotaPerSecUSec 500000, got %v", v)
+	}
+	if _, ok := values["CPUQuotaPeriodUSec"]; ok {
+		t.Error("unexpected CPUQuotaPeriodUSec for the default period")
+	}
+	if v := values["TasksMax"]; v != ^uint64(0) {
+		t.Errorf("expected infinite TasksMax, got %v", v)
+	}
+	if v, ok := values["AllowedCPUs"].([]byte); !ok || !bytes.Equal(v, []byte{0x03}) {
+		t.Errorf("expected AllowedCPUs 03, got %v", values["AllowedCPUs"])
+	}
+	if v, ok := values["BlockIOReadBandwidth"].([]ioDeviceValue); !ok || len(v) != 1 || v[0] != (ioDeviceValue{"/dev/block/8:0", 1048576}) {
+		t.Errorf("unexpected BlockIOReadBandwidth %v", values["BlockIOReadBandwidth"])
+	}
+	if v := values["DevicePolicy"]; v != "strict" {
+		t.Errorf("expected DevicePolicy strict, got %v", v)
+	}
+	allow, ok := values["DeviceAllow"].([]deviceAllow)
+	if !ok || len(allow) != 2 || allow[0] != (deviceAllow{"block-*", "m"}) || allow[1] != (deviceAllow{"/dev/char/1:3", "rw"}) {
+		t.Errorf("unexpected DeviceAllow %v", values["DeviceAllow"])
+	}
+}
+
+func TestDevicePropertiesAllowAll(t *testing.T) {
+	properties, err := deviceProperties(&configs.Resources{AllowAllDevices: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(properties) != 2 || properties[0].Value.Value() != "auto" {
+		t.Errorf("expected DevicePolicy auto, got %v", properties)
+	}
+	properties, err = deviceProperties(&configs.Resources{
+		AllowAllDevices: true,
+		DeniedDevices: []*configs.Device{
+			{Type: 'c', Major: 1, Minor: 3, Permissions: "rwm"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(properties) != 0 {
+		t.Errorf("expected no device properties when denying devices, got %v", properties)
+	}
+}
+
+func TestSupportedProperties(t *testing.T) {
+	defer func(has map[string]bool) {
+		hasTransientProperty = has
+	}(hasTransientProperty)
+	// TasksMax is supported, CPUQuotaPeriodUSec is not and AllowedCPUs was
+	// not probed.
+	hasTransientProperty = map[string]bool{
+		"TasksMax":           true,
+		"CPUQuotaPeriodUSec": false,
+	}
+	properties := supportedProperties([]systemdDbus.Property{
+		newProp("MemoryLimit", uint64(1024)),
+		newProp("TasksMax", uint64(10)),
+		newProp("CPUQuotaPeriodUSec", uint64(50000)),
+		newProp("AllowedCPUs", []byte{0x01}),
+	})
+	var names []string
+	for _, p := range properties {
+		names = append(names, p.Name)
+	}
+	if expected := []string{"MemoryLimit", "TasksMax"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected the properties %v, got %v", expected, names)
+	}
+}
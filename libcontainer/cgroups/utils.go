@@ -9,11 +9,14 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/docker/go-units"
+	"github.com/opencontainers/runc/libcontainer/configs"
 )
 
 const cgroupNamePrefix = "name="
@@ -319,33 +322,94 @@ func EnterPid(cgroupPaths map[string]string, pid int) error {
 	return nil
 }
 
-// RemovePaths iterates over the provided paths removing them.
-// We trying to remove all paths five times with increasing delay between tries.
-// If after all there are not removed cgroups - appropriate error will be
-// returned.
-func RemovePaths(paths map[string]string) (err error) {
-	delay := 10 * time.Millisecond
-	for i := 0; i < 5; i++ {
-		if i != 0 {
-			time.Sleep(delay)
-			delay *= 2
+// removeTimeout is how long RemovePaths retries to remove cgroups that
+// processes are still exiting from.
+var removeTimeout = 5 * time.Second
+
+// RemovePath removes the cgroup at path and all its sub-cgroups, the deepest
+// ones first.
+func RemovePath(path string) error {
+	infos, err := ioutil.ReadDir(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	for _, info := range infos {
+		if info.IsDir() {
+			if err := RemovePath(filepath.Join(path, info.Name())); err != nil {
+				return err
+			}
 		}
+	}
+	if err := syscall.Rmdir(path); err != nil && err != syscall.ENOENT {
+		return &os.PathError{Op: "rmdir", Path: path, Err: err}
+	}
+	return nil
+}
+
+// RemovePaths removes the cgroups at paths with their sub-cgroups. As a
+// cgroup cannot be removed until the processes in it exited, it retries with
+// an increasing delay until removeTimeout passes. The paths of the cgroups
+// that could not be removed are left in paths, and listed in the returned
+// error.
+func RemovePaths(paths map[string]string) error {
+	var (
+		deadline = time.Now().Add(removeTimeout)
+		delay    = 10 * time.Millisecond
+	)
+	for {
 		for s, p := range paths {
-			os.RemoveAll(p)
-			// TODO: here probably should be logging
-			_, err := os.Stat(p)
-			// We need this strange way of checking cgroups existence because
-			// RemoveAll almost always returns error, even on already removed
-			// cgroups
-			if os.IsNotExist(err) {
+			if err := RemovePath(p); err == nil {
 				delete(paths, s)
 			}
 		}
 		if len(paths) == 0 {
 			return nil
 		}
+		if time.Now().Add(delay).After(deadline) {
+			break
+		}
+		time.Sleep(delay)
+		if delay < time.Second {
+			delay *= 2
+		}
+	}
+	leaked := make(map[string]bool)
+	for _, p := range paths {
+		leaked[p] = true
+	}
+	var list []string
+	for p := range leaked {
+		list = append(list, p)
+	}
+	sort.Strings(list)
+	return fmt.Errorf("failed to remove cgroups: %s", strings.Join(list, ", "))
+}
+
+// KillAll freezes the cgroup set of m, sends SIGKILL to all the processes in
+// it and its sub-cgroups, and thaws it for them to exit. It returns the pids
+// of the killed processes.
+func KillAll(m Manager) ([]int, error) {
+	// Freezing keeps the processes from forking new ones while they are
+	// killed, but the freezer cgroup may not be available.
+	frozen := m.Freeze(configs.Frozen) == nil
+	pids, err := m.GetAllPids()
+	if err != nil && !os.IsNotExist(err) {
+		m.Freeze(configs.Thawed)
+		return nil, err
+	}
+	for _, pid := range pids {
+		if err := syscall.Kill(pid, syscall.SIGKILL); err != nil && err != syscall.ESRCH {
+			m.Freeze(configs.Thawed)
+			return nil, fmt.Errorf("killing pid %d: %v", pid, err)
+		}
+	}
+	if err := m.Freeze(configs.Thawed); err != nil && frozen {
+		return pids, err
 	}
-	return fmt.Errorf("Failed to remove paths: %v", paths)
+	return pids, nil
 }
 
 func GetHugePageSize() ([]string, error) {
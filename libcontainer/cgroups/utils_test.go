@@ -4,8 +4,18 @@ package cgroups
 
 import (
 	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
 	"strings"
+	"syscall"
 	"testing"
+	"time"
+
+	"github.com/opencontainers/runc/libcontainer/configs"
 )
 
 const fedoraMountinfo = `15 35 0:3 / /proc rw,nosuid,nodev,noexec,relatime shared:5 - proc proc rw
@@ -190,3 +200,102 @@ func BenchmarkGetCgroupMounts(b *testing.B) {
 		}
 	}
 }
+
+func TestRemovePaths(t *testing.T) {
+	root, err := ioutil.TempDir("", "cgroups_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	defer func(timeout time.Duration) {
+		removeTimeout = timeout
+	}(removeTimeout)
+	removeTimeout = 50 * time.Millisecond
+
+	removed := filepath.Join(root, "removed")
+	leaked := filepath.Join(root, "leaked")
+	for _, dir := range []string{
+		filepath.Join(removed, "a", "b"),
+		filepath.Join(removed, "c"),
+		filepath.Join(leaked, "d"),
+	} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// A file keeps the directory from being removed, as a process does a
+	// cgroup.
+	if err := ioutil.WriteFile(filepath.Join(leaked, "d", "busy"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	paths := map[string]string{
+		"memory": removed,
+		"cpu":    leaked,
+		"pids":   filepath.Join(root, "missing"),
+	}
+	err = RemovePaths(paths)
+	if err == nil {
+		t.Fatal("expected an error for the leaked cgroup")
+	}
+	if !strings.Contains(err.Error(), leaked) || strings.Contains(err.Error(), removed) {
+		t.Errorf("expected an error reporting only %s, got %v", leaked, err)
+	}
+	if len(paths) != 1 || paths["cpu"] != leaked {
+		t.Errorf("expected only the leaked path left, got %v", paths)
+	}
+	if _, err := os.Stat(removed); !os.IsNotExist(err) {
+		t.Errorf("expected %s to be removed, got %v", removed, err)
+	}
+	if _, err := os.Stat(filepath.Join(leaked, "d", "busy")); err != nil {
+		t.Error(err)
+	}
+}
+
+type killAllManager struct {
+	Manager
+	pids    []int
+	pidsErr error
+	freezes []configs.FreezerState
+}
+
+func (m *killAllManager) GetAllPids() ([]int, error) {
+	return m.pids, m.pidsErr
+}
+
+func (m *killAllManager) Freeze(state configs.FreezerState) error {
+	m.freezes = append(m.freezes, state)
+	return nil
+}
+
+func TestKillAll(t *testing.T) {
+	cmd := exec.Command("sleep", "100")
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+	m := &killAllManager{pids: []int{cmd.Process.Pid}}
+	pids, err := KillAll(m)
+	if err != nil {
+		cmd.Process.Kill()
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(pids, m.pids) {
+		t.Errorf("expected the killed pids %v, got %v", m.pids, pids)
+	}
+	err = cmd.Wait()
+	if status, ok := cmd.ProcessState.Sys().(syscall.WaitStatus); !ok || !status.Signaled() || status.Signal() != syscall.SIGKILL {
+		t.Errorf("expected the process to be killed, got %v", err)
+	}
+	if expected := []configs.FreezerState{configs.Frozen, configs.Thawed}; !reflect.DeepEqual(m.freezes, expected) {
+		t.Errorf("expected the cgroups to be frozen then thawed, got %v", m.freezes)
+	}
+
+	// The cgroups are thawed when the processes cannot be listed.
+	m = &killAllManager{pidsErr: errors.New("no pids")}
+	if _, err := KillAll(m); err != m.pidsErr {
+		t.Errorf("expected the error of the listing, got %v", err)
+	}
+	if expected := []configs.FreezerState{configs.Frozen, configs.Thawed}; !reflect.DeepEqual(m.freezes, expected) {
+		t.Errorf("expected the cgroups to be frozen then thawed, got %v", m.freezes)
+	}
+}
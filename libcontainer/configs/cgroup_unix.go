@@ -29,10 +29,21 @@ type Cgroup struct {
 	// This takes precedence over Path.
 	Paths map[string]string
 
+	// SystemdProperties are properties of the unit of the container passed as
+	// is to systemd, when it manages the cgroups.
+	SystemdProperties []SystemdProperty `json:"systemd_properties,omitempty"`
+
 	// Resources contains various cgroups settings to apply
 	*Resources
 }
 
+// SystemdProperty is a property of a systemd unit, with its value in the
+// GVariant text format, such as "uint64 1024" or "true".
+type SystemdProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
 type Resources struct {
 	// If this is true allow access to any kind of device within the container.  If false, allow access only to devices explicitly listed in the allowed_devices list.
 	// Deprecated
@@ -80,6 +91,15 @@ type Resources struct {
 	// MEM to use
 	CpusetMems string `json:"cpuset_mems"`
 
+	// Number of CPUs to reserve for the container alone out of the CPUs
+	// shared by the containers of the same root. When set, CpusetCpus is
+	// filled in with the reserved CPUs on create.
+	ExclusiveCpus int `json:"exclusive_cpus,omitempty"`
+
+	// Reserve the exclusive CPUs on a single NUMA node and restrict
+	// CpusetMems to that node.
+	ExclusiveCpusNumaLocal bool `json:"exclusive_cpus_numa_local,omitempty"`
+
 	// Process limit; set <= `0' to disable limit.
 	PidsLimit int64 `json:"pids_limit"`
 
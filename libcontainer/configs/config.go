@@ -16,6 +16,46 @@ type Rlimit struct {
 	Soft uint64 `json:"soft"`
 }
 
+// SchedPolicy is a scheduling policy, with the value the kernel uses for it.
+type SchedPolicy int
+
+const (
+	SchedOther    SchedPolicy = 0
+	SchedFIFO     SchedPolicy = 1
+	SchedRR       SchedPolicy = 2
+	SchedBatch    SchedPolicy = 3
+	SchedIdle     SchedPolicy = 5
+	SchedDeadline SchedPolicy = 6
+)
+
+// Scheduler specifies the scheduling attributes of a process, as set by
+// sched_setattr(2). Runtime, Deadline and Period are in nanoseconds and only
+// apply to SchedDeadline.
+type Scheduler struct {
+	Policy   SchedPolicy `json:"policy"`
+	Nice     int         `json:"nice"`
+	Priority int         `json:"priority"`
+	Runtime  uint64      `json:"runtime,omitempty"`
+	Deadline uint64      `json:"deadline,omitempty"`
+	Period   uint64      `json:"period,omitempty"`
+}
+
+// IOPrioClass is an I/O scheduling class, with the value the kernel uses for it.
+type IOPrioClass int
+
+const (
+	IOPrioClassRT   IOPrioClass = 1
+	IOPrioClassBE   IOPrioClass = 2
+	IOPrioClassIdle IOPrioClass = 3
+)
+
+// IOPriority specifies the I/O scheduling class and level of a process, as
+// set by ioprio_set(2).
+type IOPriority struct {
+	Class IOPrioClass `json:"class"`
+	Level int         `json:"level"`
+}
+
 // IDMap represents UID/GID Mappings for User Namespaces.
 type IDMap struct {
 	ContainerID int `json:"container_id"`
@@ -28,9 +68,18 @@ type IDMap struct {
 // for syscalls. Additional architectures can be added by specifying them in
 // Architectures.
 type Seccomp struct {
-	DefaultAction Action     `json:"default_action"`
-	Architectures []string   `json:"architectures"`
-	Syscalls      []*Syscall `json:"syscalls"`
+	DefaultAction   Action        `json:"default_action"`
+	DefaultErrnoRet *uint         `json:"default_errno_ret,omitempty"`
+	Architectures   []string      `json:"architectures"`
+	Flags           []SeccompFlag `json:"flags,omitempty"`
+	Syscalls        []*Syscall    `json:"syscalls"`
+
+	// ListenerPath is the unix socket the listener fd of the filter is sent
+	// to when any syscall uses the Notify action.
+	ListenerPath string `json:"listener_path,omitempty"`
+
+	// ListenerMetadata is passed as is to the agent at ListenerPath.
+	ListenerMetadata string `json:"listener_metadata,omitempty"`
 }
 
 // Action is taken upon rule match in Seccomp
@@ -42,6 +91,18 @@ const (
 	Trap
 	Allow
 	Trace
+	KillProcess
+	Log
+	Notify
+)
+
+// SeccompFlag is a flag passed to the kernel when the Seccomp filter is loaded
+type SeccompFlag int
+
+const (
+	FlagTsync SeccompFlag = iota + 1
+	FlagLog
+	FlagSpecAllow
 )
 
 // Operator is a comparison operator to be used when matching syscall arguments in Seccomp
@@ -66,10 +127,56 @@ type Arg struct {
 }
 
 // Syscall is a rule to match a syscall in Seccomp
+// ErrnoRet optionally overrides the errno returned by the Errno action, or the
+// value passed to the tracer by the Trace action. It defaults to EPERM.
 type Syscall struct {
-	Name   string `json:"name"`
-	Action Action `json:"action"`
-	Args   []*Arg `json:"args"`
+	Name     string `json:"name"`
+	Action   Action `json:"action"`
+	ErrnoRet *uint  `json:"errno_ret,omitempty"`
+	Args     []*Arg `json:"args"`
+}
+
+// EtcFiles describes the /etc/hosts, /etc/hostname and /etc/resolv.conf files
+// generated for a container.
+type EtcFiles struct {
+	// Dir is the directory the files are generated in when the container is
+	// created. The files are only generated for the mounts of the config
+	// whose source is in Dir, which bind mount them in the rootfs.
+	Dir string `json:"dir"`
+
+	// ExtraHosts are added to the generated /etc/hosts.
+	ExtraHosts []HostEntry `json:"extra_hosts,omitempty"`
+
+	// Nameservers, Search and Options make up the generated /etc/resolv.conf.
+	// If none of them is set, the resolv.conf of the host is used instead,
+	// without its loopback nameservers if the container has its own network
+	// namespace; creating the container fails if no nameserver is left.
+	Nameservers []string `json:"nameservers,omitempty"`
+	Search      []string `json:"search,omitempty"`
+	Options     []string `json:"options,omitempty"`
+}
+
+// HostEntry maps a hostname to an address in /etc/hosts.
+type HostEntry struct {
+	Hostname string `json:"hostname"`
+	IP       string `json:"ip"`
+}
+
+// RootfsOverlay describes the layers of an overlay root filesystem.
+type RootfsOverlay struct {
+	// LowerDirs are the read-only layers, the topmost first.
+	LowerDirs []string `json:"lower_dirs"`
+
+	// UpperDir and WorkDir receive the changes made by the container. If they
+	// are not set, the changes go to a tmpfs and are lost with the container.
+	UpperDir string `json:"upper_dir,omitempty"`
+	WorkDir  string `json:"work_dir,omitempty"`
+}
+
+// TimeOffset is the offset of a clock in a time namespace.
+type TimeOffset struct {
+	Secs     int64  `json:"secs"`
+	Nanosecs uint32 `json:"nanosecs"`
 }
 
 // TODO Windows. Many of these fields should be factored out into those parts
@@ -81,6 +188,11 @@ type Config struct {
 	// This is a common option when the container is running in ramdisk
 	NoPivotRoot bool `json:"no_pivot_root"`
 
+	// Init keeps the init of libcontainer as PID 1 of the container: it runs the
+	// process as its child, forwards it the signals it receives, reaps orphaned
+	// processes and exits with the status of the process.
+	Init bool `json:"init,omitempty"`
+
 	// ParentDeathSignal specifies the signal that is sent to the container's process in the case
 	// that the parent process dies.
 	ParentDeathSignal int `json:"parent_death_signal"`
@@ -93,6 +205,10 @@ type Config struct {
 	// Path to a directory containing the container's root filesystem.
 	Rootfs string `json:"rootfs"`
 
+	// RootfsOverlay, if set, assembles the root filesystem from layers with an
+	// overlay mounted on Rootfs in the container's mount namespace.
+	RootfsOverlay *RootfsOverlay `json:"rootfs_overlay,omitempty"`
+
 	// Readonlyfs will remount the container's rootfs as readonly where only externally mounted
 	// bind mounts are writtable.
 	Readonlyfs bool `json:"readonlyfs"`
@@ -112,10 +228,18 @@ type Config struct {
 	// Hostname optionally sets the container's hostname if provided
 	Hostname string `json:"hostname"`
 
+	// EtcFiles, if set, makes the container use /etc/hosts, /etc/hostname and
+	// /etc/resolv.conf files generated when it is created.
+	EtcFiles *EtcFiles `json:"etc_files,omitempty"`
+
 	// Namespaces specifies the container's namespaces that it should setup when cloning the init process
 	// If a namespace is not provided that namespace is shared from the container's parent process
 	Namespaces Namespaces `json:"namespaces"`
 
+	// TimeOffsets specifies the offsets of the clocks in a new time namespace, keyed
+	// by clock name: "monotonic" or "boottime".
+	TimeOffsets map[string]TimeOffset `json:"time_offsets,omitempty"`
+
 	// Capabilities specify the capabilities to keep when executing the process inside the container
 	// All capbilities not specified will be dropped from the processes capability mask
 	Capabilities []string `json:"capabilities"`
@@ -27,4 +27,31 @@ type Mount struct {
 
 	// Optional Command to be run after Source is mounted.
 	PostmountCmds []Command `json:"postmount_cmds"`
+
+	// RecAttrSet and RecAttrClr are mount attributes set and cleared on the
+	// mount and all of its submounts, which flags only apply to the former.
+	RecAttrSet uint64 `json:"rec_attr_set,omitempty"`
+	RecAttrClr uint64 `json:"rec_attr_clr,omitempty"`
+
+	// IDMapping, if set, idmaps a bind mount to the user namespace of the
+	// container.
+	IDMapping *MountIDMapping `json:"id_mapping,omitempty"`
+}
+
+// Mount attributes, as in the MOUNT_ATTR_* flags of mount_setattr(2), that can
+// be set or cleared recursively on a mount.
+const (
+	MountAttrRdonly      = 0x00000001
+	MountAttrNosuid      = 0x00000002
+	MountAttrNodev       = 0x00000004
+	MountAttrNoexec      = 0x00000008
+	MountAttrNosymfollow = 0x00200000
+)
+
+// MountIDMapping makes a bind mount an idmapped mount, whose files owned by
+// host ids appear owned by the ids they map to in the user namespace of the
+// container.
+type MountIDMapping struct {
+	// Recursive applies the mapping to the submounts of the source too.
+	Recursive bool `json:"recursive,omitempty"`
 }
@@ -15,8 +15,13 @@ var namespaceInfo = map[NamespaceType]int{
 	NEWIPC:  syscall.CLONE_NEWIPC,
 	NEWUTS:  syscall.CLONE_NEWUTS,
 	NEWPID:  syscall.CLONE_NEWPID,
+	NEWTIME: cloneNewTime,
 }
 
+// cloneNewTime is CLONE_NEWTIME, which the syscall package does not define. It
+// overlaps the exit signal bits of clone, so nsexec unshares it instead.
+const cloneNewTime = 0x80
+
 // CloneFlags parses the container's Namespaces options to set the correct
 // flags on clone, unshare. This function returns flags only for new namespaces.
 func (n *Namespaces) CloneFlags() uintptr {
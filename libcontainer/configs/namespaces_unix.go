@@ -15,6 +15,7 @@ const (
 	NEWUTS  NamespaceType = "NEWUTS"
 	NEWIPC  NamespaceType = "NEWIPC"
 	NEWUSER NamespaceType = "NEWUSER"
+	NEWTIME NamespaceType = "NEWTIME"
 )
 
 var (
@@ -37,6 +38,8 @@ func nsToFile(ns NamespaceType) string {
 		return "user"
 	case NEWUTS:
 		return "uts"
+	case NEWTIME:
+		return "time"
 	}
 	return ""
 }
@@ -70,6 +73,7 @@ func NamespaceTypes() []NamespaceType {
 		NEWUTS,
 		NEWIPC,
 		NEWUSER,
+		NEWTIME,
 	}
 }
 
@@ -2,6 +2,7 @@ package validate
 
 import (
 	"fmt"
+	"net"
 	"os"
 	"path/filepath"
 	"strings"
@@ -37,9 +38,21 @@ func (v *ConfigValidator) Validate(config *configs.Config) error {
 	if err := v.usernamespace(config); err != nil {
 		return err
 	}
+	if err := v.timenamespace(config); err != nil {
+		return err
+	}
+	if err := v.mounts(config); err != nil {
+		return err
+	}
+	if err := v.etcFiles(config); err != nil {
+		return err
+	}
 	if err := v.sysctl(config); err != nil {
 		return err
 	}
+	if err := v.seccomp(config); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -56,6 +69,42 @@ func (v *ConfigValidator) rootfs(config *configs.Config) error {
 	if filepath.Clean(config.Rootfs) != cleaned {
 		return fmt.Errorf("%s is not an absolute path or is a symlink", config.Rootfs)
 	}
+	if config.RootfsOverlay != nil {
+		return rootfsOverlay(config)
+	}
+	return nil
+}
+
+// rootfsOverlay validates the layers of an overlay rootfs, which overlayfs
+// takes as a colon and comma separated list.
+func rootfsOverlay(config *configs.Config) error {
+	o := config.RootfsOverlay
+	if !config.Namespaces.Contains(configs.NEWNS) {
+		return fmt.Errorf("an overlay rootfs requires a new mount namespace")
+	}
+	if len(o.LowerDirs) == 0 {
+		return fmt.Errorf("an overlay rootfs requires at least one lower directory")
+	}
+	if (o.UpperDir == "") != (o.WorkDir == "") {
+		return fmt.Errorf("the upper and work directories of an overlay rootfs must be set together")
+	}
+	dirs := append([]string{o.UpperDir, o.WorkDir}, o.LowerDirs...)
+	for i, dir := range dirs {
+		if i < 2 && dir == "" {
+			continue
+		}
+		if !filepath.IsAbs(dir) {
+			return fmt.Errorf("overlay rootfs directory %q is not an absolute path", dir)
+		}
+		if strings.ContainsAny(dir, ":,") {
+			return fmt.Errorf("overlay rootfs directory %q cannot contain ':' or ','", dir)
+		}
+		if fi, err := os.Stat(dir); err != nil {
+			return err
+		} else if !fi.IsDir() {
+			return fmt.Errorf("overlay rootfs directory %q is not a directory", dir)
+		}
+	}
 	return nil
 }
 
@@ -101,6 +150,86 @@ func (v *ConfigValidator) usernamespace(config *configs.Config) error {
 	return nil
 }
 
+func (v *ConfigValidator) timenamespace(config *configs.Config) error {
+	if config.Namespaces.Contains(configs.NEWTIME) {
+		if _, err := os.Stat("/proc/self/ns/time"); os.IsNotExist(err) {
+			return fmt.Errorf("TIME namespaces aren't enabled in the kernel")
+		}
+		if config.Namespaces.PathOf(configs.NEWTIME) != "" && len(config.TimeOffsets) > 0 {
+			return fmt.Errorf("time offsets cannot be set when joining an existing TIME namespace")
+		}
+	} else if len(config.TimeOffsets) > 0 {
+		return fmt.Errorf("time offsets specified, but TIME namespace isn't enabled in the config")
+	}
+	for clock, offset := range config.TimeOffsets {
+		if clock != "monotonic" && clock != "boottime" {
+			return fmt.Errorf("time offset for unknown clock %q, only monotonic and boottime can be offset", clock)
+		}
+		if offset.Nanosecs >= 1e9 {
+			return fmt.Errorf("time offset for %s has more than a second of nanoseconds", clock)
+		}
+	}
+	return nil
+}
+
+// mounts validates the recursive attributes and idmapping of the mounts.
+func (v *ConfigValidator) mounts(config *configs.Config) error {
+	const recAttrs = configs.MountAttrRdonly | configs.MountAttrNosuid | configs.MountAttrNodev |
+		configs.MountAttrNoexec | configs.MountAttrNosymfollow
+	for _, m := range config.Mounts {
+		if (m.RecAttrSet|m.RecAttrClr)&^recAttrs != 0 {
+			return fmt.Errorf("mount %s has unknown recursive attributes", m.Destination)
+		}
+		if m.RecAttrSet&m.RecAttrClr != 0 {
+			return fmt.Errorf("mount %s both sets and clears the same recursive attributes", m.Destination)
+		}
+		if m.IDMapping == nil {
+			continue
+		}
+		if m.Device != "bind" {
+			return fmt.Errorf("mount %s is idmapped but is not a bind mount", m.Destination)
+		}
+		if !config.Namespaces.Contains(configs.NEWUSER) {
+			return fmt.Errorf("mount %s is idmapped, but USER namespace isn't enabled in the config", m.Destination)
+		}
+	}
+	return nil
+}
+
+// etcFiles validates the entries of the generated /etc files, which are bind
+// mounted in the rootfs.
+func (v *ConfigValidator) etcFiles(config *configs.Config) error {
+	e := config.EtcFiles
+	if e == nil {
+		return nil
+	}
+	if !config.Namespaces.Contains(configs.NEWNS) {
+		return fmt.Errorf("generating /etc files requires a new mount namespace")
+	}
+	if !filepath.IsAbs(e.Dir) {
+		return fmt.Errorf("the directory of the generated /etc files %q is not an absolute path", e.Dir)
+	}
+	for _, h := range e.ExtraHosts {
+		if h.Hostname == "" || strings.ContainsAny(h.Hostname, " \t\n") {
+			return fmt.Errorf("invalid extra host name %q", h.Hostname)
+		}
+		if net.ParseIP(h.IP) == nil {
+			return fmt.Errorf("invalid address %q for extra host %q", h.IP, h.Hostname)
+		}
+	}
+	for _, ns := range e.Nameservers {
+		if net.ParseIP(ns) == nil {
+			return fmt.Errorf("invalid nameserver address %q", ns)
+		}
+	}
+	for _, d := range append(e.Search, e.Options...) {
+		if d == "" || strings.ContainsAny(d, " \t\n") {
+			return fmt.Errorf("invalid resolv.conf search domain or option %q", d)
+		}
+	}
+	return nil
+}
+
 // sysctl validates that the specified sysctl keys are valid or not.
 // /proc/sys isn't completely namespaced and depending on which namespaces
 // are specified, a subset of sysctls are permitted.
@@ -136,3 +265,99 @@ func (v *ConfigValidator) sysctl(config *configs.Config) error {
 
 	return nil
 }
+
+// seccomp validates that the seccomp actions, return values and filter flags
+// can be expressed in a filter.
+func (v *ConfigValidator) seccomp(config *configs.Config) error {
+	if config.Seccomp == nil {
+		return nil
+	}
+	if err := validateSeccompAction(config.Seccomp.DefaultAction, config.Seccomp.DefaultErrnoRet); err != nil {
+		return fmt.Errorf("seccomp default action: %s", err)
+	}
+	if config.Seccomp.DefaultAction == configs.Notify {
+		if err := validateSeccompNotify(config.Seccomp, ""); err != nil {
+			return fmt.Errorf("seccomp default action: %s", err)
+		}
+		if !seccompHandlesSendmsg(config.Seccomp) {
+			return fmt.Errorf("seccomp default action can only be notify with an unconditional rule for sendmsg")
+		}
+	}
+	for _, flag := range config.Seccomp.Flags {
+		switch flag {
+		case configs.FlagTsync, configs.FlagLog, configs.FlagSpecAllow:
+		default:
+			return fmt.Errorf("seccomp flag %d is not valid", flag)
+		}
+	}
+	for _, call := range config.Seccomp.Syscalls {
+		if call == nil {
+			return fmt.Errorf("seccomp syscall rule cannot be nil")
+		}
+		if call.Name == "" {
+			return fmt.Errorf("seccomp syscall rule has an empty name")
+		}
+		if err := validateSeccompAction(call.Action, call.ErrnoRet); err != nil {
+			return fmt.Errorf("seccomp syscall %q: %s", call.Name, err)
+		}
+		if call.Action == configs.Notify {
+			if err := validateSeccompNotify(config.Seccomp, call.Name); err != nil {
+				return fmt.Errorf("seccomp syscall %q: %s", call.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// maxErrno is the largest errno the kernel accepts from a seccomp filter,
+// MAX_ERRNO in include/linux/err.h.
+const maxErrno = 4095
+
+// validateSeccompNotify checks that the listener of a filter using the notify
+// action for the syscall name, or by default if name is empty, can be handed
+// over to the seccomp agent.
+func validateSeccompNotify(config *configs.Seccomp, name string) error {
+	if config.ListenerPath == "" {
+		return fmt.Errorf("the notify action requires a listener path")
+	}
+	if !filepath.IsAbs(config.ListenerPath) {
+		return fmt.Errorf("listener path %s is not absolute", config.ListenerPath)
+	}
+	// runc init passes the listener to the parent with sendmsg once the
+	// filter is loaded, so notifying it would never return.
+	if name == "sendmsg" {
+		return fmt.Errorf("the notify action cannot be used for sendmsg")
+	}
+	return nil
+}
+
+// seccompHandlesSendmsg reports whether sendmsg is never notified whatever the
+// default action of the filter is.
+func seccompHandlesSendmsg(config *configs.Seccomp) bool {
+	for _, call := range config.Syscalls {
+		if call != nil && call.Name == "sendmsg" && len(call.Args) == 0 && call.Action != configs.Notify {
+			return true
+		}
+	}
+	return false
+}
+
+func validateSeccompAction(act configs.Action, errnoRet *uint) error {
+	switch act {
+	case configs.Errno:
+		if errnoRet != nil && *errnoRet > maxErrno {
+			return fmt.Errorf("errno %d is out of range [0, %d]", *errnoRet, maxErrno)
+		}
+	case configs.Trace:
+		if errnoRet != nil && *errnoRet > 0xffff {
+			return fmt.Errorf("trace return value %d does not fit in 16 bits", *errnoRet)
+		}
+	case configs.Kill, configs.KillProcess, configs.Trap, configs.Allow, configs.Log, configs.Notify:
+		if errnoRet != nil {
+			return fmt.Errorf("a return value can only be set for the errno and trace actions")
+		}
+	default:
+		return fmt.Errorf("action %d is not valid", act)
+	}
+	return nil
+}
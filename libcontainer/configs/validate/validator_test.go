@@ -198,3 +198,327 @@ func TestValidateSysctl(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateSeccompErrnoRet(t *testing.T) {
+	enosys := uint(38)
+	config := &configs.Config{
+		Rootfs: "/var",
+		Seccomp: &configs.Seccomp{
+			DefaultAction:   configs.Errno,
+			DefaultErrnoRet: &enosys,
+			Flags:           []configs.SeccompFlag{configs.FlagTsync},
+			Syscalls: []*configs.Syscall{
+				{Name: "mount", Action: configs.Errno, ErrnoRet: &enosys},
+				{Name: "ptrace", Action: configs.Log},
+			},
+		},
+	}
+
+	validator := validate.New()
+	err := validator.Validate(config)
+	if err != nil {
+		t.Errorf("Expected error to not occur: %+v", err)
+	}
+}
+
+func TestValidateSeccompInvalidErrnoRet(t *testing.T) {
+	eperm := uint(1)
+	tooLarge := uint(4096)
+	for _, call := range []*configs.Syscall{
+		{Name: "mount", Action: configs.Allow, ErrnoRet: &eperm},
+		{Name: "mount", Action: configs.Errno, ErrnoRet: &tooLarge},
+		{Name: "mount", Action: configs.Action(42)},
+		{Action: configs.Allow},
+	} {
+		config := &configs.Config{
+			Rootfs: "/var",
+			Seccomp: &configs.Seccomp{
+				DefaultAction: configs.Allow,
+				Syscalls:      []*configs.Syscall{call},
+			},
+		}
+
+		validator := validate.New()
+		err := validator.Validate(config)
+		if err == nil {
+			t.Errorf("Expected error to occur for %+v but it was nil", call)
+		}
+	}
+}
+
+func TestValidateSeccompInvalidFlag(t *testing.T) {
+	config := &configs.Config{
+		Rootfs: "/var",
+		Seccomp: &configs.Seccomp{
+			DefaultAction: configs.Allow,
+			Flags:         []configs.SeccompFlag{configs.SeccompFlag(42)},
+		},
+	}
+
+	validator := validate.New()
+	err := validator.Validate(config)
+	if err == nil {
+		t.Error("Expected error to occur but it was nil")
+	}
+}
+
+func TestValidateSeccompNotify(t *testing.T) {
+	config := &configs.Config{
+		Rootfs: "/var",
+		Seccomp: &configs.Seccomp{
+			DefaultAction:    configs.Allow,
+			ListenerPath:     "/run/seccomp-agent.sock",
+			ListenerMetadata: "foo",
+			Syscalls: []*configs.Syscall{
+				{Name: "mount", Action: configs.Notify},
+			},
+		},
+	}
+
+	validator := validate.New()
+	err := validator.Validate(config)
+	if err != nil {
+		t.Errorf("Expected error to not occur: %+v", err)
+	}
+}
+
+func TestValidateSeccompDefaultNotify(t *testing.T) {
+	config := &configs.Config{
+		Rootfs: "/var",
+		Seccomp: &configs.Seccomp{
+			DefaultAction: configs.Notify,
+			ListenerPath:  "/run/seccomp-agent.sock",
+			Syscalls: []*configs.Syscall{
+				{Name: "sendmsg", Action: configs.Allow},
+			},
+		},
+	}
+
+	validator := validate.New()
+	err := validator.Validate(config)
+	if err != nil {
+		t.Errorf("Expected error to not occur: %+v", err)
+	}
+}
+
+func TestValidateSeccompInvalidNotify(t *testing.T) {
+	for _, seccomp := range []*configs.Seccomp{
+		{DefaultAction: configs.Notify, ListenerPath: "/run/seccomp-agent.sock"},
+		{DefaultAction: configs.Notify, ListenerPath: "/run/seccomp-agent.sock", Syscalls: []*configs.Syscall{{Name: "sendmsg", Action: configs.Allow, Args: []*configs.Arg{{Index: 0, Value: 3, Op: configs.EqualTo}}}}},
+		{DefaultAction: configs.Notify, Syscalls: []*configs.Syscall{{Name: "sendmsg", Action: configs.Allow}}},
+		{DefaultAction: configs.Allow, Syscalls: []*configs.Syscall{{Name: "mount", Action: configs.Notify}}},
+		{DefaultAction: configs.Allow, ListenerPath: "seccomp-agent.sock", Syscalls: []*configs.Syscall{{Name: "mount", Action: configs.Notify}}},
+		{DefaultAction: configs.Allow, ListenerPath: "/run/seccomp-agent.sock", Syscalls: []*configs.Syscall{{Name: "sendmsg", Action: configs.Notify}}},
+	} {
+		config := &configs.Config{
+			Rootfs:  "/var",
+			Seccomp: seccomp,
+		}
+
+		validator := validate.New()
+		err := validator.Validate(config)
+		if err == nil {
+			t.Errorf("Expected error to occur for %+v but it was nil", seccomp)
+		}
+	}
+}
+
+func TestValidateTimeNamespace(t *testing.T) {
+	if _, err := os.Stat("/proc/self/ns/time"); os.IsNotExist(err) {
+		t.Skip("time namespaces are unsupported")
+	}
+	config := &configs.Config{
+		Rootfs: "/var",
+		Namespaces: configs.Namespaces(
+			[]configs.Namespace{
+				{Type: configs.NEWTIME},
+			},
+		),
+		TimeOffsets: map[string]configs.TimeOffset{
+			"monotonic": {Secs: -3600},
+			"boottime":  {Secs: 3600, Nanosecs: 999999999},
+		},
+	}
+
+	validator := validate.New()
+	err := validator.Validate(config)
+	if err != nil {
+		t.Errorf("Expected error to not occur: %+v", err)
+	}
+}
+
+func TestValidateTimeOffsetsWithoutTimeNamespace(t *testing.T) {
+	config := &configs.Config{
+		Rootfs: "/var",
+		TimeOffsets: map[string]configs.TimeOffset{
+			"monotonic": {Secs: -3600},
+		},
+	}
+
+	validator := validate.New()
+	err := validator.Validate(config)
+	if err == nil {
+		t.Error("Expected error to occur but it was nil")
+	}
+}
+
+func TestValidateInvalidTimeOffsets(t *testing.T) {
+	if _, err := os.Stat("/proc/self/ns/time"); os.IsNotExist(err) {
+		t.Skip("time namespaces are unsupported")
+	}
+	for _, c := range []struct {
+		path    string
+		offsets map[string]configs.TimeOffset
+	}{
+		{"/proc/1/ns/time", map[string]configs.TimeOffset{"monotonic": {Secs: 1}}},
+		{"", map[string]configs.TimeOffset{"realtime": {Secs: 1}}},
+		{"", map[string]configs.TimeOffset{"boottime": {Nanosecs: 1000000000}}},
+	} {
+		config := &configs.Config{
+			Rootfs: "/var",
+			Namespaces: configs.Namespaces(
+				[]configs.Namespace{
+					{Type: configs.NEWTIME, Path: c.path},
+				},
+			),
+			TimeOffsets: c.offsets,
+		}
+
+		validator := validate.New()
+		err := validator.Validate(config)
+		if err == nil {
+			t.Errorf("Expected error to occur for %+v but it was nil", c)
+		}
+	}
+}
+
+func TestValidateMounts(t *testing.T) {
+	config := &configs.Config{
+		Rootfs: "/var",
+		Namespaces: configs.Namespaces(
+			[]configs.Namespace{
+				{Type: configs.NEWUSER},
+			},
+		),
+		Mounts: []*configs.Mount{
+			{
+				Source:      "/var/lib",
+				Destination: "/data",
+				Device:      "bind",
+				RecAttrSet:  configs.MountAttrRdonly | configs.MountAttrNosuid,
+				RecAttrClr:  configs.MountAttrNoexec,
+				IDMapping:   &configs.MountIDMapping{Recursive: true},
+			},
+		},
+	}
+
+	validator := validate.New()
+	err := validator.Validate(config)
+	if err != nil {
+		t.Errorf("Expected error to not occur: %+v", err)
+	}
+}
+
+func TestValidateInvalidMounts(t *testing.T) {
+	for _, c := range []struct {
+		userns bool
+		mount  configs.Mount
+	}{
+		{true, configs.Mount{Device: "bind", RecAttrSet: 0x10}},
+		{true, configs.Mount{Device: "bind", RecAttrSet: configs.MountAttrRdonly, RecAttrClr: configs.MountAttrRdonly}},
+		{true, configs.Mount{Device: "tmpfs", IDMapping: &configs.MountIDMapping{}}},
+		{false, configs.Mount{Device: "bind", IDMapping: &configs.MountIDMapping{}}},
+	} {
+		config := &configs.Config{
+			Rootfs: "/var",
+			Mounts: []*configs.Mount{&c.mount},
+		}
+		if c.userns {
+			config.Namespaces = configs.Namespaces([]configs.Namespace{{Type: configs.NEWUSER}})
+		}
+
+		validator := validate.New()
+		err := validator.Validate(config)
+		if err == nil {
+			t.Errorf("Expected error to occur for %+v but it was nil", c.mount)
+		}
+	}
+}
+
+func TestValidateRootfsOverlay(t *testing.T) {
+	config := &configs.Config{
+		Rootfs: "/var",
+		Namespaces: configs.Namespaces(
+			[]configs.Namespace{
+				{Type: configs.NEWNS},
+			},
+		),
+		RootfsOverlay: &configs.RootfsOverlay{
+			LowerDirs: []string{"/usr", "/etc"},
+		},
+	}
+
+	validator := validate.New()
+	err := validator.Validate(config)
+	if err != nil {
+		t.Errorf("Expected error to not occur: %+v", err)
+	}
+}
+
+func TestValidateInvalidRootfsOverlay(t *testing.T) {
+	for _, o := range []configs.RootfsOverlay{
+		{},
+		{LowerDirs: []string{"usr"}},
+		{LowerDirs: []string{"/no/such/dir"}},
+		{LowerDirs: []string{"/etc/passwd"}},
+		{LowerDirs: []string{"/usr"}, UpperDir: "/tmp"},
+		{LowerDirs: []string{"/usr"}, UpperDir: "/tmp", WorkDir: "/tmp/a:b"},
+	} {
+		o := o
+		config := &configs.Config{
+			Rootfs: "/var",
+			Namespaces: configs.Namespaces(
+				[]configs.Namespace{
+					{Type: configs.NEWNS},
+				},
+			),
+			RootfsOverlay: &o,
+		}
+
+		validator := validate.New()
+		err := validator.Validate(config)
+		if err == nil {
+			t.Errorf("Expected error to occur for %+v but it was nil", o)
+		}
+	}
+}
+
+func TestValidateInvalidEtcFiles(t *testing.T) {
+	for _, e := range []configs.EtcFiles{
+		{ExtraHosts: []configs.HostEntry{{Hostname: "db", IP: "not-an-ip"}}},
+		{ExtraHosts: []configs.HostEntry{{Hostname: "d b", IP: "10.0.0.1"}}},
+		{Nameservers: []string{"dns.example"}},
+		{Search: []string{"a.example b.example"}},
+		{Dir: "etc"},
+	} {
+		e := e
+		if e.Dir == "" {
+			e.Dir = "/run/runc/test/etc"
+		}
+		config := &configs.Config{
+			Rootfs: "/var",
+			Namespaces: configs.Namespaces(
+				[]configs.Namespace{
+					{Type: configs.NEWNS},
+				},
+			),
+			EtcFiles: &e,
+		}
+
+		validator := validate.New()
+		err := validator.Validate(config)
+		if err == nil {
+			t.Errorf("Expected error to occur for %+v but it was nil", e)
+		}
+	}
+}
@@ -3,11 +3,11 @@ package libcontainer
 import (
 	"fmt"
 	"os"
-	"path/filepath"
 	"syscall"
 	"unsafe"
 
 	"github.com/opencontainers/runc/libcontainer/label"
+	"github.com/opencontainers/runc/libcontainer/utils"
 )
 
 // NewConsole returns an initalized console that can be used within a container by copying bytes
@@ -81,15 +81,12 @@ func (c *linuxConsole) mount(rootfs, mountLabel string) error {
 	if err := label.SetFileLabel(c.slavePath, mountLabel); err != nil {
 		return err
 	}
-	dest := filepath.Join(rootfs, "/dev/console")
-	f, err := os.Create(dest)
-	if err != nil && !os.IsExist(err) {
+	dest, err := utils.CreateInRoot(rootfs, "/dev/console", 0666)
+	if err != nil {
 		return err
 	}
-	if f != nil {
-		f.Close()
-	}
-	return syscall.Mount(c.slavePath, dest, "bind", syscall.MS_BIND, "")
+	defer dest.Close()
+	return syscall.Mount(c.slavePath, utils.ProcfdPath(dest), "bind", syscall.MS_BIND, "")
 }
 
 // dupStdio opens the slavePath for the console and dups the fds to the current
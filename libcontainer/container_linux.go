@@ -12,6 +12,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"reflect"
+	"sort"
 	"strings"
 	"sync"
 	"syscall"
@@ -20,8 +21,10 @@ import (
 	"github.com/Sirupsen/logrus"
 	"github.com/golang/protobuf/proto"
 	"github.com/opencontainers/runc/libcontainer/cgroups"
+	"github.com/opencontainers/runc/libcontainer/cgroups/fs"
 	"github.com/opencontainers/runc/libcontainer/configs"
 	"github.com/opencontainers/runc/libcontainer/criurpc"
+	"github.com/opencontainers/runc/libcontainer/system"
 	"github.com/opencontainers/runc/libcontainer/utils"
 	"github.com/syndtr/gocapability/capability"
 	"github.com/vishvananda/netlink/nl"
@@ -42,6 +45,11 @@ type linuxContainer struct {
 	criuVersion   int
 	state         containerState
 	created       time.Time
+	perfCounters  *fs.PerfCounters
+	addedMounts   []string
+	// unlock releases the exclusive lock of the container taken by
+	// lockExclusive, nil when it is not held.
+	unlock func()
 }
 
 // State represents a running container's state
@@ -50,6 +58,9 @@ type State struct {
 
 	// Platform specific fields below here
 
+	// StateVersion is the version of the format of the stored state.
+	StateVersion int `json:"state_version"`
+
 	// Path to all the cgroups setup for a container. Key is cgroup subsystem name
 	// with the value as the path.
 	CgroupPaths map[string]string `json:"cgroup_paths"`
@@ -60,6 +71,10 @@ type State struct {
 
 	// Container's standard descriptors (std{in,out,err}), needed for checkpoint and restore
 	ExternalDescriptors []string `json:"external_descriptors,omitempty"`
+
+	// AddedMounts are the destinations of the mounts added to the running
+	// container, the only ones that can be removed from it.
+	AddedMounts []string `json:"added_mounts,omitempty"`
 }
 
 // Container is a libcontainer container object.
@@ -114,6 +129,79 @@ type Container interface {
 	// errors:
 	// Systemerror - System error.
 	NotifyMemoryPressure(level PressureLevel) (<-chan struct{}, error)
+
+	// AddMount bind mounts the source of m at its destination inside the rootfs of
+	// the running container, and adds it to the mounts of the container's config.
+	//
+	// errors:
+	// ContainerNotRunning - Container is not running,
+	// ConfigInvalid - m is not a valid bind mount,
+	// Systemerror - System error.
+	AddMount(m *configs.Mount) error
+
+	// RemoveMount unmounts the mount added by AddMount at destination inside the
+	// rootfs of the running container, and removes it from the mounts of the
+	// container's config.
+	//
+	// errors:
+	// ContainerNotRunning - Container is not running,
+	// ConfigInvalid - No mount added to the running container has this destination,
+	// Systemerror - System error.
+	RemoveMount(destination string) error
+
+	// AddDevice allows the device in the devices cgroup of the running container,
+	// creates its node inside the rootfs, and adds it to the devices of the
+	// container's config.
+	//
+	// errors:
+	// ContainerNotRunning - Container is not running,
+	// ConfigInvalid - device is not a valid device,
+	// Systemerror - System error.
+	AddDevice(device *configs.Device) error
+
+	// RemoveDevice denies the device at path in the devices cgroup of the running
+	// container, removes its node from the rootfs, and removes it from the devices
+	// of the container's config.
+	//
+	// errors:
+	// ContainerNotRunning - Container is not running,
+	// ConfigInvalid - No device of the config has this path, or it is a default device,
+	// Systemerror - System error.
+	RemoveDevice(path string) error
+
+	// OpenPerfCounters opens software perf counters of the events of the
+	// processes in the perf_event cgroup of the container, such as context
+	// switches and page faults. The stats of the container include their
+	// counts from then on, until ClosePerfCounters is called or the container
+	// is destroyed.
+	//
+	// errors:
+	// ContainerNotRunning - Container is not running,
+	// Systemerror - System error.
+	OpenPerfCounters() error
+
+	// ClosePerfCounters closes the perf counters of the container.
+	//
+	// errors:
+	// Systemerror - System error.
+	ClosePerfCounters() error
+
+	// RawState returns the state of the container as stored, in the format of
+	// the libcontainer that stored it.
+	//
+	// errors:
+	// ContainerNotExists - Container no longer exists,
+	// Systemerror - System error.
+	RawState() ([]byte, error)
+
+	// RemoveCriuWorkDirectory removes the default CRIU work directory of the
+	// container, with the logs of its last checkpoint or restore, and returns
+	// its path, or "" if there is none.
+	//
+	// errors:
+	// ContainerLocked - A checkpoint or restore of the container is running,
+	// Systemerror - System error.
+	RemoveCriuWorkDirectory() (string, error)
 }
 
 // ID returns the container's unique ID
@@ -129,16 +217,49 @@ func (c *linuxContainer) Config() configs.Config {
 func (c *linuxContainer) Status() (Status, error) {
 	c.m.Lock()
 	defer c.m.Unlock()
+	unlock, err := lockContainer(c.root, false)
+	if err != nil {
+		return -1, err
+	}
+	defer unlock()
 	return c.currentStatus()
 }
 
 func (c *linuxContainer) State() (*State, error) {
 	c.m.Lock()
 	defer c.m.Unlock()
+	unlock, err := lockContainer(c.root, false)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
 	return c.currentState()
 }
 
+func (c *linuxContainer) RawState() ([]byte, error) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	unlock, err := lockContainer(c.root, false)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	data, err := ioutil.ReadFile(filepath.Join(c.root, stateFilename))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, newGenericError(err, ContainerNotExists)
+		}
+		return nil, newSystemErrorWithCause(err, "reading container state")
+	}
+	return data, nil
+}
+
 func (c *linuxContainer) Processes() ([]int, error) {
+	unlock, err := lockContainer(c.root, false)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
 	pids, err := c.cgroupManager.GetAllPids()
 	if err != nil {
 		return nil, newSystemErrorWithCause(err, "getting all container pids from cgroups")
@@ -151,9 +272,22 @@ func (c *linuxContainer) Stats() (*Stats, error) {
 		err   error
 		stats = &Stats{}
 	)
+	unlock, err := lockContainer(c.root, false)
+	if err != nil {
+		return stats, err
+	}
+	defer unlock()
 	if stats.CgroupStats, err = c.cgroupManager.GetStats(); err != nil {
 		return stats, newSystemErrorWithCause(err, "getting container stats from cgroups")
 	}
+	c.m.Lock()
+	if c.perfCounters != nil {
+		stats.CgroupStats.PerfEventStats, err = c.perfCounters.GetStats()
+	}
+	c.m.Unlock()
+	if err != nil {
+		return stats, newSystemErrorWithCause(err, "getting container stats from perf counters")
+	}
 	for _, iface := range c.config.Networks {
 		switch iface.Type {
 		case "veth":
@@ -167,9 +301,56 @@ func (c *linuxContainer) Stats() (*Stats, error) {
 	return stats, nil
 }
 
+func (c *linuxContainer) OpenPerfCounters() error {
+	c.m.Lock()
+	defer c.m.Unlock()
+	status, err := c.currentStatus()
+	if err != nil {
+		return err
+	}
+	if status != Running && status != Paused {
+		return newGenericError(fmt.Errorf("container not running"), ContainerNotRunning)
+	}
+	if c.perfCounters != nil {
+		return nil
+	}
+	path, ok := c.cgroupManager.GetPaths()["perf_event"]
+	if !ok {
+		return newSystemError(fmt.Errorf("container has no perf_event cgroup"))
+	}
+	cpus, err := onlineCPUs()
+	if err != nil {
+		return newSystemErrorWithCause(err, "getting online cpus")
+	}
+	if c.perfCounters, err = fs.OpenPerfCounters(path, cpus); err != nil {
+		return newSystemErrorWithCause(err, "opening perf counters")
+	}
+	return nil
+}
+
+func (c *linuxContainer) ClosePerfCounters() error {
+	c.m.Lock()
+	defer c.m.Unlock()
+	return c.closePerfCounters()
+}
+
+func (c *linuxContainer) closePerfCounters() error {
+	if c.perfCounters == nil {
+		return nil
+	}
+	err := c.perfCounters.Close()
+	c.perfCounters = nil
+	return err
+}
+
 func (c *linuxContainer) Set(config configs.Config) error {
 	c.m.Lock()
 	defer c.m.Unlock()
+	unlock, err := lockContainer(c.root, true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
 	c.config = &config
 	return c.cgroupManager.Set(c.config)
 }
@@ -177,11 +358,18 @@ func (c *linuxContainer) Set(config configs.Config) error {
 func (c *linuxContainer) Start(process *Process) error {
 	c.m.Lock()
 	defer c.m.Unlock()
+	if err := c.lockExclusive(); err != nil {
+		return err
+	}
+	defer c.unlockExclusive()
 	status, err := c.currentStatus()
 	if err != nil {
 		return err
 	}
 	doInit := status == Destroyed
+	if doInit && process.SubCgroup != nil {
+		return newGenericError(fmt.Errorf("sub-cgroups are only supported for processes executed in a running container"), ConfigInvalid)
+	}
 	parent, err := c.newParentProcess(process, doInit)
 	if err != nil {
 		return newSystemErrorWithCause(err, "creating new parent process")
@@ -211,20 +399,64 @@ func (c *linuxContainer) Start(process *Process) error {
 				Root:       c.config.Rootfs,
 				BundlePath: utils.SearchLabels(c.config.Labels, "bundle"),
 			}
-			for i, hook := range c.config.Hooks.Poststart {
-				if err := hook.Run(s); err != nil {
-					if err := parent.terminate(); err != nil {
-						logrus.Warn(err)
-					}
-					return newSystemErrorWithCausef(err, "running poststart hook %d", i)
+			if err := c.runHooks("poststart", c.config.Hooks.Poststart, s); err != nil {
+				if err := parent.terminate(); err != nil {
+					logrus.Warn(err)
 				}
+				return err
 			}
 		}
 	}
 	return nil
 }
 
+// lockExclusive takes the exclusive lock of the container for an operation
+// that runs hooks, see runHooks.
+func (c *linuxContainer) lockExclusive() error {
+	unlock, err := lockContainer(c.root, true)
+	if err != nil {
+		return err
+	}
+	c.unlock = unlock
+	return nil
+}
+
+func (c *linuxContainer) unlockExclusive() {
+	if c.unlock != nil {
+		c.unlock()
+		c.unlock = nil
+	}
+}
+
+// runHooks runs the hooks of kind with state s. Hooks may call back into runc
+// for the container, so the lock taken by lockExclusive is released while they
+// run, and taken again afterwards unless the container is gone by then.
+func (c *linuxContainer) runHooks(kind string, hooks []configs.Hook, s configs.HookState) error {
+	locked := c.unlock != nil
+	c.unlockExclusive()
+	var err error
+	for i, hook := range hooks {
+		if err = hook.Run(s); err != nil {
+			err = newSystemErrorWithCausef(err, "running %s hook %d", kind, i)
+			break
+		}
+	}
+	if locked {
+		if lerr := c.lockExclusive(); lerr != nil && err == nil {
+			if e, ok := lerr.(Error); !ok || e.Code() != ContainerNotExists {
+				err = lerr
+			}
+		}
+	}
+	return err
+}
+
 func (c *linuxContainer) Signal(s os.Signal) error {
+	unlock, err := lockContainer(c.root, true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
 	if err := c.initProcess.signal(s); err != nil {
 		return newSystemErrorWithCause(err, "signaling init process")
 	}
@@ -311,6 +543,7 @@ func (c *linuxContainer) newSetnsProcess(p *Process, cmd *exec.Cmd, parentPipe,
 	return &setnsProcess{
 		cmd:           cmd,
 		cgroupPaths:   c.cgroupManager.GetPaths(),
+		cgroupManager: c.cgroupManager,
 		childPipe:     childPipe,
 		parentPipe:    parentPipe,
 		config:        c.newInitConfig(p),
@@ -334,6 +567,9 @@ func (c *linuxContainer) newInitConfig(process *Process) *initConfig {
 		AppArmorProfile:  c.config.AppArmorProfile,
 		ProcessLabel:     c.config.ProcessLabel,
 		Rlimits:          c.config.Rlimits,
+		Scheduler:        process.Scheduler,
+		IOPriority:       process.IOPriority,
+		CPUAffinity:      process.CPUAffinity,
 	}
 	if process.NoNewPrivileges != nil {
 		cfg.NoNewPrivileges = *process.NoNewPrivileges
@@ -361,12 +597,29 @@ func newPipe() (parent *os.File, child *os.File, err error) {
 func (c *linuxContainer) Destroy() error {
 	c.m.Lock()
 	defer c.m.Unlock()
-	return c.state.destroy()
+	if err := c.lockExclusive(); err != nil {
+		return err
+	}
+	err := c.state.destroy()
+	c.unlockExclusive()
+	// The CPUs of the container are released once its lock is dropped, as the
+	// allocator takes the locks of the containers sharing CPUs under its own.
+	if c.state.status() == Destroyed {
+		if aerr := (&cpusetAllocator{root: filepath.Dir(c.root)}).release(c.id); err == nil {
+			err = aerr
+		}
+	}
+	return err
 }
 
 func (c *linuxContainer) Pause() error {
 	c.m.Lock()
 	defer c.m.Unlock()
+	unlock, err := lockContainer(c.root, true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
 	status, err := c.currentStatus()
 	if err != nil {
 		return err
@@ -385,6 +638,11 @@ func (c *linuxContainer) Pause() error {
 func (c *linuxContainer) Resume() error {
 	c.m.Lock()
 	defer c.m.Unlock()
+	unlock, err := lockContainer(c.root, true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
 	status, err := c.currentStatus()
 	if err != nil {
 		return err
@@ -408,6 +666,266 @@ func (c *linuxContainer) NotifyMemoryPressure(level PressureLevel) (<-chan struc
 	return notifyMemoryPressure(c.cgroupManager.GetPaths(), level)
 }
 
+func (c *linuxContainer) AddMount(m *configs.Mount) error {
+	c.m.Lock()
+	defer c.m.Unlock()
+	unlock, err := lockContainer(c.root, true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	if err := c.checkMountRunning(); err != nil {
+		return err
+	}
+	if m.Device != "bind" {
+		return newGenericError(fmt.Errorf("only bind mounts can be added to a running container"), ConfigInvalid)
+	}
+	if !filepath.IsAbs(m.Destination) {
+		return newGenericError(fmt.Errorf("mount destination %q is not absolute", m.Destination), ConfigInvalid)
+	}
+	if c.findMount(m.Destination) >= 0 {
+		return newGenericError(fmt.Errorf("a mount already exists at %q", m.Destination), ConfigInvalid)
+	}
+	if m.IDMapping != nil && !c.config.Namespaces.Contains(configs.NEWUSER) {
+		return newGenericError(fmt.Errorf("idmapped mounts require a user namespace"), ConfigInvalid)
+	}
+	tree, err := openMountTree(m, c.initProcess.pid())
+	if err != nil {
+		return newSystemErrorWithCausef(err, "opening mount tree of %s", m.Source)
+	}
+	defer tree.Close()
+	dest, err := c.runMountProcess(&mountRequest{Destination: m.Destination}, tree)
+	if err != nil {
+		return err
+	}
+	m.Destination = dest
+	c.config.Mounts = append(c.config.Mounts, m)
+	c.addedMounts = append(c.addedMounts, dest)
+	return c.updateState(c.initProcess)
+}
+
+func (c *linuxContainer) RemoveMount(destination string) error {
+	c.m.Lock()
+	defer c.m.Unlock()
+	unlock, err := lockContainer(c.root, true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	if err := c.checkMountRunning(); err != nil {
+		return err
+	}
+	i := c.findMount(destination)
+	if i < 0 {
+		return newGenericError(fmt.Errorf("no mount at %q", destination), ConfigInvalid)
+	}
+	m := c.config.Mounts[i]
+	// The mounts the container was started with are part of its rootfs.
+	j := c.findAddedMount(m.Destination)
+	if j < 0 {
+		return newGenericError(fmt.Errorf("the mount at %q was not added to the running container", destination), ConfigInvalid)
+	}
+	if _, err := c.runMountProcess(&mountRequest{Destination: m.Destination, Remove: true}, nil); err != nil {
+		return err
+	}
+	c.config.Mounts = append(c.config.Mounts[:i], c.config.Mounts[i+1:]...)
+	c.addedMounts = append(c.addedMounts[:j], c.addedMounts[j+1:]...)
+	return c.updateState(c.initProcess)
+}
+
+func (c *linuxContainer) AddDevice(device *configs.Device) error {
+	c.m.Lock()
+	defer c.m.Unlock()
+	unlock, err := lockContainer(c.root, true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	if err := c.checkMountRunning(); err != nil {
+		return err
+	}
+	if device.Type != 'c' && device.Type != 'b' {
+		return newGenericError(fmt.Errorf("%c is not a valid device type for device %s", device.Type, device.Path), ConfigInvalid)
+	}
+	if !filepath.IsAbs(device.Path) {
+		return newGenericError(fmt.Errorf("device path %q is not absolute", device.Path), ConfigInvalid)
+	}
+	if c.findDevice(device.Path) >= 0 {
+		return newGenericError(fmt.Errorf("a device already exists at %q", device.Path), ConfigInvalid)
+	}
+	resources := *c.config.Cgroups.Resources
+	setDeviceRule(c.config.Cgroups.Resources, device, true)
+	if err := c.cgroupManager.Set(c.config); err != nil {
+		*c.config.Cgroups.Resources = resources
+		return newSystemErrorWithCause(err, "allowing device in cgroup")
+	}
+	path, err := c.createDevice(device)
+	if err != nil {
+		*c.config.Cgroups.Resources = resources
+		if serr := c.cgroupManager.Set(c.config); serr != nil {
+			logrus.Warn(serr)
+		}
+		return err
+	}
+	device.Path = path
+	c.config.Devices = append(c.config.Devices, device)
+	return c.updateState(c.initProcess)
+}
+
+// createDevice creates the node of device in the running container, bind
+// mounting it from the host under user namespaces as mknod is not allowed.
+func (c *linuxContainer) createDevice(device *configs.Device) (string, error) {
+	if !c.config.Namespaces.Contains(configs.NEWUSER) && !system.RunningInUserNS() {
+		return c.runMountProcess(&mountRequest{Destination: device.Path, Device: device}, nil)
+	}
+	tree, err := openMountTree(&configs.Mount{
+		Source:      device.Path,
+		Destination: device.Path,
+		Device:      "bind",
+		Flags:       syscall.MS_BIND,
+	}, c.initProcess.pid())
+	if err != nil {
+		return "", newSystemErrorWithCausef(err, "opening mount tree of %s", device.Path)
+	}
+	defer tree.Close()
+	return c.runMountProcess(&mountRequest{Destination: device.Path}, tree)
+}
+
+func (c *linuxContainer) RemoveDevice(path string) error {
+	c.m.Lock()
+	defer c.m.Unlock()
+	unlock, err := lockContainer(c.root, true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	if err := c.checkMountRunning(); err != nil {
+		return err
+	}
+	i := c.findDevice(path)
+	if i < 0 {
+		return newGenericError(fmt.Errorf("no device at %q", path), ConfigInvalid)
+	}
+	device := c.config.Devices[i]
+	if isDefaultDevice(device.Path) {
+		return newGenericError(fmt.Errorf("the default device %s cannot be removed", device.Path), ConfigInvalid)
+	}
+	resources := *c.config.Cgroups.Resources
+	setDeviceRule(c.config.Cgroups.Resources, device, false)
+	if err := c.cgroupManager.Set(c.config); err != nil {
+		*c.config.Cgroups.Resources = resources
+		return newSystemErrorWithCause(err, "denying device in cgroup")
+	}
+	if _, err := c.runMountProcess(&mountRequest{Destination: device.Path, Remove: true, Device: device}, nil); err != nil {
+		*c.config.Cgroups.Resources = resources
+		if serr := c.cgroupManager.Set(c.config); serr != nil {
+			logrus.Warn(serr)
+		}
+		return err
+	}
+	c.config.Devices = append(c.config.Devices[:i], c.config.Devices[i+1:]...)
+	return c.updateState(c.initProcess)
+}
+
+// findDevice returns the index of the device of the config at path, or -1.
+func (c *linuxContainer) findDevice(path string) int {
+	path = utils.CleanPath(path)
+	for i, d := range c.config.Devices {
+		if utils.CleanPath(d.Path) == path {
+			return i
+		}
+	}
+	return -1
+}
+
+// checkMountRunning returns an error unless the mount namespace of the
+// container can be entered.
+func (c *linuxContainer) checkMountRunning() error {
+	status, err := c.currentStatus()
+	if err != nil {
+		return err
+	}
+	if status != Running && status != Paused {
+		return newGenericError(fmt.Errorf("container not running"), ContainerNotRunning)
+	}
+	if !c.config.Namespaces.Contains(configs.NEWNS) {
+		return newGenericError(fmt.Errorf("container has no mount namespace of its own"), ConfigInvalid)
+	}
+	return nil
+}
+
+// findMount returns the index of the mount of the config at destination, or -1.
+func (c *linuxContainer) findMount(destination string) int {
+	destination = utils.CleanPath(destination)
+	for i, m := range c.config.Mounts {
+		if utils.CleanPath(m.Destination) == destination {
+			return i
+		}
+	}
+	return -1
+}
+
+// findAddedMount returns the index of the mount added to the running container
+// at destination, or -1.
+func (c *linuxContainer) findAddedMount(destination string) int {
+	destination = utils.CleanPath(destination)
+	for i, d := range c.addedMounts {
+		if utils.CleanPath(d) == destination {
+			return i
+		}
+	}
+	return -1
+}
+
+// runMountProcess runs an init process in the mount namespace of the container
+// to attach tree at, or unmount, the destination of req. It returns the
+// destination resolved inside the rootfs.
+func (c *linuxContainer) runMountProcess(req *mountRequest, tree *os.File) (string, error) {
+	parentPipe, childPipe, err := newPipe()
+	if err != nil {
+		return "", newSystemErrorWithCause(err, "creating new init pipe")
+	}
+	defer parentPipe.Close()
+	cmd, err := c.commandTemplate(&Process{}, childPipe)
+	if err != nil {
+		childPipe.Close()
+		return "", newSystemErrorWithCause(err, "creating new command template")
+	}
+	cmd.Env = append(cmd.Env, "_LIBCONTAINER_INITTYPE="+string(initMount))
+	state, err := c.currentState()
+	if err != nil {
+		childPipe.Close()
+		return "", newSystemErrorWithCause(err, "getting container's current state")
+	}
+	nsMaps := map[configs.NamespaceType]string{
+		configs.NEWNS: state.NamespacePaths[configs.NEWNS],
+	}
+	// The pid namespace is joined for /proc/self to resolve in the procfs
+	// mounted in the container, and the user namespace for the files it
+	// creates to be owned by ids mapped in the container.
+	for _, t := range []configs.NamespaceType{configs.NEWPID, configs.NEWUSER} {
+		if c.config.Namespaces.Contains(t) {
+			nsMaps[t] = state.NamespacePaths[t]
+		}
+	}
+	data, err := c.joinNamespacesData(nsMaps)
+	if err != nil {
+		childPipe.Close()
+		return "", err
+	}
+	err = cmd.Start()
+	childPipe.Close()
+	if err != nil {
+		return "", newSystemErrorWithCause(err, "starting mount process")
+	}
+	p := &mountProcess{cmd: cmd, parentPipe: parentPipe}
+	defer p.wait()
+	if err := p.start(data); err != nil {
+		return "", err
+	}
+	return p.run(req, tree)
+}
+
 // checkCriuVersion checks Criu version greater than or equal to minVersion
 func (c *linuxContainer) checkCriuVersion(minVersion string) error {
 	var x, y, z, versionReq int
@@ -483,6 +1001,11 @@ func (c *linuxContainer) addCriuDumpMount(req *criurpc.CriuReq, m *configs.Mount
 func (c *linuxContainer) Checkpoint(criuOpts *CriuOpts) error {
 	c.m.Lock()
 	defer c.m.Unlock()
+	unlock, err := lockContainer(c.root, true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
 
 	if err := c.checkCriuVersion("1.5.2"); err != nil {
 		return err
@@ -499,7 +1022,7 @@ func (c *linuxContainer) Checkpoint(criuOpts *CriuOpts) error {
 	}
 
 	if criuOpts.WorkDirectory == "" {
-		criuOpts.WorkDirectory = filepath.Join(c.root, "criu.work")
+		criuOpts.WorkDirectory = filepath.Join(c.root, DefaultCriuWorkDirectory)
 	}
 
 	if err := os.Mkdir(criuOpts.WorkDirectory, 0755); err != nil && !os.IsExist(err) {
@@ -607,14 +1130,41 @@ func (c *linuxContainer) addCriuRestoreMount(req *criurpc.CriuReq, m *configs.Mo
 	req.Opts.ExtMnt = append(req.Opts.ExtMnt, extMnt)
 }
 
+func (c *linuxContainer) RemoveCriuWorkDirectory() (string, error) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	// Checkpoints and restores hold the lock while CRIU uses the directory.
+	unlock, err := lockContainer(c.root, true)
+	if err != nil {
+		return "", err
+	}
+	defer unlock()
+	path := filepath.Join(c.root, DefaultCriuWorkDirectory)
+	if _, err := os.Stat(path); err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", newSystemErrorWithCause(err, "checking CRIU work directory")
+	}
+	if err := os.RemoveAll(path); err != nil {
+		return "", newSystemErrorWithCause(err, "removing CRIU work directory")
+	}
+	return path, nil
+}
+
 func (c *linuxContainer) Restore(process *Process, criuOpts *CriuOpts) error {
 	c.m.Lock()
 	defer c.m.Unlock()
+	unlock, err := lockContainer(c.root, true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
 	if err := c.checkCriuVersion("1.5.2"); err != nil {
 		return err
 	}
 	if criuOpts.WorkDirectory == "" {
-		criuOpts.WorkDirectory = filepath.Join(c.root, "criu.work")
+		criuOpts.WorkDirectory = filepath.Join(c.root, DefaultCriuWorkDirectory)
 	}
 	// Since a container can be C/R'ed multiple times,
 	// the work directory may already exist.
@@ -996,12 +1546,42 @@ func (c *linuxContainer) updateState(process parentProcess) error {
 }
 
 func (c *linuxContainer) saveState(s *State) error {
-	f, err := os.Create(filepath.Join(c.root, stateFilename))
+	return writeJSONFile(filepath.Join(c.root, stateFilename), s)
+}
+
+// writeJSONFile atomically replaces the file at path with the JSON encoding of
+// v, so that it is never seen partially written, even after a crash.
+func writeJSONFile(path string, v interface{}) error {
+	dir, name := filepath.Split(path)
+	f, err := ioutil.TempFile(dir, "."+name)
 	if err != nil {
 		return err
 	}
-	defer f.Close()
-	return utils.WriteJSON(f, s)
+	defer os.Remove(f.Name())
+	if err := utils.WriteJSON(f, v); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Chmod(0600); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(f.Name(), path); err != nil {
+		return err
+	}
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
 }
 
 func (c *linuxContainer) deleteState() error {
@@ -1048,7 +1628,19 @@ func (c *linuxContainer) isRunning() (bool, error) {
 		}
 		return false, newSystemErrorWithCausef(err, "sending signal 0 to pid %d", c.initProcess.pid())
 	}
-	return true, nil
+	// and is not another process that has been given its pid since it exited.
+	expected, _ := c.initProcess.startTime()
+	if expected == "" {
+		return true, nil
+	}
+	startTime, err := system.GetProcessStartTime(c.initProcess.pid())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, newSystemErrorWithCausef(err, "getting start time of pid %d", c.initProcess.pid())
+	}
+	return startTime == expected, nil
 }
 
 func (c *linuxContainer) isPaused() (bool, error) {
@@ -1074,6 +1666,7 @@ func (c *linuxContainer) currentState() (*State, error) {
 		externalDescriptors = c.initProcess.externalDescriptors()
 	}
 	state := &State{
+		StateVersion: stateVersion,
 		BaseState: BaseState{
 			ID:                   c.ID(),
 			Config:               *c.config,
@@ -1084,6 +1677,7 @@ func (c *linuxContainer) currentState() (*State, error) {
 		CgroupPaths:         c.cgroupManager.GetPaths(),
 		NamespacePaths:      make(map[configs.NamespaceType]string),
 		ExternalDescriptors: externalDescriptors,
+		AddedMounts:         c.addedMounts,
 	}
 	if pid > 0 {
 		for _, ns := range c.config.Namespaces {
@@ -1113,6 +1707,11 @@ func (c *linuxContainer) orderNamespacePaths(namespaces map[configs.NamespaceTyp
 		configs.NEWPID,
 		configs.NEWNS,
 	}
+	// join the time ns only if the init process explicitly requires NEWTIME,
+	// and before the userns as the time ns may be owned by our own userns
+	if c.config.Namespaces.Contains(configs.NEWTIME) {
+		nsTypes = append(nsTypes, configs.NEWTIME)
+	}
 	// join userns if the init process explicitly requires NEWUSER
 	if c.config.Namespaces.Contains(configs.NEWUSER) {
 		nsTypes = append(nsTypes, configs.NEWUSER)
@@ -1138,6 +1737,21 @@ func (c *linuxContainer) orderNamespacePaths(namespaces map[configs.NamespaceTyp
 	return paths, nil
 }
 
+// encodeTimeOffsets returns the offsets in the format of
+// /proc/<pid>/timens_offsets.
+func encodeTimeOffsets(offsets map[string]configs.TimeOffset) []byte {
+	var clocks []string
+	for clock := range offsets {
+		clocks = append(clocks, clock)
+	}
+	sort.Strings(clocks)
+	data := bytes.NewBuffer(nil)
+	for _, clock := range clocks {
+		fmt.Fprintf(data, "%s %d %d\n", clock, offsets[clock].Secs, offsets[clock].Nanosecs)
+	}
+	return data.Bytes()
+}
+
 func encodeIDMapping(idMap []configs.IDMap) ([]byte, error) {
 	data := bytes.NewBuffer(nil)
 	for _, im := range idMap {
@@ -1149,6 +1763,26 @@ func encodeIDMapping(idMap []configs.IDMap) ([]byte, error) {
 	return data.Bytes(), nil
 }
 
+// joinNamespacesData returns the bootstrap data for a process only joining the
+// namespaces of nsMaps. Unlike bootstrapData, it never carries id mappings, as
+// the namespaces it joins already exist.
+func (c *linuxContainer) joinNamespacesData(nsMaps map[configs.NamespaceType]string) (io.Reader, error) {
+	r := nl.NewNetlinkRequest(int(InitMsg), 0)
+	r.AddData(&Int32msg{
+		Type:  CloneFlagsAttr,
+		Value: 0,
+	})
+	nsPaths, err := c.orderNamespacePaths(nsMaps)
+	if err != nil {
+		return nil, err
+	}
+	r.AddData(&Bytemsg{
+		Type:  NsPathsAttr,
+		Value: []byte(strings.Join(nsPaths, ",")),
+	})
+	return bytes.NewReader(r.Serialize()), nil
+}
+
 // bootstrapData encodes the necessary data in netlink binary format
 // as a io.Reader.
 // Consumer can write the data to a bootstrap program
@@ -1185,6 +1819,15 @@ func (c *linuxContainer) bootstrapData(cloneFlags uintptr, nsMaps map[configs.Na
 		})
 	}
 
+	// write time offsets only when we are creating a new time ns
+	timens := configs.Namespace{Type: configs.NEWTIME}
+	if cloneFlags&uintptr(timens.Syscall()) != 0 && len(c.config.TimeOffsets) > 0 {
+		r.AddData(&Bytemsg{
+			Type:  TimeOffsetsAttr,
+			Value: encodeTimeOffsets(c.config.TimeOffsets),
+		})
+	}
+
 	// write namespace paths only when we are not joining an existing user ns
 	_, joinExistingUser := nsMaps[configs.NEWUSER]
 	if !joinExistingUser {
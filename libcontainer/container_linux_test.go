@@ -9,6 +9,7 @@ import (
 
 	"github.com/opencontainers/runc/libcontainer/cgroups"
 	"github.com/opencontainers/runc/libcontainer/configs"
+	"github.com/opencontainers/runc/libcontainer/system"
 )
 
 type mockCgroupManager struct {
@@ -50,6 +51,10 @@ func (m *mockCgroupManager) Freeze(state configs.FreezerState) error {
 	return nil
 }
 
+func (m *mockCgroupManager) ApplySubCgroup(name string, pid int, resources *configs.Resources) (map[string]string, error) {
+	return nil, nil
+}
+
 type mockProcess struct {
 	_pid    int
 	started string
@@ -87,8 +92,14 @@ func (m *mockProcess) setExternalDescriptors(newFds []string) {
 }
 
 func TestGetContainerPids(t *testing.T) {
+	root, err := newTestRoot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
 	container := &linuxContainer{
 		id:            "myid",
+		root:          root,
 		config:        &configs.Config{},
 		cgroupManager: &mockCgroupManager{allPids: []int{1, 2, 3}},
 	}
@@ -104,8 +115,14 @@ func TestGetContainerPids(t *testing.T) {
 }
 
 func TestGetContainerStats(t *testing.T) {
+	root, err := newTestRoot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
 	container := &linuxContainer{
 		id:     "myid",
+		root:   root,
 		config: &configs.Config{},
 		cgroupManager: &mockCgroupManager{
 			pids: []int{1, 2, 3},
@@ -136,8 +153,14 @@ func TestGetContainerState(t *testing.T) {
 		expectedMemoryPath  = "/sys/fs/cgroup/memory/myid"
 		expectedNetworkPath = "/networks/fd"
 	)
+	root, err := newTestRoot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
 	container := &linuxContainer{
-		id: "myid",
+		id:   "myid",
+		root: root,
 		config: &configs.Config{
 			Namespaces: []configs.Namespace{
 				{Type: configs.NEWPID},
@@ -216,3 +239,111 @@ func TestGetContainerState(t *testing.T) {
 		}
 	}
 }
+
+func TestContainerIsRunningChecksStartTime(t *testing.T) {
+	startTime, err := system.GetProcessStartTime(os.Getpid())
+	if err != nil {
+		t.Fatal(err)
+	}
+	process := &mockProcess{_pid: os.Getpid(), started: startTime}
+	container := &linuxContainer{
+		id:          "myid",
+		config:      &configs.Config{},
+		initProcess: process,
+	}
+	if running, err := container.isRunning(); err != nil || !running {
+		t.Fatalf("expected the container to be running, got %v %v", running, err)
+	}
+	// Another process was given the pid of the exited init process.
+	process.started = "0"
+	if running, err := container.isRunning(); err != nil || running {
+		t.Fatalf("expected the container not to be running, got %v %v", running, err)
+	}
+}
+
+func newRunningTestContainer(t *testing.T, root string, config *configs.Config) *linuxContainer {
+	startTime, err := system.GetProcessStartTime(os.Getpid())
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &linuxContainer{
+		id:            "myid",
+		root:          root,
+		config:        config,
+		cgroupManager: &mockCgroupManager{},
+		initProcess:   &mockProcess{_pid: os.Getpid(), started: startTime},
+	}
+	c.state = &createdState{c: c, s: Created}
+	return c
+}
+
+func TestContainerAddMountInvalid(t *testing.T) {
+	root, err := newTestRoot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	container := newRunningTestContainer(t, root, &configs.Config{
+		Namespaces: configs.Namespaces{{Type: configs.NEWNS}},
+		Mounts:     []*configs.Mount{{Source: "proc", Destination: "/proc", Device: "proc"}},
+	})
+	for _, m := range []*configs.Mount{
+		{Source: "tmpfs", Destination: "/tmp", Device: "tmpfs"},
+		{Source: "/tmp", Destination: "tmp", Device: "bind"},
+		{Source: "/tmp", Destination: "/proc/", Device: "bind"},
+		{Source: "/tmp", Destination: "/mnt", Device: "bind", IDMapping: &configs.MountIDMapping{}},
+	} {
+		err := container.AddMount(m)
+		if lerr, ok := err.(Error); !ok || lerr.Code() != ConfigInvalid {
+			t.Errorf("expected adding %+v to fail with ConfigInvalid, got %v", m, err)
+		}
+	}
+	if len(container.config.Mounts) != 1 || len(container.addedMounts) != 0 {
+		t.Fatalf("expected the mounts to be left alone, got %+v %v", container.config.Mounts, container.addedMounts)
+	}
+
+	container.initProcess = nil
+	err = container.AddMount(&configs.Mount{Source: "/tmp", Destination: "/mnt", Device: "bind"})
+	if lerr, ok := err.(Error); !ok || lerr.Code() != ContainerNotRunning {
+		t.Fatalf("expected adding a mount to a stopped container to fail with ContainerNotRunning, got %v", err)
+	}
+}
+
+func TestContainerRemoveMountNotAdded(t *testing.T) {
+	root, err := newTestRoot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	container := newRunningTestContainer(t, root, &configs.Config{
+		Namespaces: configs.Namespaces{{Type: configs.NEWNS}},
+		Mounts: []*configs.Mount{
+			{Source: "proc", Destination: "/proc", Device: "proc"},
+			{Source: "/data", Destination: "/data", Device: "bind"},
+		},
+	})
+	for _, dest := range []string{"/proc", "/data/", "/mnt"} {
+		err := container.RemoveMount(dest)
+		if lerr, ok := err.(Error); !ok || lerr.Code() != ConfigInvalid {
+			t.Errorf("expected removing %s to fail with ConfigInvalid, got %v", dest, err)
+		}
+	}
+	if len(container.config.Mounts) != 2 {
+		t.Fatalf("expected the mounts to be left alone, got %+v", container.config.Mounts)
+	}
+}
+
+func TestContainerStateAddedMounts(t *testing.T) {
+	container := newRunningTestContainer(t, "", &configs.Config{})
+	container.addedMounts = []string{"/data/"}
+	state, err := container.currentState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(state.AddedMounts) != 1 || state.AddedMounts[0] != "/data/" {
+		t.Fatalf("expected the added mounts to be kept in the state, got %v", state.AddedMounts)
+	}
+	if container.findAddedMount("/data") != 0 || container.findAddedMount("/proc") != -1 {
+		t.Fatal("expected the added mounts to be found by their clean destination")
+	}
+}
@@ -0,0 +1,411 @@
+// +build linux
+
+package libcontainer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/opencontainers/runc/libcontainer/cgroups/fs"
+	"github.com/opencontainers/runc/libcontainer/configs"
+)
+
+const (
+	cpusetStateFilename = "cpuset.json"
+	cpusetLockFilename  = "cpuset.lock"
+)
+
+// sysfsSystemPath is where the CPU and NUMA node topology of the host is read
+// from.
+var sysfsSystemPath = "/sys/devices/system"
+
+// cpusetReservation is the set of CPUs and memory nodes reserved for a
+// container.
+type cpusetReservation struct {
+	Cpus string `json:"cpus"`
+	Mems string `json:"mems,omitempty"`
+}
+
+// cpusetState is the state of the allocator of a root, stored in its
+// cpuset.json.
+type cpusetState struct {
+	// Exclusive maps the ids of containers to the CPUs reserved for them.
+	Exclusive map[string]*cpusetReservation `json:"exclusive"`
+	// Shared is the ids of the containers confined to the CPUs that are not
+	// reserved.
+	Shared []string `json:"shared"`
+}
+
+// cpuTopology is the layout of the online CPUs of the host.
+type cpuTopology struct {
+	cpus []int
+	// core maps a CPU to the physical core it belongs to.
+	core map[int]string
+	// node maps a CPU to the NUMA node it belongs to.
+	node map[int]int
+}
+
+// cpusetAllocator hands out exclusive CPUs to the containers of a root. The
+// containers of the root that do not reserve CPUs and do not set their own
+// cpuset share the CPUs nobody reserved, which shrinks and grows as
+// reservations come and go. Containers with an explicit cpuset are left alone.
+//
+// The allocator takes the locks of the containers sharing CPUs while holding
+// its own, so it must not be called with the lock of a container held.
+type cpusetAllocator struct {
+	root string
+}
+
+// assign reserves the exclusive CPUs requested by the resources of the
+// container id, or confines it to the shared CPUs if others have reserved
+// some, and fills in the cpuset of the resources accordingly.
+func (a *cpusetAllocator) assign(id string, r *configs.Resources) error {
+	if r.ExclusiveCpus == 0 {
+		if r.CpusetCpus != "" {
+			return nil
+		}
+		if _, err := os.Stat(filepath.Join(a.root, cpusetStateFilename)); os.IsNotExist(err) {
+			return nil
+		}
+	}
+	if r.ExclusiveCpus < 0 {
+		return fmt.Errorf("invalid number of exclusive cpus %d", r.ExclusiveCpus)
+	}
+	if r.ExclusiveCpus > 0 && r.CpusetCpus != "" {
+		return fmt.Errorf("exclusive cpus cannot be combined with cpuset cpus %q", r.CpusetCpus)
+	}
+	unlock, err := a.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	state, err := a.load()
+	if err != nil {
+		return err
+	}
+	topo, err := readCPUTopology(sysfsSystemPath)
+	if err != nil {
+		return err
+	}
+	if r.ExclusiveCpus == 0 {
+		if len(state.Exclusive) == 0 {
+			return nil
+		}
+		r.CpusetCpus = formatCPUList(sharedCPUs(topo, state))
+		state.Shared = append(state.Shared, id)
+		return a.save(state)
+	}
+	cpus, node, err := allocateCPUs(topo, reservedCPUs(state), r.ExclusiveCpus, r.ExclusiveCpusNumaLocal)
+	if err != nil {
+		return err
+	}
+	res := &cpusetReservation{Cpus: formatCPUList(cpus)}
+	if r.ExclusiveCpusNumaLocal {
+		res.Mems = strconv.Itoa(node)
+		r.CpusetMems = res.Mems
+	}
+	r.CpusetCpus = res.Cpus
+	state.Exclusive[id] = res
+	a.updateShared(state, formatCPUList(sharedCPUs(topo, state)))
+	return a.save(state)
+}
+
+// release gives back the CPUs reserved by the container id to the shared
+// CPUs.
+func (a *cpusetAllocator) release(id string) error {
+	if _, err := os.Stat(filepath.Join(a.root, cpusetStateFilename)); os.IsNotExist(err) {
+		return nil
+	}
+	unlock, err := a.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	state, err := a.load()
+	if err != nil {
+		return err
+	}
+	for i, s := range state.Shared {
+		if s == id {
+			state.Shared = append(state.Shared[:i], state.Shared[i+1:]...)
+			break
+		}
+	}
+	if _, ok := state.Exclusive[id]; ok {
+		delete(state.Exclusive, id)
+		topo, err := readCPUTopology(sysfsSystemPath)
+		if err != nil {
+			return err
+		}
+		a.updateShared(state, formatCPUList(sharedCPUs(topo, state)))
+	}
+	return a.save(state)
+}
+
+// updateShared confines the containers sharing CPUs to cpus. Containers
+// without a cpuset of their own are added to the shared ones, and the ones
+// that no longer exist are dropped.
+func (a *cpusetAllocator) updateShared(state *cpusetState, cpus string) {
+	shared := make(map[string]bool)
+	for _, id := range state.Shared {
+		shared[id] = true
+	}
+	dirs, err := ioutil.ReadDir(a.root)
+	if err != nil {
+		logrus.Warnf("unable to list containers to update their cpuset: %v", err)
+		return
+	}
+	state.Shared = nil
+	for _, dir := range dirs {
+		id := dir.Name()
+		if !dir.IsDir() || state.Exclusive[id] != nil {
+			continue
+		}
+		// Containers being created have no state yet, and the process
+		// creating one holds its lock, which must not be dropped by
+		// locking it again here.
+		path := filepath.Join(a.root, id, stateFilename)
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		isShared, err := a.updateContainer(id, cpus, shared[id])
+		if err != nil {
+			logrus.Warnf("unable to update cpuset of container %s: %v", id, err)
+		}
+		if isShared {
+			state.Shared = append(state.Shared, id)
+		}
+	}
+}
+
+// updateContainer confines the container id to cpus if it is shared or has no
+// cpuset of its own, and reports whether it now shares CPUs.
+func (a *cpusetAllocator) updateContainer(id, cpus string, shared bool) (bool, error) {
+	root := filepath.Join(a.root, id)
+	unlock, err := lockContainer(root, true)
+	if err != nil {
+		return shared, err
+	}
+	defer unlock()
+	path := filepath.Join(root, stateFilename)
+	s, err := readStateFile(path)
+	if err != nil {
+		return shared, err
+	}
+	r := s.Config.Cgroups.Resources
+	if r == nil || (!shared && r.CpusetCpus != "") {
+		return false, nil
+	}
+	if cgroup := s.CgroupPaths["cpuset"]; cgroup != "" {
+		if err := (&fs.CpusetGroup{}).Set(cgroup, &configs.Cgroup{Resources: &configs.Resources{CpusetCpus: cpus}}); err != nil {
+			return true, err
+		}
+	}
+	r.CpusetCpus = cpus
+	return true, writeJSONFile(path, s)
+}
+
+// lock takes the lock of the allocator, which is held across processes until
+// the returned function is called.
+func (a *cpusetAllocator) lock() (func(), error) {
+	f, err := os.OpenFile(filepath.Join(a.root, cpusetLockFilename), os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return func() { f.Close() }, nil
+}
+
+func (a *cpusetAllocator) load() (*cpusetState, error) {
+	state := &cpusetState{}
+	if err := readJSONFile(filepath.Join(a.root, cpusetStateFilename), state); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if state.Exclusive == nil {
+		state.Exclusive = make(map[string]*cpusetReservation)
+	}
+	return state, nil
+}
+
+func (a *cpusetAllocator) save(state *cpusetState) error {
+	path := filepath.Join(a.root, cpusetStateFilename)
+	if len(state.Exclusive) == 0 && len(state.Shared) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	return writeJSONFile(path, state)
+}
+
+func readJSONFile(path string, v interface{}) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return json.NewDecoder(f).Decode(v)
+}
+
+// reservedCPUs returns the CPUs reserved by any container.
+func reservedCPUs(state *cpusetState) map[int]bool {
+	reserved := make(map[int]bool)
+	for id, r := range state.Exclusive {
+		cpus, err := parseCPUList(r.Cpus)
+		if err != nil {
+			logrus.Warnf("invalid cpus reserved by container %s: %v", id, err)
+			continue
+		}
+		for _, cpu := range cpus {
+			reserved[cpu] = true
+		}
+	}
+	return reserved
+}
+
+// sharedCPUs returns the online CPUs that are not reserved by any container.
+func sharedCPUs(topo *cpuTopology, state *cpusetState) []int {
+	reserved := reservedCPUs(state)
+	var cpus []int
+	for _, cpu := range topo.cpus {
+		if !reserved[cpu] {
+			cpus = append(cpus, cpu)
+		}
+	}
+	return cpus
+}
+
+// allocateCPUs picks n of the CPUs that are not reserved, all on the same
+// NUMA node if numaLocal is set, which is returned. CPUs of cores with no
+// reserved CPU are picked first so that containers share cores as little as
+// possible. At least one CPU is always left for the shared CPUs.
+func allocateCPUs(topo *cpuTopology, reserved map[int]bool, n int, numaLocal bool) ([]int, int, error) {
+	var free []int
+	busyCores := make(map[string]bool)
+	for _, cpu := range topo.cpus {
+		if reserved[cpu] {
+			busyCores[topo.core[cpu]] = true
+		} else {
+			free = append(free, cpu)
+		}
+	}
+	if len(free)-n < 1 {
+		return nil, 0, fmt.Errorf("not enough free cpus: %d requested, %d free and one is kept for the shared cpus", n, len(free))
+	}
+	candidates, node := free, 0
+	if numaLocal {
+		byNode := make(map[int][]int)
+		var nodes []int
+		for _, cpu := range free {
+			if _, ok := byNode[topo.node[cpu]]; !ok {
+				nodes = append(nodes, topo.node[cpu])
+			}
+			byNode[topo.node[cpu]] = append(byNode[topo.node[cpu]], cpu)
+		}
+		sort.Ints(nodes)
+		candidates = nil
+		for _, nd := range nodes {
+			if len(byNode[nd]) >= n {
+				candidates, node = byNode[nd], nd
+				break
+			}
+		}
+		if candidates == nil {
+			return nil, 0, fmt.Errorf("not enough free cpus on a single numa node: %d requested", n)
+		}
+	}
+	var idle, rest []int
+	for _, cpu := range candidates {
+		if busyCores[topo.core[cpu]] {
+			rest = append(rest, cpu)
+		} else {
+			idle = append(idle, cpu)
+		}
+	}
+	cpus := append(idle, rest...)[:n]
+	sort.Ints(cpus)
+	return cpus, node, nil
+}
+
+// readCPUTopology reads the topology of the online CPUs from the sysfs
+// directory root.
+func readCPUTopology(root string) (*cpuTopology, error) {
+	online, err := ioutil.ReadFile(filepath.Join(root, "cpu", "online"))
+	if err != nil {
+		return nil, err
+	}
+	cpus, err := parseCPUList(strings.TrimSpace(string(online)))
+	if err != nil {
+		return nil, err
+	}
+	topo := &cpuTopology{
+		cpus: cpus,
+		core: make(map[int]string),
+		node: make(map[int]int),
+	}
+	for _, cpu := range cpus {
+		dir := filepath.Join(root, "cpu", "cpu"+strconv.Itoa(cpu), "topology")
+		pkg, perr := ioutil.ReadFile(filepath.Join(dir, "physical_package_id"))
+		core, cerr := ioutil.ReadFile(filepath.Join(dir, "core_id"))
+		if perr != nil || cerr != nil {
+			// Without a topology every CPU is a core of its own.
+			topo.core[cpu] = "cpu" + strconv.Itoa(cpu)
+			continue
+		}
+		topo.core[cpu] = strings.TrimSpace(string(pkg)) + ":" + strings.TrimSpace(string(core))
+	}
+	nodes, err := filepath.Glob(filepath.Join(root, "node", "node[0-9]*"))
+	if err != nil {
+		return nil, err
+	}
+	for _, dir := range nodes {
+		node, err := strconv.Atoi(strings.TrimPrefix(filepath.Base(dir), "node"))
+		if err != nil {
+			continue
+		}
+		list, err := ioutil.ReadFile(filepath.Join(dir, "cpulist"))
+		if err != nil {
+			return nil, err
+		}
+		if strings.TrimSpace(string(list)) == "" {
+			continue
+		}
+		nodeCPUs, err := parseCPUList(strings.TrimSpace(string(list)))
+		if err != nil {
+			return nil, err
+		}
+		for _, cpu := range nodeCPUs {
+			topo.node[cpu] = node
+		}
+	}
+	return topo, nil
+}
+
+// formatCPUList formats sorted CPUs in the format of cpuset.cpus.
+func formatCPUList(cpus []int) string {
+	var parts []string
+	for i := 0; i < len(cpus); {
+		j := i
+		for j+1 < len(cpus) && cpus[j+1] == cpus[j]+1 {
+			j++
+		}
+		if i == j {
+			parts = append(parts, strconv.Itoa(cpus[i]))
+		} else {
+			parts = append(parts, fmt.Sprintf("%d-%d", cpus[i], cpus[j]))
+		}
+		i = j + 1
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,156 @@
+// +build linux
+
+package libcontainer
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/opencontainers/runc/libcontainer/configs"
+)
+
+// writeFakeTopology lays out 8 CPUs on 2 NUMA nodes of 2 cores with 2
+// threads each: cpus 0-1 and 2-3 are the cores of node 0, cpus 4-5 and 6-7
+// those of node 1.
+func writeFakeTopology(t *testing.T, root string) {
+	write := func(path, data string) {
+		path = filepath.Join(root, path)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(data+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("cpu/online", "0-7")
+	for cpu := 0; cpu < 8; cpu++ {
+		dir := "cpu/cpu" + strconv.Itoa(cpu) + "/topology/"
+		write(dir+"physical_package_id", strconv.Itoa(cpu/4))
+		write(dir+"core_id", strconv.Itoa(cpu%4/2))
+	}
+	write("node/node0/cpulist", "0-3")
+	write("node/node1/cpulist", "4-7")
+}
+
+func writeFakeContainerState(t *testing.T, root, id, cpus string) {
+	if err := os.MkdirAll(filepath.Join(root, id), 0700); err != nil {
+		t.Fatal(err)
+	}
+	state := &State{}
+	state.Config.Cgroups = &configs.Cgroup{Resources: &configs.Resources{CpusetCpus: cpus}}
+	if err := writeJSONFile(filepath.Join(root, id, stateFilename), state); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func fakeContainerCpus(t *testing.T, root, id string) string {
+	var state *State
+	if err := readJSONFile(filepath.Join(root, id, stateFilename), &state); err != nil {
+		t.Fatal(err)
+	}
+	return state.Config.Cgroups.Resources.CpusetCpus
+}
+
+func TestCpusetAllocator(t *testing.T) {
+	sysfs, err := ioutil.TempDir("", "sysfs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(sysfs)
+	writeFakeTopology(t, sysfs)
+	defer func(path string) { sysfsSystemPath = path }(sysfsSystemPath)
+	sysfsSystemPath = sysfs
+
+	root, err := ioutil.TempDir("", "root")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	a := &cpusetAllocator{root: root}
+	writeFakeContainerState(t, root, "shared", "")
+	writeFakeContainerState(t, root, "pinned", "0")
+
+	// Without reservations nothing is confined.
+	r := &configs.Resources{}
+	if err := a.assign("first", r); err != nil {
+		t.Fatal(err)
+	}
+	if r.CpusetCpus != "" {
+		t.Fatalf("expected no cpuset without reservations, got %q", r.CpusetCpus)
+	}
+
+	for _, step := range []struct {
+		id        string
+		n         int
+		numaLocal bool
+		cpus      string
+		mems      string
+		shared    string
+	}{
+		{"a", 2, true, "0-1", "0", "2-7"},
+		{"b", 1, false, "2", "", "3-7"},
+		{"c", 3, true, "4-6", "1", "3,7"},
+	} {
+		r := &configs.Resources{ExclusiveCpus: step.n, ExclusiveCpusNumaLocal: step.numaLocal}
+		if err := a.assign(step.id, r); err != nil {
+			t.Fatalf("reserving %d cpus for %s: %v", step.n, step.id, err)
+		}
+		if r.CpusetCpus != step.cpus || r.CpusetMems != step.mems {
+			t.Fatalf("expected %s to get cpus %q mems %q, got %q %q", step.id, step.cpus, step.mems, r.CpusetCpus, r.CpusetMems)
+		}
+		if cpus := fakeContainerCpus(t, root, "shared"); cpus != step.shared {
+			t.Fatalf("expected shared cpus %q after reserving for %s, got %q", step.shared, step.id, cpus)
+		}
+	}
+	if cpus := fakeContainerCpus(t, root, "pinned"); cpus != "0" {
+		t.Fatalf("expected a container with its own cpuset to be left alone, got %q", cpus)
+	}
+
+	// The last free CPU is kept for the shared ones.
+	if err := a.assign("d", &configs.Resources{ExclusiveCpus: 2}); err == nil {
+		t.Fatal("expected reserving more cpus than are free to fail")
+	}
+	if err := a.assign("d", &configs.Resources{ExclusiveCpus: 2, ExclusiveCpusNumaLocal: true}); err == nil {
+		t.Fatal("expected reserving more cpus than are free on a node to fail")
+	}
+
+	r = &configs.Resources{}
+	if err := a.assign("e", r); err != nil {
+		t.Fatal(err)
+	}
+	if r.CpusetCpus != "3,7" {
+		t.Fatalf("expected a new container to get the shared cpus, got %q", r.CpusetCpus)
+	}
+
+	if err := a.release("b"); err != nil {
+		t.Fatal(err)
+	}
+	if cpus := fakeContainerCpus(t, root, "shared"); cpus != "2-3,7" {
+		t.Fatalf("expected shared cpus %q after releasing, got %q", "2-3,7", cpus)
+	}
+	for _, id := range []string{"a", "c", "e", "shared"} {
+		if err := a.release(id); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(root, cpusetStateFilename)); !os.IsNotExist(err) {
+		t.Fatalf("expected the allocator state to be removed once empty, got %v", err)
+	}
+}
+
+func TestFormatCPUList(t *testing.T) {
+	for expected, cpus := range map[string][]int{
+		"":           nil,
+		"0":          {0},
+		"0-3":        {0, 1, 2, 3},
+		"1,3-4,64":   {1, 3, 4, 64},
+		"0-1,5-6,10": {0, 1, 5, 6, 10},
+	} {
+		if list := formatCPUList(cpus); list != expected {
+			t.Errorf("formatting %v: expected %q, got %q", cpus, expected, list)
+		}
+	}
+}
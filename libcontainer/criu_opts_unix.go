@@ -2,6 +2,11 @@
 
 package libcontainer
 
+// DefaultCriuWorkDirectory is the directory of the state directory of a
+// container CRIU uses as its work directory when CriuOpts.WorkDirectory is not
+// set.
+const DefaultCriuWorkDirectory = "criu.work"
+
 // cgroup restoring strategy provided by criu
 type cgMode uint32
 
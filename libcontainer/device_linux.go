@@ -0,0 +1,121 @@
+// +build linux
+
+package libcontainer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+
+	"github.com/opencontainers/runc/libcontainer/configs"
+	"github.com/opencontainers/runc/libcontainer/utils"
+)
+
+// umountNoFollow is UMOUNT_NOFOLLOW, missing from package syscall.
+const umountNoFollow = 0x8
+
+// setDeviceRule replaces the devices cgroup rules of r for the device with one
+// allowing or denying it. The slices of r are reallocated, so that a copy of r
+// taken before still holds the previous rules.
+func setDeviceRule(r *configs.Resources, device *configs.Device, allow bool) {
+	rule := *device
+	rule.Allow = allow
+	switch {
+	case len(r.Devices) > 0:
+		r.Devices = append(withoutDevice(r.Devices, device), &rule)
+	case r.AllowAllDevices:
+		r.DeniedDevices = withoutDevice(r.DeniedDevices, device)
+		if !allow {
+			r.DeniedDevices = append(r.DeniedDevices, &rule)
+		}
+	default:
+		r.AllowedDevices = withoutDevice(r.AllowedDevices, device)
+		if allow {
+			r.AllowedDevices = append(r.AllowedDevices, &rule)
+		}
+	}
+}
+
+// withoutDevice returns a copy of rules without the ones for the device.
+func withoutDevice(rules []*configs.Device, device *configs.Device) []*configs.Device {
+	var out []*configs.Device
+	for _, r := range rules {
+		if r.Type == device.Type && r.Major == device.Major && r.Minor == device.Minor {
+			continue
+		}
+		out = append(out, r)
+	}
+	return out
+}
+
+// mknodDeviceNode creates the node of device at path, from a mount init
+// process, and returns path resolved of symlinks.
+func mknodDeviceNode(path string, device *configs.Device) (string, error) {
+	dir, err := utils.MkdirAllInRoot("/", filepath.Dir(path), 0755)
+	if err != nil {
+		return "", err
+	}
+	defer dir.Close()
+	resolved, err := os.Readlink(utils.ProcfdPath(dir))
+	if err != nil {
+		return "", err
+	}
+	resolved = filepath.Join(resolved, filepath.Base(path))
+	if err := checkMountDestination("/", resolved); err != nil {
+		return "", err
+	}
+	oldMask := syscall.Umask(0000)
+	defer syscall.Umask(oldMask)
+	if err := mknodDevice(dir, filepath.Base(path), device); err != nil {
+		if os.IsExist(err) {
+			return "", fmt.Errorf("%s already exists in the container", path)
+		}
+		return "", err
+	}
+	return resolved, nil
+}
+
+// removeDeviceNode unmounts what is mounted on the node at path, from a mount
+// init process, and removes it.
+func removeDeviceNode(path string) (string, error) {
+	dir, err := utils.OpenInRoot("/", filepath.Dir(path))
+	if err != nil {
+		return "", err
+	}
+	defer dir.Close()
+	resolved, err := os.Readlink(utils.ProcfdPath(dir))
+	if err != nil {
+		return "", err
+	}
+	resolved = filepath.Join(resolved, filepath.Base(path))
+	if err := checkMountDestination("/", resolved); err != nil {
+		return "", err
+	}
+	// Under user namespaces the node is a bind mount of the one of the host.
+	for {
+		err := syscall.Unmount(resolved, syscall.MNT_DETACH|umountNoFollow)
+		if err == syscall.EINVAL || err == syscall.ENOENT {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+	if err := syscall.Unlinkat(int(dir.Fd()), filepath.Base(path)); err != nil && !os.IsNotExist(err) {
+		return "", err
+	}
+	return resolved, nil
+}
+
+// isDefaultDevice returns whether path is the path of one of the devices
+// created in every container, such as /dev/null.
+func isDefaultDevice(path string) bool {
+	path = utils.CleanPath(path)
+	for _, d := range configs.DefaultSimpleDevices {
+		if d.Path != "" && d.Path == path {
+			return true
+		}
+	}
+	return false
+}
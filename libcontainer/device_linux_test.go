@@ -0,0 +1,104 @@
+// +build linux
+
+package libcontainer
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/opencontainers/runc/libcontainer/configs"
+)
+
+func TestSetDeviceRule(t *testing.T) {
+	fuse := &configs.Device{Type: 'c', Path: "/dev/fuse", Major: 10, Minor: 229, Permissions: "rwm"}
+	r := &configs.Resources{
+		Devices: []*configs.Device{
+			{Type: 'a', Major: configs.Wildcard, Minor: configs.Wildcard, Permissions: "rwm"},
+			{Type: 'c', Major: 10, Minor: 229, Permissions: "rwm"},
+		},
+	}
+	old := *r
+	setDeviceRule(r, fuse, true)
+	if len(r.Devices) != 2 || !r.Devices[1].Allow || r.Devices[1].Path != "/dev/fuse" {
+		t.Fatalf("unexpected rules after allowing: %+v", r.Devices)
+	}
+	if old.Devices[1].Allow {
+		t.Fatal("the previous rules were modified")
+	}
+	setDeviceRule(r, fuse, false)
+	if len(r.Devices) != 2 || r.Devices[1].Allow {
+		t.Fatalf("unexpected rules after denying: %+v", r.Devices)
+	}
+
+	r = &configs.Resources{}
+	setDeviceRule(r, fuse, true)
+	if len(r.AllowedDevices) != 1 {
+		t.Fatalf("expected the device in the allowed devices, got %+v", r.AllowedDevices)
+	}
+	setDeviceRule(r, fuse, false)
+	if len(r.AllowedDevices) != 0 {
+		t.Fatalf("expected no allowed devices, got %+v", r.AllowedDevices)
+	}
+
+	r = &configs.Resources{AllowAllDevices: true}
+	setDeviceRule(r, fuse, false)
+	if len(r.DeniedDevices) != 1 {
+		t.Fatalf("expected the device in the denied devices, got %+v", r.DeniedDevices)
+	}
+	setDeviceRule(r, fuse, true)
+	if len(r.DeniedDevices) != 0 {
+		t.Fatalf("expected no denied devices, got %+v", r.DeniedDevices)
+	}
+}
+
+func TestRemoveDevice(t *testing.T) {
+	root, err := newTestRoot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	kvm := &configs.Device{Type: 'c', Path: "/dev/kvm", Major: 10, Minor: 232, Permissions: "rwm"}
+	null := &configs.Device{Type: 'c', Path: "/dev/null", Major: 1, Minor: 3, Permissions: "rwm"}
+	rules := []*configs.Device{
+		{Type: 'a', Major: configs.Wildcard, Minor: configs.Wildcard, Permissions: "rwm"},
+		{Type: 'c', Major: 1, Minor: 3, Permissions: "rwm", Allow: true},
+		{Type: 'c', Major: 10, Minor: 232, Permissions: "rwm", Allow: true},
+	}
+	container := newRunningTestContainer(t, root, &configs.Config{
+		Namespaces: configs.Namespaces{{Type: configs.NEWNS}},
+		Devices:    []*configs.Device{null, kvm},
+		Cgroups:    &configs.Cgroup{Resources: &configs.Resources{Devices: rules}},
+	})
+
+	err = container.RemoveDevice("/dev/null")
+	if lerr, ok := err.(Error); !ok || lerr.Code() != ConfigInvalid {
+		t.Fatalf("expected removing /dev/null to fail with ConfigInvalid, got %v", err)
+	}
+
+	// The process removing the node cannot be started here, the rule must be
+	// restored.
+	if err := container.RemoveDevice("/dev/kvm"); err == nil {
+		t.Fatal("expected removing the node to fail")
+	}
+	if !reflect.DeepEqual(container.config.Cgroups.Resources.Devices, rules) {
+		t.Fatalf("expected the device rules to be restored, got %+v", container.config.Cgroups.Resources.Devices)
+	}
+	if len(container.config.Devices) != 2 {
+		t.Fatalf("expected the devices to be left alone, got %+v", container.config.Devices)
+	}
+}
+
+func TestIsDefaultDevice(t *testing.T) {
+	for path, expected := range map[string]bool{
+		"/dev/null":        true,
+		"/dev/../dev/zero": true,
+		"/dev/fuse":        false,
+		"/dev/kvm":         false,
+		"":                 false,
+	} {
+		if isDefaultDevice(path) != expected {
+			t.Errorf("expected isDefaultDevice(%q) to be %v", path, expected)
+		}
+	}
+}
@@ -17,6 +17,7 @@ const (
 	ContainerNotStopped
 	ContainerNotRunning
 	ContainerNotPaused
+	ContainerLocked
 
 	// Process errors
 	NoProcessOps
@@ -49,6 +50,8 @@ func (c ErrorCode) String() string {
 		return "Console exists for process"
 	case ContainerNotPaused:
 		return "Container is not paused"
+	case ContainerLocked:
+		return "Container is locked"
 	case NoProcessOps:
 		return "No process operations"
 	default:
@@ -0,0 +1,142 @@
+// +build linux
+
+package libcontainer
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/opencontainers/runc/libcontainer/configs"
+)
+
+// hostResolvConf is the resolv.conf the generated one derives from.
+var hostResolvConf = "/etc/resolv.conf"
+
+// setupEtcFiles writes the /etc files of the container bind mounted by its
+// mounts to the directory of the files. The directory is reachable by the
+// users of the container, which mount the files from it.
+func setupEtcFiles(config *configs.Config) error {
+	files := map[string]func(*configs.Config) ([]byte, error){
+		"hosts":       generateHosts,
+		"hostname":    generateHostname,
+		"resolv.conf": generateResolvConf,
+	}
+	dir := config.EtcFiles.Dir
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	for _, m := range config.Mounts {
+		if filepath.Dir(m.Source) != filepath.Clean(dir) {
+			continue
+		}
+		generate, ok := files[filepath.Base(m.Source)]
+		if !ok {
+			continue
+		}
+		data, err := generate(config)
+		if err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(m.Source, data, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func generateHosts(config *configs.Config) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("127.0.0.1\tlocalhost\n")
+	buf.WriteString("::1\tlocalhost ip6-localhost ip6-loopback\n")
+	buf.WriteString("fe00::0\tip6-localnet\n")
+	buf.WriteString("ff00::0\tip6-mcastprefix\n")
+	buf.WriteString("ff02::1\tip6-allnodes\n")
+	buf.WriteString("ff02::2\tip6-allrouters\n")
+	if config.Hostname != "" {
+		fmt.Fprintf(&buf, "%s\t%s\n", containerAddress(config), config.Hostname)
+	}
+	for _, h := range config.EtcFiles.ExtraHosts {
+		fmt.Fprintf(&buf, "%s\t%s\n", h.IP, h.Hostname)
+	}
+	return buf.Bytes(), nil
+}
+
+// containerAddress returns the address the hostname of the container
+// resolves to: the one of its first interface with an IPv4 address, or a
+// loopback one.
+func containerAddress(config *configs.Config) string {
+	for _, n := range config.Networks {
+		if n.Type == "loopback" || n.Address == "" {
+			continue
+		}
+		if ip, _, err := net.ParseCIDR(n.Address); err == nil {
+			return ip.String()
+		}
+	}
+	return "127.0.1.1"
+}
+
+func generateHostname(config *configs.Config) ([]byte, error) {
+	hostname := config.Hostname
+	if hostname == "" {
+		var err error
+		if hostname, err = os.Hostname(); err != nil {
+			return nil, err
+		}
+	}
+	return []byte(hostname + "\n"), nil
+}
+
+func generateResolvConf(config *configs.Config) ([]byte, error) {
+	e := config.EtcFiles
+	if len(e.Nameservers) == 0 && len(e.Search) == 0 && len(e.Options) == 0 {
+		host, err := ioutil.ReadFile(hostResolvConf)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		return filterResolvConf(host, config.Namespaces.Contains(configs.NEWNET))
+	}
+	var buf bytes.Buffer
+	for _, ns := range e.Nameservers {
+		fmt.Fprintf(&buf, "nameserver %s\n", ns)
+	}
+	if len(e.Search) > 0 {
+		fmt.Fprintf(&buf, "search %s\n", strings.Join(e.Search, " "))
+	}
+	if len(e.Options) > 0 {
+		fmt.Fprintf(&buf, "options %s\n", strings.Join(e.Options, " "))
+	}
+	return buf.Bytes(), nil
+}
+
+// filterResolvConf returns the resolv.conf of the host for a container. In a
+// network namespace of its own, the loopback nameservers of the host cannot be
+// reached and are dropped, and it is an error if none is left.
+func filterResolvConf(host []byte, netns bool) ([]byte, error) {
+	var (
+		buf         bytes.Buffer
+		nameservers int
+	)
+	s := bufio.NewScanner(bytes.NewReader(host))
+	for s.Scan() {
+		line := s.Text()
+		fields := strings.Fields(line)
+		if len(fields) >= 2 && fields[0] == "nameserver" {
+			if ip := net.ParseIP(fields[1]); netns && (ip == nil || ip.IsLoopback()) {
+				continue
+			}
+			nameservers++
+		}
+		buf.WriteString(line + "\n")
+	}
+	if netns && nameservers == 0 {
+		return nil, fmt.Errorf("none of the nameservers of %s can be reached from the network namespace of the container", hostResolvConf)
+	}
+	return buf.Bytes(), nil
+}
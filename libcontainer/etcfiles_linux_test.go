@@ -0,0 +1,90 @@
+// +build linux
+
+package libcontainer
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/opencontainers/runc/libcontainer/configs"
+)
+
+func TestFilterResolvConf(t *testing.T) {
+	host := []byte("# generated\nnameserver 127.0.0.53\nnameserver 10.0.0.1\nnameserver ::1\nsearch example.com\noptions edns0\n")
+
+	got, err := filterResolvConf(host, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "# generated\nnameserver 10.0.0.1\nsearch example.com\noptions edns0\n"
+	if string(got) != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+	if got, err := filterResolvConf(host, false); err != nil || string(got) != string(host) {
+		t.Errorf("expected the host resolv.conf to be kept without a network namespace, got %q %v", got, err)
+	}
+}
+
+func TestFilterResolvConfNoNameserver(t *testing.T) {
+	host := []byte("nameserver 127.0.0.53\nsearch lan\n")
+	if _, err := filterResolvConf(host, true); err == nil {
+		t.Error("expected an error when no nameserver can be reached from the network namespace")
+	}
+	if _, err := filterResolvConf(host, false); err != nil {
+		t.Errorf("expected the loopback nameservers to be kept without a network namespace, got %v", err)
+	}
+}
+
+func TestSetupEtcFiles(t *testing.T) {
+	root, err := ioutil.TempDir("", "etcfiles")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	root = filepath.Join(root, "etc")
+	config := &configs.Config{
+		Hostname: "web",
+		Networks: []*configs.Network{
+			{Type: "loopback"},
+			{Type: "veth", Address: "172.17.0.2/16"},
+		},
+		Mounts: []*configs.Mount{
+			{Source: "/etc/hostname", Destination: "/etc/hostname", Device: "bind"},
+			{Source: filepath.Join(root, "hosts"), Destination: "/etc/hosts", Device: "bind"},
+			{Source: filepath.Join(root, "resolv.conf"), Destination: "/etc/resolv.conf", Device: "bind"},
+		},
+		EtcFiles: &configs.EtcFiles{
+			Dir:         root,
+			ExtraHosts:  []configs.HostEntry{{Hostname: "db", IP: "172.17.0.3"}},
+			Nameservers: []string{"1.1.1.1"},
+			Search:      []string{"a.example", "b.example"},
+		},
+	}
+	if err := setupEtcFiles(config); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(filepath.Join(root, "hostname")); !os.IsNotExist(err) {
+		t.Errorf("expected hostname not to be generated over a mount, got %v", err)
+	}
+	hosts, err := ioutil.ReadFile(filepath.Join(root, "hosts"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{"172.17.0.2\tweb\n", "172.17.0.3\tdb\n"} {
+		if !strings.Contains(string(hosts), line) {
+			t.Errorf("expected hosts to contain %q, got %q", line, hosts)
+		}
+	}
+	resolv, err := ioutil.ReadFile(filepath.Join(root, "resolv.conf"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "nameserver 1.1.1.1\nsearch a.example b.example\n"; string(resolv) != want {
+		t.Errorf("expected resolv.conf %q, got %q", want, resolv)
+	}
+}
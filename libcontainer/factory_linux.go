@@ -3,7 +3,6 @@
 package libcontainer
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
@@ -106,7 +105,11 @@ func TmpfsRoot(l *LinuxFactory) error {
 // configures the factory with the provided option funcs.
 func New(root string, options ...func(*LinuxFactory) error) (Factory, error) {
 	if root != "" {
-		if err := os.MkdirAll(root, 0700); err != nil {
+		// The root is searchable, but not listable, by other users so that the
+		// root of a user namespace, mapped to another user on the host, can
+		// reach the /etc files generated in the state directory of its
+		// container. An existing root keeps its permissions.
+		if err := os.MkdirAll(root, 0711); err != nil {
 			return nil, newGenericError(err, SystemError)
 		}
 	}
@@ -159,12 +162,41 @@ func (l *LinuxFactory) Create(id string, config *configs.Config) (Container, err
 		return nil, newGenericError(err, ConfigInvalid)
 	}
 	containerRoot := filepath.Join(l.Root, id)
-	if _, err := os.Stat(containerRoot); err == nil {
-		return nil, newGenericError(fmt.Errorf("container with id exists: %v", id), IdInUse)
-	} else if !os.IsNotExist(err) {
+	if err := os.Mkdir(containerRoot, 0700); err != nil {
+		if os.IsExist(err) {
+			return nil, newGenericError(fmt.Errorf("container with id exists: %v", id), IdInUse)
+		}
 		return nil, newGenericError(err, SystemError)
 	}
-	if err := os.MkdirAll(containerRoot, 0700); err != nil {
+	if config.EtcFiles != nil && config.Namespaces.Contains(configs.NEWUSER) {
+		if err := l.makeSearchable(containerRoot); err != nil {
+			os.RemoveAll(containerRoot)
+			return nil, newGenericError(err, SystemError)
+		}
+	}
+	// The CPUs are assigned before the container is locked, as the allocator
+	// takes the locks of the containers sharing CPUs under its own. The new
+	// container has no state yet, so it is not one of them.
+	allocator := &cpusetAllocator{root: l.Root}
+	if config.Cgroups != nil && config.Cgroups.Resources != nil {
+		if err := allocator.assign(id, config.Cgroups.Resources); err != nil {
+			os.RemoveAll(containerRoot)
+			return nil, newGenericError(err, SystemError)
+		}
+	}
+	unlock, err := lockContainer(containerRoot, true)
+	if err != nil {
+		os.RemoveAll(containerRoot)
+		allocator.release(id)
+		return nil, err
+	}
+	if config.EtcFiles != nil {
+		err = setupEtcFiles(config)
+	}
+	unlock()
+	if err != nil {
+		os.RemoveAll(containerRoot)
+		allocator.release(id)
 		return nil, newGenericError(err, SystemError)
 	}
 	c := &linuxContainer{
@@ -180,12 +212,37 @@ func (l *LinuxFactory) Create(id string, config *configs.Config) (Container, err
 	return c, nil
 }
 
+// makeSearchable lets any user search the state directory of a container, so
+// that the root of a user namespace, which is mapped to another user on the
+// host, can reach the /etc files of the state directory bind mounted in the
+// container. The content of the directory cannot be listed, and the files keep
+// their own permissions. The root directory is left alone: it has to be
+// searchable already, as New creates it.
+func (l *LinuxFactory) makeSearchable(containerRoot string) error {
+	fi, err := os.Stat(l.Root)
+	if err != nil {
+		return err
+	}
+	if fi.Mode().Perm()&0011 != 0011 {
+		return fmt.Errorf("root %s must be searchable by other users (mode 0711) for the /etc files of a container in a user namespace", l.Root)
+	}
+	return os.Chmod(containerRoot, 0711)
+}
+
 func (l *LinuxFactory) Load(id string) (Container, error) {
 	if l.Root == "" {
 		return nil, newGenericError(fmt.Errorf("invalid root"), ConfigInvalid)
 	}
+	if err := l.validateID(id); err != nil {
+		return nil, err
+	}
 	containerRoot := filepath.Join(l.Root, id)
+	unlock, err := lockContainer(containerRoot, false)
+	if err != nil {
+		return nil, err
+	}
 	state, err := l.loadState(containerRoot)
+	unlock()
 	if err != nil {
 		return nil, err
 	}
@@ -204,6 +261,7 @@ func (l *LinuxFactory) Load(id string) (Container, error) {
 		cgroupManager: l.NewCgroupsManager(state.Config.Cgroups, state.CgroupPaths),
 		root:          containerRoot,
 		created:       state.Created,
+		addedMounts:   state.AddedMounts,
 	}
 	c.state = &createdState{c: c, s: Created}
 	if err := c.refreshState(); err != nil {
@@ -237,11 +295,8 @@ func (l *LinuxFactory) StartInitialization() (err error) {
 		// send it back to the parent process in the form of an initError.
 		// If container's init successed, syscall.Exec will not return, hence
 		// this defer function will never be called.
-		if _, ok := i.(*linuxStandardInit); ok {
-			//  Synchronisation only necessary for standard init.
-			if err := utils.WriteJSON(pipe, syncT{procError}); err != nil {
-				panic(err)
-			}
+		if err := utils.WriteJSON(pipe, syncT{procError}); err != nil {
+			panic(err)
 		}
 		if err := utils.WriteJSON(pipe, newSystemError(err)); err != nil {
 			panic(err)
@@ -263,19 +318,16 @@ func (l *LinuxFactory) StartInitialization() (err error) {
 	return i.Init()
 }
 
+// loadState reads the state stored in root, migrating it from the format of
+// the libcontainer that stored it to the current one.
 func (l *LinuxFactory) loadState(root string) (*State, error) {
-	f, err := os.Open(filepath.Join(root, stateFilename))
+	state, err := readStateFile(filepath.Join(root, stateFilename))
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil, newGenericError(err, ContainerNotExists)
 		}
 		return nil, newGenericError(err, SystemError)
 	}
-	defer f.Close()
-	var state *State
-	if err := json.NewDecoder(f).Decode(&state); err != nil {
-		return nil, newGenericError(err, SystemError)
-	}
 	return state, nil
 }
 
@@ -205,3 +205,67 @@ type unserializableHook struct{}
 func (unserializableHook) Run(configs.HookState) error {
 	return nil
 }
+
+func TestFactoryMakeSearchable(t *testing.T) {
+	root, rerr := newTestRoot()
+	if rerr != nil {
+		t.Fatal(rerr)
+	}
+	defer os.RemoveAll(root)
+	containerRoot := filepath.Join(root, "test")
+	if err := os.Mkdir(containerRoot, 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	// The root is never changed, it has to be searchable already.
+	if err := os.Chmod(root, 0700); err != nil {
+		t.Fatal(err)
+	}
+	l := &LinuxFactory{Root: root}
+	if err := l.makeSearchable(containerRoot); err == nil {
+		t.Fatal("expected an error for a root that is not searchable")
+	}
+	if err := os.Chmod(root, 0711); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.makeSearchable(containerRoot); err != nil {
+		t.Fatal(err)
+	}
+	for dir, expected := range map[string]os.FileMode{root: 0711, containerRoot: 0711} {
+		fi, err := os.Stat(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if mode := fi.Mode().Perm(); mode != expected {
+			t.Errorf("expected %s to have mode %o, got %o", dir, expected, mode)
+		}
+	}
+}
+
+func TestFactoryNewSearchableRoot(t *testing.T) {
+	root, rerr := newTestRoot()
+	if rerr != nil {
+		t.Fatal(rerr)
+	}
+	defer os.RemoveAll(root)
+	created := filepath.Join(root, "created")
+	if _, err := New(created); err != nil {
+		t.Fatal(err)
+	}
+	existing := filepath.Join(root, "existing")
+	if err := os.Mkdir(existing, 0700); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := New(existing); err != nil {
+		t.Fatal(err)
+	}
+	for dir, expected := range map[string]os.FileMode{created: 0711, existing: 0700} {
+		fi, err := os.Stat(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if mode := fi.Mode().Perm(); mode != expected {
+			t.Errorf("expected %s to have mode %o, got %o", dir, expected, mode)
+		}
+	}
+}
@@ -17,6 +17,8 @@ const (
 	procRun
 	procHooks
 	procResume
+	procSeccomp
+	procMountFds
 )
 
 type syncT struct {
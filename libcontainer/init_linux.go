@@ -27,6 +27,7 @@ type initType string
 const (
 	initSetns    initType = "setns"
 	initStandard initType = "standard"
+	initMount    initType = "mount"
 )
 
 type pid struct {
@@ -44,20 +45,23 @@ type network struct {
 
 // initConfig is used for transferring parameters from Exec() to Init()
 type initConfig struct {
-	Args             []string         `json:"args"`
-	Env              []string         `json:"env"`
-	Cwd              string           `json:"cwd"`
-	Capabilities     []string         `json:"capabilities"`
-	ProcessLabel     string           `json:"process_label"`
-	AppArmorProfile  string           `json:"apparmor_profile"`
-	NoNewPrivileges  bool             `json:"no_new_privileges"`
-	User             string           `json:"user"`
-	Config           *configs.Config  `json:"config"`
-	Console          string           `json:"console"`
-	Networks         []*network       `json:"network"`
-	PassedFilesCount int              `json:"passed_files_count"`
-	ContainerId      string           `json:"containerid"`
-	Rlimits          []configs.Rlimit `json:"rlimits"`
+	Args             []string            `json:"args"`
+	Env              []string            `json:"env"`
+	Cwd              string              `json:"cwd"`
+	Capabilities     []string            `json:"capabilities"`
+	ProcessLabel     string              `json:"process_label"`
+	AppArmorProfile  string              `json:"apparmor_profile"`
+	NoNewPrivileges  bool                `json:"no_new_privileges"`
+	User             string              `json:"user"`
+	Config           *configs.Config     `json:"config"`
+	Console          string              `json:"console"`
+	Networks         []*network          `json:"network"`
+	PassedFilesCount int                 `json:"passed_files_count"`
+	ContainerId      string              `json:"containerid"`
+	Rlimits          []configs.Rlimit    `json:"rlimits"`
+	Scheduler        *configs.Scheduler  `json:"scheduler,omitempty"`
+	IOPriority       *configs.IOPriority `json:"io_priority,omitempty"`
+	CPUAffinity      string              `json:"cpu_affinity,omitempty"`
 }
 
 type initer interface {
@@ -65,6 +69,9 @@ type initer interface {
 }
 
 func newContainerInit(t initType, pipe *os.File) (initer, error) {
+	if t == initMount {
+		return &linuxMountInit{pipe: pipe}, nil
+	}
 	var config *initConfig
 	if err := json.NewDecoder(pipe).Decode(&config); err != nil {
 		return nil, err
@@ -75,6 +82,7 @@ func newContainerInit(t initType, pipe *os.File) (initer, error) {
 	switch t {
 	case initSetns:
 		return &linuxSetnsInit{
+			pipe:   pipe,
 			config: config,
 		}, nil
 	case initStandard:
@@ -189,6 +197,64 @@ func syncParentHooks(pipe io.ReadWriter) error {
 	return nil
 }
 
+// syncParentSeccomp passes the seccomp listener fd, if the filter has one, to
+// the parent along with a JSON payload so that it can be forwarded to the
+// seccomp agent. Our copy of the fd is closed.
+func syncParentSeccomp(pipe *os.File, seccompFd int) error {
+	if seccompFd == -1 {
+		return nil
+	}
+	defer syscall.Close(seccompFd)
+	data, err := json.Marshal(syncT{procSeccomp})
+	if err != nil {
+		return err
+	}
+	return utils.SendFds(pipe, data, seccompFd)
+}
+
+// syncParentMountFds asks the parent for the n idmapped mounts of the
+// container, which it sends back one per JSON payload in the order of the
+// config.
+func syncParentMountFds(pipe *os.File, n int) ([]*os.File, error) {
+	if n == 0 {
+		return nil, nil
+	}
+	if err := utils.WriteJSON(pipe, syncT{procMountFds}); err != nil {
+		return nil, err
+	}
+	r := utils.NewFdReader(pipe)
+	defer r.Close()
+	dec := json.NewDecoder(r)
+	var mounts []*os.File
+	for i := 0; i < n; i++ {
+		var procSync syncT
+		if err := dec.Decode(&procSync); err != nil {
+			closeFiles(mounts)
+			if err == io.EOF {
+				return nil, fmt.Errorf("parent closed synchronisation channel")
+			}
+			return nil, err
+		}
+		if procSync.Type != procMountFds {
+			closeFiles(mounts)
+			return nil, fmt.Errorf("invalid synchronisation flag from parent")
+		}
+		fd, err := r.TakeFd()
+		if err != nil {
+			closeFiles(mounts)
+			return nil, err
+		}
+		mounts = append(mounts, os.NewFile(uintptr(fd), "idmapped mount"))
+	}
+	return mounts, nil
+}
+
+func closeFiles(files []*os.File) {
+	for _, f := range files {
+		f.Close()
+	}
+}
+
 // setupUser changes the groups, gid, and uid for the user inside the container
 func setupUser(config *initConfig) error {
 	// Set up defaults.
@@ -331,17 +397,11 @@ func setOomScoreAdj(oomScoreAdj int, pid int) error {
 	return ioutil.WriteFile(path, []byte(strconv.Itoa(oomScoreAdj)), 0600)
 }
 
-// killCgroupProcesses freezes then iterates over all the processes inside the
-// manager's cgroups sending a SIGKILL to each process then waiting for them to
-// exit.
+// killCgroupProcesses kills all the processes inside the manager's cgroups
+// then waits for them to exit.
 func killCgroupProcesses(m cgroups.Manager) error {
-	var procs []*os.Process
-	if err := m.Freeze(configs.Frozen); err != nil {
-		logrus.Warn(err)
-	}
-	pids, err := m.GetAllPids()
+	pids, err := cgroups.KillAll(m)
 	if err != nil {
-		m.Freeze(configs.Thawed)
 		return err
 	}
 	for _, pid := range pids {
@@ -350,15 +410,6 @@ func killCgroupProcesses(m cgroups.Manager) error {
 			logrus.Warn(err)
 			continue
 		}
-		procs = append(procs, p)
-		if err := p.Kill(); err != nil {
-			logrus.Warn(err)
-		}
-	}
-	if err := m.Freeze(configs.Thawed); err != nil {
-		logrus.Warn(err)
-	}
-	for _, p := range procs {
 		if _, err := p.Wait(); err != nil {
 			logrus.Warn(err)
 		}
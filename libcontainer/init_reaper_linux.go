@@ -0,0 +1,81 @@
+// +build linux
+
+package libcontainer
+
+import (
+	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
+
+	"github.com/opencontainers/runc/libcontainer/utils"
+)
+
+// runInit runs the process of the container as the child of its init, which
+// stays PID 1 of the container: it forwards the signals it receives to the
+// process, reaps all the processes reparented to it, and exits with the
+// status of the process. Its parent stops waiting on the init pipe once the
+// process is started. With a console, the process is moved to a foreground
+// process group of its own, so that the signals of the terminal are not sent
+// to it twice.
+func runInit(pipe *os.File, args []string, env []string, console bool) error {
+	name, err := exec.LookPath(args[0])
+	if err != nil {
+		return err
+	}
+	// Subscribe to the signals before starting the process, so that neither a
+	// signal for it nor its exit are missed.
+	signals := make(chan os.Signal, 128)
+	signal.Notify(signals)
+	attr := &os.ProcAttr{
+		Env:   env,
+		Files: []*os.File{os.Stdin, os.Stdout, os.Stderr},
+	}
+	if console {
+		attr.Sys = &syscall.SysProcAttr{
+			Setpgid:    true,
+			Foreground: true,
+			Ctty:       0,
+		}
+	}
+	// The process must not inherit the init pipe, which is only closed, to
+	// tell the parent that the container started, once it is.
+	syscall.CloseOnExec(int(pipe.Fd()))
+	process, err := os.StartProcess(name, args, attr)
+	if err != nil {
+		return err
+	}
+	pipe.Close()
+	for s := range signals {
+		switch s {
+		case syscall.SIGCHLD:
+			if status, exited := reapChildren(process.Pid); exited {
+				os.Exit(utils.ExitStatus(status))
+			}
+		case syscall.SIGURG:
+			// Used by the go runtime to preempt goroutines.
+		default:
+			// The process may have exited, it is reaped on SIGCHLD.
+			syscall.Kill(process.Pid, s.(syscall.Signal))
+		}
+	}
+	return nil
+}
+
+// reapChildren reaps all the exited children, and returns the status of the
+// process pid if it is one of them.
+func reapChildren(pid int) (status syscall.WaitStatus, exited bool) {
+	for {
+		var ws syscall.WaitStatus
+		p, err := syscall.Wait4(-1, &ws, syscall.WNOHANG, nil)
+		if err == syscall.EINTR {
+			continue
+		}
+		if err != nil || p <= 0 {
+			return status, exited
+		}
+		if p == pid {
+			status, exited = ws, true
+		}
+	}
+}
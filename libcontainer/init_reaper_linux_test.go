@@ -0,0 +1,110 @@
+// +build linux
+
+package libcontainer
+
+import (
+	"bufio"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"syscall"
+	"testing"
+)
+
+// TestInitHelperProcess runs the command following "--" under runInit, with
+// the init pipe given as fd 3.
+func TestInitHelperProcess(t *testing.T) {
+	if os.Getenv("INIT_HELPER") == "" {
+		return
+	}
+	args := os.Args
+	for len(args) > 0 && args[0] != "--" {
+		args = args[1:]
+	}
+	if err := runInit(os.NewFile(3, "pipe"), args[1:], os.Environ(), false); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// startInit starts args under runInit in a helper process, and returns it
+// once the init pipe was closed.
+func startInit(t *testing.T, args ...string) (*exec.Cmd, *bufio.Reader) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	cmd := exec.Command(os.Args[0], append([]string{"-test.run=TestInitHelperProcess", "--"}, args...)...)
+	cmd.Env = append(os.Environ(), "INIT_HELPER=1")
+	cmd.ExtraFiles = []*os.File{w}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = cmd.Start()
+	w.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The pipe is closed by the init, and not inherited by the process.
+	if _, err := ioutil.ReadAll(r); err != nil {
+		t.Fatal(err)
+	}
+	return cmd, bufio.NewReader(stdout)
+}
+
+func TestRunInitExitStatus(t *testing.T) {
+	cmd, _ := startInit(t, "sh", "-c", "sleep 0.1 & exit 7")
+	err := cmd.Wait()
+	if status, ok := err.(*exec.ExitError); !ok || status.Sys().(syscall.WaitStatus).ExitStatus() != 7 {
+		t.Fatalf("expected the init to exit with the status of the process, got %v", err)
+	}
+}
+
+func TestRunInitForwardsSignals(t *testing.T) {
+	cmd, stdout := startInit(t, "sh", "-c", `trap "exit 5" TERM; echo ready; while :; do sleep 0.1; done`)
+	if line, err := stdout.ReadString('\n'); err != nil || line != "ready\n" {
+		cmd.Process.Kill()
+		cmd.Wait()
+		t.Fatalf("expected the process to start: %q %v", line, err)
+	}
+	if err := cmd.Process.Signal(syscall.SIGTERM); err != nil {
+		t.Fatal(err)
+	}
+	err := cmd.Wait()
+	if status, ok := err.(*exec.ExitError); !ok || status.Sys().(syscall.WaitStatus).ExitStatus() != 5 {
+		t.Fatalf("expected the process to exit on the forwarded signal, got %v", err)
+	}
+}
+
+func TestReapChildren(t *testing.T) {
+	start := func(status string) int {
+		p, err := os.StartProcess("/bin/sh", []string{"sh", "-c", "exit " + status}, &os.ProcAttr{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return p.Pid
+	}
+	other := start("1")
+	pid := start("3")
+	// Wait for both to exit without reaping them: waitid(P_PID, p, NULL,
+	// WEXITED|WNOWAIT).
+	for _, p := range []int{other, pid} {
+		for {
+			_, _, errno := syscall.Syscall6(syscall.SYS_WAITID, 1, uintptr(p), 0, syscall.WEXITED|syscall.WNOWAIT, 0, 0)
+			if errno != syscall.EINTR {
+				break
+			}
+		}
+	}
+	status, exited := reapChildren(pid)
+	if !exited || status.ExitStatus() != 3 {
+		t.Fatalf("expected the process to be reaped with status 3, got %v %v", status, exited)
+	}
+	if _, err := syscall.Wait4(other, nil, syscall.WNOHANG, nil); err != syscall.ECHILD {
+		t.Fatalf("expected the other child to be reaped too, got %v", err)
+	}
+	if _, exited := reapChildren(pid); exited {
+		t.Fatal("expected no child left to reap")
+	}
+}
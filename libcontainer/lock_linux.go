@@ -0,0 +1,74 @@
+// +build linux
+
+package libcontainer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+)
+
+const lockFilename = "state.lock"
+
+// lockTimeout is how long to wait for a container locked by another process.
+var lockTimeout = 10 * time.Second
+
+// lockContainer locks the state of the container whose state directory is
+// root against the other processes operating on it, shared for reading it or
+// exclusive for changing it, and returns the function releasing the lock.
+//
+// The lock is a POSIX record lock on a file of the state directory so that the
+// process holding it can be reported. Such locks belong to the process and are
+// dropped as soon as it closes any descriptor of the file, so a process must
+// not lock the same container twice at once.
+func lockContainer(root string, exclusive bool) (func(), error) {
+	path := filepath.Join(root, lockFilename)
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|syscall.O_CLOEXEC, 0600)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, newGenericError(fmt.Errorf("container does not exist"), ContainerNotExists)
+		}
+		return nil, newSystemErrorWithCause(err, "opening container lock")
+	}
+	lk := syscall.Flock_t{
+		Type: syscall.F_RDLCK,
+	}
+	if exclusive {
+		lk.Type = syscall.F_WRLCK
+	}
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		err := syscall.FcntlFlock(f.Fd(), syscall.F_SETLK, &lk)
+		if err == nil {
+			break
+		}
+		if err != syscall.EAGAIN && err != syscall.EACCES {
+			f.Close()
+			return nil, newSystemErrorWithCause(err, "locking container")
+		}
+		if time.Now().After(deadline) {
+			holder := lk
+			if err := syscall.FcntlFlock(f.Fd(), syscall.F_GETLK, &holder); err != nil || holder.Type == syscall.F_UNLCK {
+				// The lock was released in the meantime.
+				continue
+			}
+			f.Close()
+			return nil, newGenericError(fmt.Errorf("container is locked by pid %d", holder.Pid), ContainerLocked)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	// The container may have been destroyed, and its state directory removed,
+	// while we were waiting for the lock.
+	var st, fst syscall.Stat_t
+	if err := syscall.Fstat(int(f.Fd()), &fst); err != nil {
+		f.Close()
+		return nil, newSystemErrorWithCause(err, "locking container")
+	}
+	if err := syscall.Stat(path, &st); err != nil || st.Ino != fst.Ino || st.Dev != fst.Dev {
+		f.Close()
+		return nil, newGenericError(fmt.Errorf("container does not exist"), ContainerNotExists)
+	}
+	return func() { f.Close() }, nil
+}
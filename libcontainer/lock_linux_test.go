@@ -0,0 +1,167 @@
+// +build linux
+
+package libcontainer
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/opencontainers/runc/libcontainer/configs"
+)
+
+// TestLockHelperProcess holds the lock of the container in LOCK_HELPER_ROOT
+// until its stdin is closed.
+func TestLockHelperProcess(t *testing.T) {
+	root := os.Getenv("LOCK_HELPER_ROOT")
+	if root == "" {
+		return
+	}
+	unlock, err := lockContainer(root, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer unlock()
+	fmt.Println("locked")
+	ioutil.ReadAll(os.Stdin)
+}
+
+func TestLockContainer(t *testing.T) {
+	root, err := ioutil.TempDir("", "container")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	defer func(timeout time.Duration) { lockTimeout = timeout }(lockTimeout)
+	lockTimeout = 100 * time.Millisecond
+
+	cmd := exec.Command(os.Args[0], "-test.run=TestLockHelperProcess")
+	cmd.Env = append(os.Environ(), "LOCK_HELPER_ROOT="+root)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+	if line, err := bufio.NewReader(stdout).ReadString('\n'); err != nil || line != "locked\n" {
+		stdin.Close()
+		cmd.Wait()
+		t.Fatalf("helper failed to lock the container: %q %v", line, err)
+	}
+
+	_, err = lockContainer(root, false)
+	stdin.Close()
+	if werr := cmd.Wait(); werr != nil {
+		t.Fatalf("helper failed: %v", werr)
+	}
+	if err == nil {
+		t.Fatal("expected locking a container locked by another process to fail")
+	}
+	if lerr, ok := err.(Error); !ok || lerr.Code() != ContainerLocked {
+		t.Fatalf("expected a ContainerLocked error, got %v", err)
+	}
+	if expected := fmt.Sprintf("locked by pid %d", cmd.Process.Pid); !strings.Contains(err.Error(), expected) {
+		t.Fatalf("expected error %q to contain %q", err, expected)
+	}
+
+	unlock, err := lockContainer(root, true)
+	if err != nil {
+		t.Fatalf("expected the lock to be acquired once released: %v", err)
+	}
+	unlock()
+
+	if err := os.RemoveAll(root); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := lockContainer(root, false); err == nil {
+		t.Fatal("expected locking a removed container to fail")
+	} else if lerr, ok := err.(Error); !ok || lerr.Code() != ContainerNotExists {
+		t.Fatalf("expected a ContainerNotExists error, got %v", err)
+	}
+}
+
+// TestHookHelperProcess loads the container HOOK_HELPER_ID of the root
+// HOOK_HELPER_ROOT and gets its state, as a hook calling back into runc does.
+func TestHookHelperProcess(t *testing.T) {
+	root := os.Getenv("HOOK_HELPER_ROOT")
+	if root == "" {
+		return
+	}
+	lockTimeout = 100 * time.Millisecond
+	factory, err := New(root, Cgroupfs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	container, err := factory.Load(os.Getenv("HOOK_HELPER_ID"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := container.State(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRunHooksUnlocked(t *testing.T) {
+	root, err := newTestRoot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	id := "hooked"
+	if err := os.Mkdir(filepath.Join(root, id), 0700); err != nil {
+		t.Fatal(err)
+	}
+	state := &State{
+		BaseState: BaseState{
+			ID:             id,
+			InitProcessPid: os.Getpid(),
+			Config:         configs.Config{Rootfs: "/mycontainer/root"},
+		},
+		StateVersion: stateVersion,
+	}
+	if err := marshal(filepath.Join(root, id, stateFilename), state); err != nil {
+		t.Fatal(err)
+	}
+	factory, err := New(root, Cgroupfs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	container, err := factory.Load(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := container.(*linuxContainer)
+	hook := configs.NewCommandHook(configs.Command{
+		Path: os.Args[0],
+		Args: []string{os.Args[0], "-test.run=TestHookHelperProcess"},
+		Env:  append(os.Environ(), "HOOK_HELPER_ROOT="+root, "HOOK_HELPER_ID="+id),
+	})
+	s := configs.HookState{ID: id, Root: c.config.Rootfs}
+
+	if err := c.lockExclusive(); err != nil {
+		t.Fatal(err)
+	}
+	defer c.unlockExclusive()
+	// The hook cannot reach the container while the lock is held...
+	if err := hook.Run(s); err == nil {
+		t.Fatal("expected the hook to fail to load the locked container")
+	}
+	// ...but runHooks releases it while hooks run.
+	if err := c.runHooks("poststart", []configs.Hook{hook}, s); err != nil {
+		t.Fatalf("expected the hook to load the container and get its state: %v", err)
+	}
+	if c.unlock == nil {
+		t.Fatal("expected the lock to be taken again after the hooks")
+	}
+}
@@ -0,0 +1,109 @@
+// +build linux
+
+package libcontainer
+
+import (
+	"encoding/json"
+	"os"
+	"syscall"
+
+	"github.com/opencontainers/runc/libcontainer/system"
+	"github.com/opencontainers/runc/libcontainer/utils"
+)
+
+// linuxMountInit attaches or unmounts a mount in the mount namespace of a
+// running container, joined by the C code before the go runtime booted: "/" is
+// the rootfs of the container.
+type linuxMountInit struct {
+	pipe *os.File
+}
+
+func (l *linuxMountInit) Init() error {
+	r := utils.NewFdReader(l.pipe)
+	defer r.Close()
+	var req *mountRequest
+	if err := json.NewDecoder(r).Decode(&req); err != nil {
+		return err
+	}
+	var (
+		dest string
+		err  error
+	)
+	switch {
+	case req.Remove && req.Device != nil:
+		dest, err = removeDeviceNode(req.Destination)
+	case req.Remove:
+		dest, err = detachMount(req.Destination)
+	case req.Device != nil:
+		dest, err = mknodDeviceNode(req.Destination, req.Device)
+	default:
+		fd, ferr := r.TakeFd()
+		if ferr != nil {
+			return ferr
+		}
+		tree := os.NewFile(uintptr(fd), "mount tree")
+		defer tree.Close()
+		dest, err = attachMount(req.Destination, tree)
+	}
+	if err != nil {
+		return err
+	}
+	if err := utils.WriteJSON(l.pipe, syncT{procReady}); err != nil {
+		return err
+	}
+	if err := utils.WriteJSON(l.pipe, mountResponse{Destination: dest}); err != nil {
+		return err
+	}
+	os.Exit(0)
+	return nil
+}
+
+// attachMount moves tree to destination, created if missing, and returns the
+// destination resolved of symlinks.
+func attachMount(destination string, tree *os.File) (string, error) {
+	fi, err := tree.Stat()
+	if err != nil {
+		return "", err
+	}
+	var dest *os.File
+	if fi.IsDir() {
+		dest, err = utils.MkdirAllInRoot("/", destination, 0755)
+	} else {
+		dest, err = utils.CreateInRoot("/", destination, 0755)
+	}
+	if err != nil {
+		return "", err
+	}
+	defer dest.Close()
+	resolved, err := os.Readlink(utils.ProcfdPath(dest))
+	if err != nil {
+		return "", err
+	}
+	if err := checkMountDestination("/", resolved); err != nil {
+		return "", err
+	}
+	if err := system.MoveMount(int(tree.Fd()), "", int(dest.Fd()), "", system.MOVE_MOUNT_F_EMPTY_PATH|system.MOVE_MOUNT_T_EMPTY_PATH); err != nil {
+		return "", mountAPIError("mounts added to a running container", err)
+	}
+	return resolved, nil
+}
+
+// detachMount lazily unmounts the mount at destination.
+func detachMount(destination string) (string, error) {
+	dest, err := utils.OpenInRoot("/", destination)
+	if err != nil {
+		return "", err
+	}
+	defer dest.Close()
+	resolved, err := os.Readlink(utils.ProcfdPath(dest))
+	if err != nil {
+		return "", err
+	}
+	if err := checkMountDestination("/", resolved); err != nil {
+		return "", err
+	}
+	if err := syscall.Unmount(utils.ProcfdPath(dest), syscall.MNT_DETACH); err != nil {
+		return "", err
+	}
+	return resolved, nil
+}
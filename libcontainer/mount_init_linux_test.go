@@ -0,0 +1,116 @@
+// +build linux
+
+package libcontainer
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/opencontainers/runc/libcontainer/configs"
+	"github.com/opencontainers/runc/libcontainer/utils"
+)
+
+// TestMountInitHelperProcess runs the mount init on the pipe it is given as
+// fd 3, reporting its error like StartInitialization. "/" is the rootfs of the
+// host.
+func TestMountInitHelperProcess(t *testing.T) {
+	if os.Getenv("MOUNT_INIT_HELPER") == "" {
+		return
+	}
+	pipe := os.NewFile(3, "pipe")
+	l := &linuxMountInit{pipe: pipe}
+	if err := l.Init(); err != nil {
+		utils.WriteJSON(pipe, syncT{procError})
+		utils.WriteJSON(pipe, newSystemError(err))
+	}
+}
+
+// runMountInit runs the mount init in a helper process for req.
+func runMountInit(t *testing.T, req *mountRequest, tree *os.File) (string, error) {
+	parentPipe, childPipe, err := newPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer parentPipe.Close()
+	cmd := exec.Command(os.Args[0], "-test.run=TestMountInitHelperProcess")
+	cmd.Env = append(os.Environ(), "MOUNT_INIT_HELPER=1")
+	cmd.ExtraFiles = []*os.File{childPipe}
+	err = cmd.Start()
+	childPipe.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := &mountProcess{cmd: cmd, parentPipe: parentPipe}
+	defer p.wait()
+	return p.run(req, tree)
+}
+
+func TestMountInit(t *testing.T) {
+	if os.Getuid() != 0 {
+		t.Skip("mounting requires root")
+	}
+	dir, err := ioutil.TempDir("", "mountinit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	// Resolve /tmp, if it is a symlink, to compare with the destinations
+	// returned.
+	if dir, err = filepath.EvalSymlinks(dir); err != nil {
+		t.Fatal(err)
+	}
+	source := filepath.Join(dir, "source")
+	if err := os.Mkdir(source, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(source, "file"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	tree, err := openMountTree(&configs.Mount{Source: source, Device: "bind", Flags: syscall.MS_BIND}, os.Getpid())
+	if err != nil {
+		if os.IsPermission(err) || err == syscall.ENOSYS {
+			t.Skipf("the mount API is not available: %v", err)
+		}
+		t.Fatal(err)
+	}
+	defer tree.Close()
+
+	// The missing destination is created through a symlink resolved in "/".
+	dest := filepath.Join(dir, "a", "b")
+	if err := os.Mkdir(filepath.Join(dir, "a"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(dir, "a"), filepath.Join(dir, "link")); err != nil {
+		t.Fatal(err)
+	}
+	resolved, err := runMountInit(t, &mountRequest{Destination: filepath.Join(dir, "link", "b")}, tree)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer syscall.Unmount(dest, syscall.MNT_DETACH)
+	if resolved != dest {
+		t.Fatalf("expected the destination to resolve to %s, got %s", dest, resolved)
+	}
+	if _, err := os.Stat(filepath.Join(dest, "file")); err != nil {
+		t.Fatalf("expected the source to be mounted at %s: %v", dest, err)
+	}
+
+	resolved, err = runMountInit(t, &mountRequest{Destination: dest, Remove: true}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resolved != dest {
+		t.Fatalf("expected the unmounted destination to be %s, got %s", dest, resolved)
+	}
+	if _, err := os.Stat(filepath.Join(dest, "file")); !os.IsNotExist(err) {
+		t.Fatalf("expected the source to be unmounted from %s, got %v", dest, err)
+	}
+
+	if _, err := runMountInit(t, &mountRequest{Destination: "/proc", Remove: true}, nil); err == nil {
+		t.Fatal("expected unmounting /proc to be refused")
+	}
+}
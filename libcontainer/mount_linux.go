@@ -0,0 +1,178 @@
+// +build linux
+
+package libcontainer
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+
+	"github.com/opencontainers/runc/libcontainer/configs"
+	"github.com/opencontainers/runc/libcontainer/system"
+	"github.com/opencontainers/runc/libcontainer/utils"
+)
+
+// mountAPIError explains the failure of a syscall of the new mount API when
+// the kernel is too old to provide it.
+func mountAPIError(what string, err error) error {
+	if err == syscall.ENOSYS {
+		return fmt.Errorf("%s require open_tree(2), move_mount(2) and mount_setattr(2), available since Linux 5.12", what)
+	}
+	return err
+}
+
+// openIDMappedMount returns a detached clone of the source of m, idmapped to
+// the user namespace of the process pid. It runs in runc, as only the initial
+// user namespace owns the mount of the source.
+func openIDMappedMount(m *configs.Mount, pid int) (*os.File, error) {
+	flags := system.OPEN_TREE_CLONE | system.OPEN_TREE_CLOEXEC
+	setattrFlags := system.AT_EMPTY_PATH
+	if m.Flags&syscall.MS_REC != 0 {
+		flags |= system.AT_RECURSIVE
+	}
+	if m.IDMapping.Recursive {
+		setattrFlags |= system.AT_RECURSIVE
+	}
+	fd, err := system.OpenTree(system.AT_FDCWD, m.Source, flags)
+	if err != nil {
+		return nil, mountAPIError("idmapped mounts", err)
+	}
+	tree := os.NewFile(uintptr(fd), m.Source)
+	userns, err := os.Open(fmt.Sprintf("/proc/%d/ns/user", pid))
+	if err != nil {
+		tree.Close()
+		return nil, err
+	}
+	defer userns.Close()
+	attr := &system.MountAttr{
+		AttrSet:  system.MOUNT_ATTR_IDMAP,
+		UsernsFd: uint64(userns.Fd()),
+	}
+	if err := system.MountSetattr(int(tree.Fd()), "", setattrFlags, attr); err != nil {
+		tree.Close()
+		if err == syscall.EINVAL {
+			return nil, fmt.Errorf("the filesystem of %q does not support idmapped mounts", m.Source)
+		}
+		return nil, mountAPIError("idmapped mounts", err)
+	}
+	return tree, nil
+}
+
+// moveMount attaches the detached mount tree to the destination of m.
+func moveMount(m *configs.Mount, rootfs string, tree *os.File) error {
+	dest, err := utils.OpenInRoot(rootfs, m.Destination)
+	if err != nil {
+		return err
+	}
+	defer dest.Close()
+	return system.MoveMount(int(tree.Fd()), "", int(dest.Fd()), "", system.MOVE_MOUNT_F_EMPTY_PATH|system.MOVE_MOUNT_T_EMPTY_PATH)
+}
+
+// setRecAttr applies the recursive mount attributes of m to the mount at its
+// destination and all of its submounts.
+func setRecAttr(m *configs.Mount, rootfs string) error {
+	if m.RecAttrSet == 0 && m.RecAttrClr == 0 {
+		return nil
+	}
+	dest, err := utils.OpenInRoot(rootfs, m.Destination)
+	if err != nil {
+		return err
+	}
+	defer dest.Close()
+	attr := &system.MountAttr{
+		AttrSet: m.RecAttrSet,
+		AttrClr: m.RecAttrClr,
+	}
+	if err := system.MountSetattr(int(dest.Fd()), "", system.AT_EMPTY_PATH|system.AT_RECURSIVE, attr); err != nil {
+		return mountAPIError("recursive mount attributes", err)
+	}
+	return nil
+}
+
+// idMappedMounts returns the number of idmapped mounts of config.
+func idMappedMounts(config *configs.Config) int {
+	n := 0
+	for _, m := range config.Mounts {
+		if m.IDMapping != nil {
+			n++
+		}
+	}
+	return n
+}
+
+// mountRequest asks a mount init process to attach the mount tree passed
+// along with it at Destination, or to unmount Destination if Remove is set.
+// With Device, the node of the device is created at Destination instead of
+// attaching a tree, or removed along with what is mounted on it.
+type mountRequest struct {
+	Destination string          `json:"destination"`
+	Remove      bool            `json:"remove,omitempty"`
+	Device      *configs.Device `json:"device,omitempty"`
+}
+
+// mountResponse is the reply of a mount init process to a mountRequest.
+type mountResponse struct {
+	Destination string `json:"destination"`
+}
+
+// mountFlagAttrs maps the mount flags to the mount attributes of
+// mount_setattr(2).
+var mountFlagAttrs = map[int]uint64{
+	syscall.MS_RDONLY: configs.MountAttrRdonly,
+	syscall.MS_NOSUID: configs.MountAttrNosuid,
+	syscall.MS_NODEV:  configs.MountAttrNodev,
+	syscall.MS_NOEXEC: configs.MountAttrNoexec,
+}
+
+// openMountTree returns a detached clone of the source of m with its flags,
+// recursive attributes and propagation applied, ready to be attached in the
+// mount namespace of the running container whose init is pid.
+func openMountTree(m *configs.Mount, pid int) (*os.File, error) {
+	var tree *os.File
+	if m.IDMapping != nil {
+		t, err := openIDMappedMount(m, pid)
+		if err != nil {
+			return nil, err
+		}
+		tree = t
+	} else {
+		flags := system.OPEN_TREE_CLONE | system.OPEN_TREE_CLOEXEC
+		if m.Flags&syscall.MS_REC != 0 {
+			flags |= system.AT_RECURSIVE
+		}
+		fd, err := system.OpenTree(system.AT_FDCWD, m.Source, flags)
+		if err != nil {
+			return nil, mountAPIError("mounts added to a running container", err)
+		}
+		tree = os.NewFile(uintptr(fd), m.Source)
+	}
+	attr := &system.MountAttr{}
+	for flag, a := range mountFlagAttrs {
+		if m.Flags&flag != 0 {
+			attr.AttrSet |= a
+		}
+	}
+	for _, p := range m.PropagationFlags {
+		attr.Propagation = uint64(p &^ syscall.MS_REC)
+	}
+	recAttr := &system.MountAttr{
+		AttrSet: m.RecAttrSet,
+		AttrClr: m.RecAttrClr,
+	}
+	for _, a := range []struct {
+		attr  *system.MountAttr
+		flags int
+	}{
+		{attr, system.AT_EMPTY_PATH},
+		{recAttr, system.AT_EMPTY_PATH | system.AT_RECURSIVE},
+	} {
+		if *a.attr == (system.MountAttr{}) {
+			continue
+		}
+		if err := system.MountSetattr(int(tree.Fd()), "", a.flags, a.attr); err != nil {
+			tree.Close()
+			return nil, mountAPIError("mounts added to a running container", err)
+		}
+	}
+	return tree, nil
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net"
+	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -27,6 +28,7 @@ type networkStrategy interface {
 	initialize(*network) error
 	detach(*configs.Network) error
 	attach(*configs.Network) error
+	destroy(*configs.Network) error
 }
 
 // getStrategy returns the specific network strategy for the
@@ -104,6 +106,10 @@ func (l *loopback) detach(n *configs.Network) (err error) {
 	return nil
 }
 
+func (l *loopback) destroy(n *configs.Network) (err error) {
+	return nil
+}
+
 // veth is a network strategy that uses a bridge and creates
 // a veth pair, one that is attached to the bridge on the host and the other
 // is placed inside the container's namespace
@@ -114,6 +120,31 @@ func (v *veth) detach(n *configs.Network) (err error) {
 	return netlink.LinkSetMaster(&netlink.Device{LinkAttrs: netlink.LinkAttrs{Name: n.HostInterfaceName}}, nil)
 }
 
+// destroy removes the host side of the veth pair, which the kernel only
+// removes along with the container's network namespace. An interface of the
+// same name that is not a veth attached to the bridge of the network is not
+// the one of the container, and is left alone.
+func (v *veth) destroy(n *configs.Network) (err error) {
+	if n.HostInterfaceName == "" {
+		return nil
+	}
+	if _, err := os.Stat(filepath.Join("/sys/class/net", n.HostInterfaceName)); os.IsNotExist(err) {
+		return nil
+	}
+	link, err := netlink.LinkByName(n.HostInterfaceName)
+	if err != nil {
+		return err
+	}
+	if link.Type() != "veth" {
+		return nil
+	}
+	br, err := netlink.LinkByName(n.Bridge)
+	if err != nil || link.Attrs().MasterIndex != br.Attrs().Index {
+		return nil
+	}
+	return netlink.LinkDel(link)
+}
+
 // attach a container network interface to an external network
 func (v *veth) attach(n *configs.Network) (err error) {
 	brl, err := netlink.LinkByName(n.Bridge)
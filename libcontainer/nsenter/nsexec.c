@@ -39,6 +39,8 @@ struct nsenter_config {
 	int      gidmap_len;
 	uint8_t  is_setgroup;
 	int      consolefd;
+	char     *timensoffset;
+	int      timensoffset_len;
 };
 
 // list of known message types we want to send to bootstrap program
@@ -50,6 +52,12 @@ struct nsenter_config {
 #define UIDMAP_ATTR	    27284
 #define GIDMAP_ATTR	    27285
 #define SETGROUP_ATTR	    27286
+#define TIMENSOFFSET_ATTR   27287
+
+// CLONE_NEWTIME is only defined by recent headers
+#ifndef CLONE_NEWTIME
+	#define CLONE_NEWTIME 0x00000080
+#endif
 
 // Use raw setns syscall for versions of glibc that don't include it
 // (namely glibc-2.12)
@@ -228,6 +236,15 @@ static void update_process_gidmap(int pid, uint8_t is_setgroup, char *map, int m
 	update_process_idmap("/proc/%d/gid_map", pid, map, map_len);
 }
 
+static void update_timens_offsets(int pid, char *map, int map_len)
+{
+	if ((map == NULL) || (map_len <= 0)) {
+		return;
+	}
+
+	update_process_idmap("/proc/%d/timens_offsets", pid, map, map_len);
+}
+
 
 static void start_child(int pipenum, jmp_buf *env, int syncpipe[2],
 		 struct nsenter_config *config)
@@ -237,6 +254,18 @@ static void start_child(int pipenum, jmp_buf *env, int syncpipe[2],
 	char    buf[PATH_MAX];
 	uint8_t syncbyte = 1;
 
+	// CLONE_NEWTIME overlaps the exit signal of clone, so unshare the time
+	// namespace instead: only our children will be in it. Its clocks can
+	// only be offset before any process enters it.
+	if (config->cloneflags & CLONE_NEWTIME) {
+		if (unshare(CLONE_NEWTIME) == -1) {
+			pr_perror("Unable to unshare time namespace");
+			exit(1);
+		}
+		update_timens_offsets(getpid(), config->timensoffset, config->timensoffset_len);
+		config->cloneflags &= ~CLONE_NEWTIME;
+	}
+
 	// We must fork to actually enter the PID namespace, use CLONE_PARENT
 	// so the child can have the right parent, and we don't need to forward
 	// the child's exit code or resend its death signal.
@@ -340,6 +369,9 @@ static struct nsenter_config process_nl_attributes(int pipenum, char *data, int
 			config.gidmap_len = payload_len;
 		} else if (nlattr->nla_type == SETGROUP_ATTR) {
 			config.is_setgroup = readint8(data + start);
+		} else if (nlattr->nla_type == TIMENSOFFSET_ATTR) {
+			config.timensoffset     = data + start;
+			config.timensoffset_len = payload_len;
 		} else {
 			pr_perror("Unknown netlink message type %d",
 				  nlattr->nla_type);
@@ -0,0 +1,66 @@
+// +build linux
+
+package libcontainer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/opencontainers/runc/libcontainer/configs"
+)
+
+// mountRootfsOverlay mounts the overlay root filesystem of the container on
+// its rootfs. Without an upper directory, a tmpfs is mounted on the rootfs
+// first to hold the upper and work directories, and is then hidden by the
+// overlay: it lives as long as the mount namespace of the container.
+func mountRootfsOverlay(config *configs.Config) error {
+	var (
+		o     = config.RootfsOverlay
+		upper = o.UpperDir
+		work  = o.WorkDir
+	)
+	if upper == "" {
+		if err := syscall.Mount("tmpfs", config.Rootfs, "tmpfs", 0, "mode=755"); err != nil {
+			return fmt.Errorf("mounting tmpfs for the overlay upper directory: %v", err)
+		}
+		upper = filepath.Join(config.Rootfs, "upper")
+		work = filepath.Join(config.Rootfs, "work")
+		for _, dir := range []string{upper, work} {
+			if err := os.Mkdir(dir, 0755); err != nil {
+				return err
+			}
+		}
+	}
+	data := fmt.Sprintf("lowerdir=%s,upperdir=%s,workdir=%s", strings.Join(o.LowerDirs, ":"), upper, work)
+	userns := config.Namespaces.Contains(configs.NEWUSER)
+	if userns {
+		// Without CAP_SYS_ADMIN in the initial user namespace, overlayfs
+		// can only store its metadata in user.* extended attributes.
+		data += ",userxattr"
+	}
+	if err := syscall.Mount("overlay", config.Rootfs, "overlay", 0, data); err != nil {
+		if userns && (err == syscall.EPERM || err == syscall.EINVAL) {
+			return fmt.Errorf("mounting an overlay in a user namespace requires Linux 5.11 or later: %v", err)
+		}
+		return fmt.Errorf("mounting overlay rootfs: %v", err)
+	}
+	return nil
+}
+
+// cleanupRootfsOverlay removes the directories overlayfs creates in the work
+// directory of the container, which are only of use while it is mounted.
+func cleanupRootfsOverlay(config *configs.Config) error {
+	o := config.RootfsOverlay
+	if o == nil || o.WorkDir == "" {
+		return nil
+	}
+	for _, dir := range []string{"work", "index"} {
+		if err := os.RemoveAll(filepath.Join(o.WorkDir, dir)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -64,9 +64,41 @@ type Process struct {
 	// If Rlimits are not set, the container will inherit rlimits from the parent process
 	Rlimits []configs.Rlimit
 
+	// Scheduler specifies the scheduling policy and attributes of the process
+	// If Scheduler is not set, the process will inherit them from its parent
+	Scheduler *configs.Scheduler
+
+	// IOPriority specifies the I/O scheduling class and level of the process
+	// If IOPriority is not set, the process will inherit it from its parent
+	IOPriority *configs.IOPriority
+
+	// CPUAffinity is the list of CPUs, such as "0-3,7", the process is allowed
+	// to run on. If CPUAffinity is empty, the process will inherit it from its parent
+	CPUAffinity string
+
+	// InitialCPUAffinity is the list of CPUs the process is allowed to run on
+	// while it joins the namespaces and cgroups of the container, before
+	// CPUAffinity is applied. It is only used for processes run in an existing
+	// container. If InitialCPUAffinity is empty, the process will inherit it from its parent
+	InitialCPUAffinity string
+
+	// SubCgroup specifies a cgroup nested in the cgroups of the container to
+	// run the process in, with its own limits. It is removed when the process
+	// exits. If SubCgroup is not set, the process will run in the cgroups of the container
+	SubCgroup *SubCgroup
+
 	ops processOperations
 }
 
+// SubCgroup is a cgroup nested in the cgroups of a container.
+type SubCgroup struct {
+	// Name is the name of the cgroup in the cgroups of the container.
+	Name string
+
+	// Resources are the memory, cpu and pids limits of the cgroup.
+	Resources *configs.Resources
+}
+
 // Wait waits for the process to exit.
 // Wait releases any resources associated with the Process
 func (p Process) Wait() (*os.ProcessState, error) {
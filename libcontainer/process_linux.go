@@ -13,6 +13,7 @@ import (
 	"strconv"
 	"syscall"
 
+	"github.com/Sirupsen/logrus"
 	"github.com/opencontainers/runc/libcontainer/cgroups"
 	"github.com/opencontainers/runc/libcontainer/configs"
 	"github.com/opencontainers/runc/libcontainer/system"
@@ -43,14 +44,16 @@ type parentProcess interface {
 }
 
 type setnsProcess struct {
-	cmd           *exec.Cmd
-	parentPipe    *os.File
-	childPipe     *os.File
-	cgroupPaths   map[string]string
-	config        *initConfig
-	fds           []string
-	process       *Process
-	bootstrapData io.Reader
+	cmd            *exec.Cmd
+	parentPipe     *os.File
+	childPipe      *os.File
+	cgroupPaths    map[string]string
+	cgroupManager  cgroups.Manager
+	subCgroupPaths map[string]string
+	config         *initConfig
+	fds            []string
+	process        *Process
+	bootstrapData  io.Reader
 }
 
 func (p *setnsProcess) startTime() (string, error) {
@@ -72,6 +75,13 @@ func (p *setnsProcess) start() (err error) {
 	if err != nil {
 		return newSystemErrorWithCause(err, "starting setns process")
 	}
+	// The initial affinity applies while the process joins the namespaces of
+	// the container, which it does on reading the bootstrap data.
+	if cpus := p.process.InitialCPUAffinity; cpus != "" {
+		if err := setCPUAffinity(p.cmd.Process.Pid, cpus); err != nil {
+			return err
+		}
+	}
 	if p.bootstrapData != nil {
 		if _, err := io.Copy(p.parentPipe, p.bootstrapData); err != nil {
 			return newSystemErrorWithCause(err, "copying bootstrap data to pipe")
@@ -85,6 +95,13 @@ func (p *setnsProcess) start() (err error) {
 			return newSystemErrorWithCausef(err, "adding pid %d to cgroups", p.pid())
 		}
 	}
+	if sub := p.process.SubCgroup; sub != nil {
+		paths, err := p.cgroupManager.ApplySubCgroup(sub.Name, p.pid(), sub.Resources)
+		if err != nil {
+			return newSystemErrorWithCausef(err, "adding pid %d to sub-cgroup %s", p.pid(), sub.Name)
+		}
+		p.subCgroupPaths = paths
+	}
 	// set oom_score_adj
 	if err := setOomScoreAdj(p.config.Config.OomScoreAdj, p.pid()); err != nil {
 		return newSystemErrorWithCause(err, "setting oom score")
@@ -103,9 +120,38 @@ func (p *setnsProcess) start() (err error) {
 	}
 	// wait for the child process to fully complete and receive an error message
 	// if one was encoutered
-	var ierr *genericError
-	if err := json.NewDecoder(p.parentPipe).Decode(&ierr); err != nil && err != io.EOF {
-		return newSystemErrorWithCause(err, "decoding init error from pipe")
+	var (
+		procSync syncT
+		ierr     *genericError
+	)
+	pipe := utils.NewFdReader(p.parentPipe)
+	defer pipe.Close()
+	dec := json.NewDecoder(pipe)
+loop:
+	for {
+		if err := dec.Decode(&procSync); err != nil {
+			if err == io.EOF {
+				break loop
+			}
+			return newSystemErrorWithCause(err, "decoding sync type from init pipe")
+		}
+		switch procSync.Type {
+		case procSeccomp:
+			fd, err := pipe.TakeFd()
+			if err != nil {
+				return newSystemErrorWithCause(err, "receiving seccomp listener from init")
+			}
+			if err := sendSeccompListener(p.config, p.pid(), fd); err != nil {
+				return newSystemErrorWithCause(err, "sending seccomp listener to agent")
+			}
+		case procError:
+			if err := dec.Decode(&ierr); err != nil && err != io.EOF {
+				return newSystemErrorWithCause(err, "decoding init error from pipe")
+			}
+			break loop
+		default:
+			return newSystemError(fmt.Errorf("invalid JSON payload from child"))
+		}
 	}
 	// Must be done after Shutdown so the child will exit and we can wait for it.
 	if ierr != nil {
@@ -159,10 +205,35 @@ func (p *setnsProcess) terminate() error {
 func (p *setnsProcess) wait() (*os.ProcessState, error) {
 	err := p.cmd.Wait()
 
+	if p.subCgroupPaths != nil {
+		if rerr := removeSubCgroup(p.subCgroupPaths, p.cgroupPaths); rerr != nil {
+			logrus.Warn(rerr)
+		}
+		p.subCgroupPaths = nil
+	}
+
 	// Return actual ProcessState even on Wait error
 	return p.cmd.ProcessState, err
 }
 
+// removeSubCgroup removes the sub-cgroup at paths of a process that exited,
+// after moving the processes it left behind to the cgroups of the container at
+// containerPaths.
+func removeSubCgroup(paths, containerPaths map[string]string) error {
+	for name, path := range paths {
+		pids, err := cgroups.GetPids(path)
+		if err != nil {
+			continue
+		}
+		for _, pid := range pids {
+			// The process may have exited since, and any process left
+			// makes the removal of the cgroup fail below anyway.
+			cgroups.EnterPid(map[string]string{name: containerPaths[name]}, pid)
+		}
+	}
+	return cgroups.RemovePaths(paths)
+}
+
 func (p *setnsProcess) pid() int {
 	return p.cmd.Process.Pid
 }
@@ -271,7 +342,9 @@ func (p *initProcess) start() error {
 		ierr       *genericError
 	)
 
-	dec := json.NewDecoder(p.parentPipe)
+	pipe := utils.NewFdReader(p.parentPipe)
+	defer pipe.Close()
+	dec := json.NewDecoder(pipe)
 loop:
 	for {
 		if err := dec.Decode(&procSync); err != nil {
@@ -303,10 +376,8 @@ loop:
 						Pid:     p.pid(),
 						Root:    p.config.Config.Rootfs,
 					}
-					for i, hook := range p.config.Config.Hooks.Prestart {
-						if err := hook.Run(s); err != nil {
-							return newSystemErrorWithCausef(err, "running prestart hook %d", i)
-						}
+					if err := p.container.runHooks("prestart", p.config.Config.Hooks.Prestart, s); err != nil {
+						return err
 					}
 				}
 			}
@@ -324,10 +395,8 @@ loop:
 					Root:       p.config.Config.Rootfs,
 					BundlePath: utils.SearchLabels(p.config.Config.Labels, "bundle"),
 				}
-				for i, hook := range p.config.Config.Hooks.Prestart {
-					if err := hook.Run(s); err != nil {
-						return newSystemErrorWithCausef(err, "running prestart hook %d", i)
-					}
+				if err := p.container.runHooks("prestart", p.config.Config.Hooks.Prestart, s); err != nil {
+					return err
 				}
 			}
 			// Sync with child.
@@ -335,6 +404,18 @@ loop:
 				return newSystemErrorWithCause(err, "reading syncT resume type")
 			}
 			sentResume = true
+		case procSeccomp:
+			fd, err := pipe.TakeFd()
+			if err != nil {
+				return newSystemErrorWithCause(err, "receiving seccomp listener from init")
+			}
+			if err := sendSeccompListener(p.config, p.pid(), fd); err != nil {
+				return newSystemErrorWithCause(err, "sending seccomp listener to agent")
+			}
+		case procMountFds:
+			if err := p.sendIDMappedMounts(); err != nil {
+				return newSystemErrorWithCause(err, "sending idmapped mounts to init")
+			}
 		case procError:
 			// wait for the child process to fully complete and receive an error message
 			// if one was encoutered
@@ -367,6 +448,30 @@ loop:
 	return nil
 }
 
+// sendIDMappedMounts creates the idmapped mounts of the container, which can
+// only be done once its user namespace exists, and passes them to init.
+func (p *initProcess) sendIDMappedMounts() error {
+	data, err := json.Marshal(syncT{procMountFds})
+	if err != nil {
+		return err
+	}
+	for _, m := range p.config.Config.Mounts {
+		if m.IDMapping == nil {
+			continue
+		}
+		tree, err := openIDMappedMount(m, p.pid())
+		if err != nil {
+			return newSystemErrorWithCausef(err, "creating idmapped mount of %q", m.Source)
+		}
+		err = utils.SendFds(p.parentPipe, data, int(tree.Fd()))
+		tree.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (p *initProcess) wait() (*os.ProcessState, error) {
 	err := p.cmd.Wait()
 	if err != nil {
@@ -485,3 +590,78 @@ func (p *Process) InitializeIO(rootuid int) (i *IO, err error) {
 	}
 	return i, nil
 }
+
+// mountProcess is an init process attaching or unmounting a mount in the mount
+// namespace of a running container.
+type mountProcess struct {
+	cmd        *exec.Cmd
+	parentPipe *os.File
+}
+
+// start sends the bootstrap data to the C code joining the namespaces, and
+// waits for it to send the pid of the process it forked.
+func (p *mountProcess) start(bootstrapData io.Reader) error {
+	if _, err := io.Copy(p.parentPipe, bootstrapData); err != nil {
+		return newSystemErrorWithCause(err, "copying bootstrap data to pipe")
+	}
+	status, err := p.cmd.Process.Wait()
+	if err != nil {
+		return newSystemErrorWithCause(err, "waiting on mount process to finish")
+	}
+	if !status.Success() {
+		return newSystemError(&exec.ExitError{ProcessState: status})
+	}
+	var pid *pid
+	if err := json.NewDecoder(p.parentPipe).Decode(&pid); err != nil {
+		return newSystemErrorWithCause(err, "reading pid from init pipe")
+	}
+	process, err := os.FindProcess(pid.Pid)
+	if err != nil {
+		return err
+	}
+	p.cmd.Process = process
+	return nil
+}
+
+// run sends req, along with the mount tree to attach if any, and returns the
+// destination the process resolved.
+func (p *mountProcess) run(req *mountRequest, tree *os.File) (string, error) {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+	var fds []int
+	if tree != nil {
+		fds = append(fds, int(tree.Fd()))
+	}
+	if err := utils.SendFds(p.parentPipe, data, fds...); err != nil {
+		return "", newSystemErrorWithCause(err, "sending mount request to init")
+	}
+	var (
+		procSync syncT
+		resp     mountResponse
+		ierr     *genericError
+	)
+	dec := json.NewDecoder(p.parentPipe)
+	if err := dec.Decode(&procSync); err != nil {
+		return "", newSystemErrorWithCause(err, "decoding sync type from init pipe")
+	}
+	switch procSync.Type {
+	case procReady:
+		if err := dec.Decode(&resp); err != nil {
+			return "", newSystemErrorWithCause(err, "decoding mount response from init pipe")
+		}
+		return resp.Destination, nil
+	case procError:
+		if err := dec.Decode(&ierr); err != nil {
+			return "", newSystemErrorWithCause(err, "decoding init error from pipe")
+		}
+		return "", newSystemErrorWithCause(ierr, "mounting in container")
+	}
+	return "", newSystemError(fmt.Errorf("invalid JSON payload from child"))
+}
+
+func (p *mountProcess) wait() (*os.ProcessState, error) {
+	err := p.cmd.Wait()
+	return p.cmd.ProcessState, err
+}
@@ -0,0 +1,52 @@
+// +build linux
+
+package libcontainer
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/opencontainers/runc/libcontainer/cgroups"
+)
+
+func TestRemoveSubCgroup(t *testing.T) {
+	root, err := cgroups.FindCgroupMountpoint("pids")
+	if err != nil || os.Getuid() != 0 {
+		t.Skip("requires root and the pids cgroup")
+	}
+	container := filepath.Join(root, fmt.Sprintf("runc-test-%d", os.Getpid()))
+	sub := filepath.Join(container, "exec")
+	if err := os.MkdirAll(sub, 0755); err != nil {
+		t.Skipf("unable to create cgroups: %v", err)
+	}
+	defer cgroups.RemovePaths(map[string]string{"pids": container})
+
+	// A process the exited process left behind in the sub-cgroup.
+	cmd := exec.Command("sleep", "10")
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer cmd.Wait()
+	defer cmd.Process.Kill()
+	containerPaths := map[string]string{"pids": container}
+	if err := cgroups.EnterPid(map[string]string{"pids": sub}, cmd.Process.Pid); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := removeSubCgroup(map[string]string{"pids": sub}, containerPaths); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(sub); !os.IsNotExist(err) {
+		t.Fatalf("expected the sub-cgroup to be removed, got %v", err)
+	}
+	pids, err := cgroups.GetPids(container)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pids) != 1 || pids[0] != cmd.Process.Pid {
+		t.Fatalf("expected the process to be moved to the cgroup of the container, got %v", pids)
+	}
+}
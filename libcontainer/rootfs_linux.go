@@ -4,7 +4,6 @@ package libcontainer
 
 import (
 	"fmt"
-	"io"
 	"io/ioutil"
 	"os"
 	"os/exec"
@@ -15,7 +14,6 @@ import (
 	"time"
 
 	"github.com/docker/docker/pkg/mount"
-	"github.com/docker/docker/pkg/symlink"
 	"github.com/opencontainers/runc/libcontainer/cgroups"
 	"github.com/opencontainers/runc/libcontainer/configs"
 	"github.com/opencontainers/runc/libcontainer/label"
@@ -25,6 +23,9 @@ import (
 
 const defaultMountFlags = syscall.MS_NOEXEC | syscall.MS_NOSUID | syscall.MS_NODEV
 
+// atSymlinkNofollow is AT_SYMLINK_NOFOLLOW, missing from the syscall package.
+const atSymlinkNofollow = 0x100
+
 // needsSetupDev returns true if /dev needs to be set up.
 func needsSetupDev(config *configs.Config) bool {
 	for _, m := range config.Mounts {
@@ -37,10 +38,15 @@ func needsSetupDev(config *configs.Config) bool {
 
 // setupRootfs sets up the devices, mount points, and filesystems for use inside a
 // new mount namespace.
-func setupRootfs(config *configs.Config, console *linuxConsole, pipe io.ReadWriter) (err error) {
+func setupRootfs(config *configs.Config, console *linuxConsole, pipe *os.File) (err error) {
 	if err := prepareRoot(config); err != nil {
 		return newSystemErrorWithCause(err, "preparing rootfs")
 	}
+	idmapped, err := syncParentMountFds(pipe, idMappedMounts(config))
+	if err != nil {
+		return newSystemErrorWithCause(err, "receiving idmapped mounts")
+	}
+	defer closeFiles(idmapped)
 
 	setupDev := needsSetupDev(config)
 	for _, m := range config.Mounts {
@@ -49,9 +55,16 @@ func setupRootfs(config *configs.Config, console *linuxConsole, pipe io.ReadWrit
 				return newSystemErrorWithCause(err, "running premount command")
 			}
 		}
-		if err := mountToRootfs(m, config.Rootfs, config.MountLabel); err != nil {
+		var tree *os.File
+		if m.IDMapping != nil {
+			tree, idmapped = idmapped[0], idmapped[1:]
+		}
+		if err := mountToRootfs(m, config.Rootfs, config.MountLabel, tree); err != nil {
 			return newSystemErrorWithCausef(err, "mounting %q to rootfs %q", m.Destination, config.Rootfs)
 		}
+		if err := setRecAttr(m, config.Rootfs); err != nil {
+			return newSystemErrorWithCausef(err, "setting recursive mount attributes of %q", m.Destination)
+		}
 
 		for _, postcmd := range m.PostmountCmds {
 			if err := mountCmd(postcmd); err != nil {
@@ -127,23 +140,18 @@ func mountCmd(cmd configs.Command) error {
 	return nil
 }
 
-func mountToRootfs(m *configs.Mount, rootfs, mountLabel string) error {
-	var (
-		dest = m.Destination
-	)
-	if !strings.HasPrefix(dest, rootfs) {
-		dest = filepath.Join(rootfs, dest)
-	}
-
+// mountToRootfs mounts m in rootfs. The source of an idmapped bind mount is
+// the detached mount tree, which is moved to the destination instead.
+func mountToRootfs(m *configs.Mount, rootfs, mountLabel string, tree *os.File) error {
 	switch m.Device {
 	case "proc", "sysfs":
-		if err := os.MkdirAll(dest, 0755); err != nil {
+		if err := mkdirInRoot(rootfs, m.Destination); err != nil {
 			return err
 		}
 		// Selinux kernels do not support labeling of /proc or /sys
 		return mountPropagate(m, rootfs, "")
 	case "mqueue":
-		if err := os.MkdirAll(dest, 0755); err != nil {
+		if err := mkdirInRoot(rootfs, m.Destination); err != nil {
 			return err
 		}
 		if err := mountPropagate(m, rootfs, mountLabel); err != nil {
@@ -151,23 +159,29 @@ func mountToRootfs(m *configs.Mount, rootfs, mountLabel string) error {
 			if err := mountPropagate(m, rootfs, ""); err != nil {
 				return err
 			}
-			return label.SetFileLabel(dest, mountLabel)
+			return libcontainerUtils.WithProcfd(rootfs, m.Destination, func(procfd string) error {
+				return label.SetFileLabel(procfd, mountLabel)
+			})
 		}
 		return nil
 	case "tmpfs":
-		stat, err := os.Stat(dest)
-		if err != nil {
-			if err := os.MkdirAll(dest, 0755); err != nil {
+		var stat os.FileInfo
+		if dir, err := libcontainerUtils.OpenInRoot(rootfs, m.Destination); err == nil {
+			stat, err = dir.Stat()
+			dir.Close()
+			if err != nil {
 				return err
 			}
+		} else if err := mkdirInRoot(rootfs, m.Destination); err != nil {
+			return err
 		}
 		if err := mountPropagate(m, rootfs, mountLabel); err != nil {
 			return err
 		}
 		if stat != nil {
-			if err = os.Chmod(dest, stat.Mode()); err != nil {
-				return err
-			}
+			return libcontainerUtils.WithProcfd(rootfs, m.Destination, func(procfd string) error {
+				return os.Chmod(procfd, stat.Mode())
+			})
 		}
 		return nil
 	case "bind":
@@ -181,18 +195,36 @@ func mountToRootfs(m *configs.Mount, rootfs, mountLabel string) error {
 		// any previous mounts can invalidate the next mount's destination.
 		// this can happen when a user specifies mounts within other mounts to cause breakouts or other
 		// evil stuff to try to escape the container's rootfs.
-		if dest, err = symlink.FollowSymlinkInScope(filepath.Join(rootfs, m.Destination), rootfs); err != nil {
+		var dest *os.File
+		if stat.IsDir() {
+			dest, err = libcontainerUtils.MkdirAllInRoot(rootfs, m.Destination, 0755)
+		} else {
+			dest, err = libcontainerUtils.CreateInRoot(rootfs, m.Destination, 0755)
+		}
+		if err != nil {
+			return err
+		}
+		resolved, err := os.Readlink(libcontainerUtils.ProcfdPath(dest))
+		dest.Close()
+		if err != nil {
 			return err
 		}
-		if err := checkMountDestination(rootfs, dest); err != nil {
+		if err := checkMountDestination(rootfs, resolved); err != nil {
 			return err
 		}
 		// update the mount with the correct dest after symlinks are resolved.
-		m.Destination = dest
-		if err := createIfNotExists(dest, stat.IsDir()); err != nil {
+		if m.Destination, err = filepath.Rel(rootfs, resolved); err != nil {
 			return err
 		}
-		if err := mountPropagate(m, rootfs, mountLabel); err != nil {
+		m.Destination = "/" + m.Destination
+		if tree != nil {
+			if err := moveMount(m, rootfs, tree); err != nil {
+				return err
+			}
+			if err := setPropagation(m, rootfs); err != nil {
+				return err
+			}
+		} else if err := mountPropagate(m, rootfs, mountLabel); err != nil {
 			return err
 		}
 		// bind mount won't change mount options, we need remount to make mount options effective.
@@ -233,37 +265,28 @@ func mountToRootfs(m *configs.Mount, rootfs, mountLabel string) error {
 			Data:             "mode=755",
 			PropagationFlags: m.PropagationFlags,
 		}
-		if err := mountToRootfs(tmpfs, rootfs, mountLabel); err != nil {
+		if err := mountToRootfs(tmpfs, rootfs, mountLabel, nil); err != nil {
 			return err
 		}
 		for _, b := range binds {
-			if err := mountToRootfs(b, rootfs, mountLabel); err != nil {
+			if err := mountToRootfs(b, rootfs, mountLabel, nil); err != nil {
 				return err
 			}
 		}
 		// create symlinks for merged cgroups
-		cwd, err := os.Getwd()
+		dir, err := libcontainerUtils.OpenInRoot(rootfs, m.Destination)
 		if err != nil {
 			return err
 		}
-		if err := os.Chdir(filepath.Join(rootfs, m.Destination)); err != nil {
-			return err
-		}
+		defer dir.Close()
 		for _, mc := range merged {
 			for _, ss := range strings.Split(mc, ",") {
-				if err := os.Symlink(mc, ss); err != nil {
-					// if cgroup already exists, then okay(it could have been created before)
-					if os.IsExist(err) {
-						continue
-					}
-					os.Chdir(cwd)
+				// if cgroup already exists, then okay(it could have been created before)
+				if err := system.Symlinkat(mc, int(dir.Fd()), ss); err != nil && err != syscall.EEXIST {
 					return err
 				}
 			}
 		}
-		if err := os.Chdir(cwd); err != nil {
-			return err
-		}
 		if m.Flags&syscall.MS_RDONLY != 0 {
 			// remount cgroup root as readonly
 			mcgrouproot := &configs.Mount{
@@ -277,7 +300,7 @@ func mountToRootfs(m *configs.Mount, rootfs, mountLabel string) error {
 			}
 		}
 	default:
-		if err := os.MkdirAll(dest, 0755); err != nil {
+		if err := mkdirInRoot(rootfs, m.Destination); err != nil {
 			return err
 		}
 		return mountPropagate(m, rootfs, mountLabel)
@@ -285,6 +308,16 @@ func mountToRootfs(m *configs.Mount, rootfs, mountLabel string) error {
 	return nil
 }
 
+// mkdirInRoot creates the directory dest, along with any missing parent,
+// inside rootfs.
+func mkdirInRoot(rootfs, dest string) error {
+	dir, err := libcontainerUtils.MkdirAllInRoot(rootfs, dest, 0755)
+	if err != nil {
+		return err
+	}
+	return dir.Close()
+}
+
 func getCgroupMounts(m *configs.Mount) ([]*configs.Mount, error) {
 	mounts, err := cgroups.GetCgroupMounts()
 	if err != nil {
@@ -361,23 +394,28 @@ func checkMountDestination(rootfs, dest string) error {
 
 func setupDevSymlinks(rootfs string) error {
 	var links = [][2]string{
-		{"/proc/self/fd", "/dev/fd"},
-		{"/proc/self/fd/0", "/dev/stdin"},
-		{"/proc/self/fd/1", "/dev/stdout"},
-		{"/proc/self/fd/2", "/dev/stderr"},
+		{"/proc/self/fd", "fd"},
+		{"/proc/self/fd/0", "stdin"},
+		{"/proc/self/fd/1", "stdout"},
+		{"/proc/self/fd/2", "stderr"},
 	}
 	// kcore support can be toggled with CONFIG_PROC_KCORE; only create a symlink
 	// in /dev if it exists in /proc.
 	if _, err := os.Stat("/proc/kcore"); err == nil {
-		links = append(links, [2]string{"/proc/kcore", "/dev/core"})
+		links = append(links, [2]string{"/proc/kcore", "core"})
 	}
+	dev, err := libcontainerUtils.OpenInRoot(rootfs, "/dev")
+	if err != nil {
+		return err
+	}
+	defer dev.Close()
 	for _, link := range links {
 		var (
 			src = link[0]
-			dst = filepath.Join(rootfs, link[1])
+			dst = link[1]
 		)
-		if err := os.Symlink(src, dst); err != nil && !os.IsExist(err) {
-			return fmt.Errorf("symlink %s %s %s", src, dst, err)
+		if err := system.Symlinkat(src, int(dev.Fd()), dst); err != nil && err != syscall.EEXIST {
+			return fmt.Errorf("symlink %s /dev/%s %s", src, dst, err)
 		}
 	}
 	return nil
@@ -427,39 +465,38 @@ func createDevices(config *configs.Config) error {
 	return nil
 }
 
-func bindMountDeviceNode(dest string, node *configs.Device) error {
-	f, err := os.Create(dest)
-	if err != nil && !os.IsExist(err) {
+func bindMountDeviceNode(rootfs string, node *configs.Device) error {
+	dest, err := libcontainerUtils.CreateInRoot(rootfs, node.Path, 0666)
+	if err != nil {
 		return err
 	}
-	if f != nil {
-		f.Close()
-	}
-	return syscall.Mount(node.Path, dest, "bind", syscall.MS_BIND, "")
+	defer dest.Close()
+	return syscall.Mount(node.Path, libcontainerUtils.ProcfdPath(dest), "bind", syscall.MS_BIND, "")
 }
 
 // Creates the device node in the rootfs of the container.
 func createDeviceNode(rootfs string, node *configs.Device, bind bool) error {
-	dest := filepath.Join(rootfs, node.Path)
-	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
-		return err
-	}
-
 	if bind {
-		return bindMountDeviceNode(dest, node)
+		return bindMountDeviceNode(rootfs, node)
 	}
-	if err := mknodDevice(dest, node); err != nil {
+	dir, err := libcontainerUtils.MkdirAllInRoot(rootfs, filepath.Dir(node.Path), 0755)
+	if err != nil {
+		return err
+	}
+	defer dir.Close()
+	if err := mknodDevice(dir, filepath.Base(node.Path), node); err != nil {
 		if os.IsExist(err) {
 			return nil
 		} else if os.IsPermission(err) {
-			return bindMountDeviceNode(dest, node)
+			return bindMountDeviceNode(rootfs, node)
 		}
 		return err
 	}
 	return nil
 }
 
-func mknodDevice(dest string, node *configs.Device) error {
+// mknodDevice creates the device node name in the directory dir.
+func mknodDevice(dir *os.File, name string, node *configs.Device) error {
 	fileMode := node.FileMode
 	switch node.Type {
 	case 'c':
@@ -469,10 +506,10 @@ func mknodDevice(dest string, node *configs.Device) error {
 	default:
 		return fmt.Errorf("%c is not a valid device type for device %s", node.Type, node.Path)
 	}
-	if err := syscall.Mknod(dest, uint32(fileMode), node.Mkdev()); err != nil {
+	if err := syscall.Mknodat(int(dir.Fd()), name, uint32(fileMode), node.Mkdev()); err != nil {
 		return err
 	}
-	return syscall.Chown(dest, int(node.Uid), int(node.Gid))
+	return syscall.Fchownat(int(dir.Fd()), name, int(node.Uid), int(node.Gid), atSymlinkNofollow)
 }
 
 func getMountInfo(mountinfo []*mount.Info, dir string) *mount.Info {
@@ -559,6 +596,9 @@ func prepareRoot(config *configs.Config) error {
 		}
 	}
 
+	if config.RootfsOverlay != nil {
+		return mountRootfsOverlay(config)
+	}
 	return syscall.Mount(config.Rootfs, config.Rootfs, "bind", syscall.MS_BIND|syscall.MS_REC, "")
 }
 
@@ -567,11 +607,15 @@ func setReadonly() error {
 }
 
 func setupPtmx(config *configs.Config, console *linuxConsole) error {
-	ptmx := filepath.Join(config.Rootfs, "dev/ptmx")
-	if err := os.Remove(ptmx); err != nil && !os.IsNotExist(err) {
+	dev, err := libcontainerUtils.OpenInRoot(config.Rootfs, "/dev")
+	if err != nil {
+		return err
+	}
+	defer dev.Close()
+	if err := syscall.Unlinkat(int(dev.Fd()), "ptmx"); err != nil && err != syscall.ENOENT {
 		return err
 	}
-	if err := os.Symlink("pts/ptmx", ptmx); err != nil {
+	if err := system.Symlinkat("pts/ptmx", int(dev.Fd()), "ptmx"); err != nil {
 		return fmt.Errorf("symlink dev ptmx %s", err)
 	}
 	if console != nil {
@@ -636,26 +680,6 @@ func msMoveRoot(rootfs string) error {
 	return syscall.Chdir("/")
 }
 
-// createIfNotExists creates a file or a directory only if it does not already exist.
-func createIfNotExists(path string, isDir bool) error {
-	if _, err := os.Stat(path); err != nil {
-		if os.IsNotExist(err) {
-			if isDir {
-				return os.MkdirAll(path, 0755)
-			}
-			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
-				return err
-			}
-			f, err := os.OpenFile(path, os.O_CREATE, 0755)
-			if err != nil {
-				return err
-			}
-			f.Close()
-		}
-	}
-	return nil
-}
-
 // remountReadonly will bind over the top of an existing path and ensure that it is read-only.
 func remountReadonly(path string) error {
 	for i := 0; i < 5; i++ {
@@ -696,39 +720,38 @@ func writeSystemProperty(key, value string) error {
 }
 
 func remount(m *configs.Mount, rootfs string) error {
-	var (
-		dest = m.Destination
-	)
-	if !strings.HasPrefix(dest, rootfs) {
-		dest = filepath.Join(rootfs, dest)
-	}
-	if err := syscall.Mount(m.Source, dest, m.Device, uintptr(m.Flags|syscall.MS_REMOUNT), ""); err != nil {
-		return err
-	}
-	return nil
+	return libcontainerUtils.WithProcfd(rootfs, m.Destination, func(procfd string) error {
+		return syscall.Mount(m.Source, procfd, m.Device, uintptr(m.Flags|syscall.MS_REMOUNT), "")
+	})
 }
 
 // Do the mount operation followed by additional mounts required to take care
-// of propagation flags.
+// of propagation flags. The destination is resolved again for each of them, so
+// that they apply to the mount just made rather than to what is under it.
 func mountPropagate(m *configs.Mount, rootfs string, mountLabel string) error {
 	var (
-		dest  = m.Destination
 		data  = label.FormatMountLabel(m.Data, mountLabel)
 		flags = m.Flags
 	)
-	if dest == "/dev" {
+	if libcontainerUtils.CleanPath(m.Destination) == "/dev" {
 		flags &= ^syscall.MS_RDONLY
 	}
-	if !strings.HasPrefix(dest, rootfs) {
-		dest = filepath.Join(rootfs, dest)
-	}
 
-	if err := syscall.Mount(m.Source, dest, m.Device, uintptr(flags), data); err != nil {
+	if err := libcontainerUtils.WithProcfd(rootfs, m.Destination, func(procfd string) error {
+		return syscall.Mount(m.Source, procfd, m.Device, uintptr(flags), data)
+	}); err != nil {
 		return err
 	}
+	return setPropagation(m, rootfs)
+}
 
+// setPropagation applies the propagation flags of m to the mount at its
+// destination.
+func setPropagation(m *configs.Mount, rootfs string) error {
 	for _, pflag := range m.PropagationFlags {
-		if err := syscall.Mount("", dest, "", uintptr(pflag), ""); err != nil {
+		if err := libcontainerUtils.WithProcfd(rootfs, m.Destination, func(procfd string) error {
+			return syscall.Mount("", procfd, "", uintptr(pflag), "")
+		}); err != nil {
 			return err
 		}
 	}
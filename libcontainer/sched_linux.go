@@ -0,0 +1,88 @@
+// +build linux
+
+package libcontainer
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"github.com/opencontainers/runc/libcontainer/system"
+)
+
+// setupScheduler applies the CPU affinity, scheduling attributes and I/O
+// priority of the process to the calling thread, which the process inherits
+// on exec. Raising priorities requires CAP_SYS_NICE or CAP_SYS_ADMIN, so this
+// has to be done before capabilities are dropped.
+func setupScheduler(config *initConfig) error {
+	if config.CPUAffinity != "" {
+		if err := setCPUAffinity(0, config.CPUAffinity); err != nil {
+			return err
+		}
+	}
+	if s := config.Scheduler; s != nil {
+		attr := &system.SchedAttr{
+			Policy:   uint32(s.Policy),
+			Nice:     int32(s.Nice),
+			Priority: uint32(s.Priority),
+			Runtime:  s.Runtime,
+			Deadline: s.Deadline,
+			Period:   s.Period,
+		}
+		if err := system.SchedSetattr(0, attr); err != nil {
+			return newSystemErrorWithCause(err, "setting scheduling attributes")
+		}
+	}
+	if p := config.IOPriority; p != nil {
+		if err := system.IoprioSet(system.IOPRIO_WHO_PROCESS, 0, int(p.Class), p.Level); err != nil {
+			return newSystemErrorWithCause(err, "setting io priority")
+		}
+	}
+	return nil
+}
+
+// setCPUAffinity restricts the process pid, or the calling thread if pid is 0,
+// to the CPUs of list.
+func setCPUAffinity(pid int, list string) error {
+	cpus, err := parseCPUList(list)
+	if err != nil {
+		return err
+	}
+	if err := system.SchedSetaffinity(pid, cpus); err != nil {
+		return newSystemErrorWithCausef(err, "setting cpu affinity to %s", list)
+	}
+	return nil
+}
+
+// parseCPUList parses a list of CPUs in the format of cpuset.cpus, such as
+// "0-3,7".
+func parseCPUList(list string) ([]int, error) {
+	var cpus []int
+	for _, part := range strings.Split(list, ",") {
+		bounds := strings.SplitN(strings.TrimSpace(part), "-", 2)
+		first, err := strconv.Atoi(bounds[0])
+		if err != nil || first < 0 {
+			return nil, fmt.Errorf("invalid cpu list %q", list)
+		}
+		last := first
+		if len(bounds) == 2 {
+			if last, err = strconv.Atoi(bounds[1]); err != nil || last < first {
+				return nil, fmt.Errorf("invalid cpu list %q", list)
+			}
+		}
+		for cpu := first; cpu <= last; cpu++ {
+			cpus = append(cpus, cpu)
+		}
+	}
+	return cpus, nil
+}
+
+// onlineCPUs returns the CPUs of the host that are online.
+func onlineCPUs() ([]int, error) {
+	list, err := ioutil.ReadFile("/sys/devices/system/cpu/online")
+	if err != nil {
+		return nil, err
+	}
+	return parseCPUList(strings.TrimSpace(string(list)))
+}
@@ -0,0 +1,63 @@
+// +build linux
+
+package libcontainer
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseCPUList(t *testing.T) {
+	for list, expected := range map[string][]int{
+		"0":          {0},
+		"0-3":        {0, 1, 2, 3},
+		"1,3-4, 64":  {1, 3, 4, 64},
+		"2-2,0":      {2, 0},
+		"127":        {127},
+		"0-1,5-6,10": {0, 1, 5, 6, 10},
+	} {
+		cpus, err := parseCPUList(list)
+		if err != nil {
+			t.Errorf("parsing %q: %v", list, err)
+			continue
+		}
+		if !reflect.DeepEqual(cpus, expected) {
+			t.Errorf("parsing %q: expected %v, got %v", list, expected, cpus)
+		}
+	}
+}
+
+func TestParseInvalidCPUList(t *testing.T) {
+	for _, list := range []string{"", "a", "-1", "3-1", "0-", "0,,1", "1-2-3"} {
+		if cpus, err := parseCPUList(list); err == nil {
+			t.Errorf("expected parsing %q to fail, got %v", list, cpus)
+		}
+	}
+}
+
+func TestSetCPUAffinity(t *testing.T) {
+	cmd := exec.Command("sleep", "10")
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer cmd.Wait()
+	defer cmd.Process.Kill()
+
+	if err := setCPUAffinity(cmd.Process.Pid, "0"); err != nil {
+		t.Fatal(err)
+	}
+	status, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/status", cmd.Process.Pid))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(status), "Cpus_allowed_list:\t0\n") {
+		t.Errorf("expected the process to be restricted to cpu 0, got status\n%s", status)
+	}
+	if err := setCPUAffinity(cmd.Process.Pid, "0-"); err == nil {
+		t.Error("expected an invalid cpu list to be rejected")
+	}
+}
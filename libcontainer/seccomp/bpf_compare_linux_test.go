@@ -0,0 +1,193 @@
+// +build linux,cgo,seccomp
+
+package seccomp
+
+import (
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/opencontainers/runc/libcontainer/configs"
+)
+
+// libseccompProgram returns the BPF program libseccomp generates for config.
+func libseccompProgram(t *testing.T, config *configs.Seccomp, native string) []sockFilter {
+	filter, err := newFilter(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer filter.Release()
+
+	f, err := ioutil.TempFile("", "seccomp-bpf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	if err := filter.ExportBPF(f); err != nil {
+		t.Fatal(err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.Seek(0, 0); err != nil {
+		t.Fatal(err)
+	}
+	var order binary.ByteOrder = binary.LittleEndian
+	if archInfos[native].bigEndian {
+		order = binary.BigEndian
+	}
+	prog := make([]sockFilter, info.Size()/8)
+	if err := binary.Read(f, order, prog); err != nil {
+		t.Fatal(err)
+	}
+	return prog
+}
+
+// compareVerdicts runs every combination of the given syscalls and argument
+// vectors through the programs of both backends and expects the same verdict.
+func compareVerdicts(t *testing.T, config *configs.Seccomp, arches []string, names []string, args [][maxArgs]uint64) {
+	native, err := nativeArch()
+	if err != nil {
+		t.Skip(err)
+	}
+	ours, err := compileFilter(config, native)
+	if err != nil {
+		t.Fatal(err)
+	}
+	theirs := libseccompProgram(t, config, native)
+
+	for _, arch := range append([]string{native}, arches...) {
+		for _, name := range names {
+			n, ok := syscallTables[arch][name]
+			if !ok {
+				continue
+			}
+			for _, a := range args {
+				d := seccompData{arch: arch, nr: n, args: a}
+				want, err := runFilter(theirs, d)
+				if err != nil {
+					t.Fatalf("libseccomp program: %s", err)
+				}
+				got, err := runFilter(ours, d)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got != want {
+					t.Errorf("%s %s%v: libseccomp returns %#x, compiled filter returns %#x", arch, name, a, want, got)
+				}
+			}
+		}
+	}
+}
+
+func TestCompareActions(t *testing.T) {
+	enosys := uint(syscall.ENOSYS)
+	config := &configs.Seccomp{
+		DefaultAction: configs.Allow,
+		Syscalls: []*configs.Syscall{
+			{Name: "mount", Action: configs.Errno},
+			{Name: "umount2", Action: configs.Errno, ErrnoRet: &enosys},
+			{Name: "ptrace", Action: configs.Trace},
+			{Name: "reboot", Action: configs.Kill},
+			{Name: "swapon", Action: configs.Trap},
+			{Name: "no_such_syscall", Action: configs.Kill},
+		},
+	}
+	compareVerdicts(t, config, nil,
+		[]string{"mount", "umount2", "ptrace", "reboot", "swapon", "read", "write"},
+		[][maxArgs]uint64{{}})
+}
+
+func TestCompareDefaultErrno(t *testing.T) {
+	enosys := uint(syscall.ENOSYS)
+	config := &configs.Seccomp{
+		DefaultAction:   configs.Errno,
+		DefaultErrnoRet: &enosys,
+		Syscalls: []*configs.Syscall{
+			{Name: "read", Action: configs.Allow},
+			{Name: "write", Action: configs.Allow},
+		},
+	}
+	compareVerdicts(t, config, nil,
+		[]string{"read", "write", "open", "clone"},
+		[][maxArgs]uint64{{}})
+}
+
+func TestCompareArgs(t *testing.T) {
+	config := &configs.Seccomp{
+		DefaultAction: configs.Errno,
+		Syscalls: []*configs.Syscall{
+			{Name: "read", Action: configs.Allow, Args: []*configs.Arg{{Index: 0, Value: 3, Op: configs.EqualTo}}},
+			{Name: "write", Action: configs.Allow, Args: []*configs.Arg{{Index: 0, Value: 3, Op: configs.NotEqualTo}}},
+			{Name: "close", Action: configs.Allow, Args: []*configs.Arg{{Index: 0, Value: 3, Op: configs.GreaterThan}}},
+			{Name: "dup", Action: configs.Allow, Args: []*configs.Arg{{Index: 0, Value: 3, Op: configs.GreaterThanOrEqualTo}}},
+			{Name: "dup2", Action: configs.Allow, Args: []*configs.Arg{{Index: 0, Value: 3, Op: configs.LessThan}}},
+			{Name: "dup3", Action: configs.Allow, Args: []*configs.Arg{{Index: 0, Value: 3, Op: configs.LessThanOrEqualTo}}},
+			{Name: "personality", Action: configs.Allow, Args: []*configs.Arg{{Index: 0, Value: 0xff, ValueTwo: 8, Op: configs.MaskEqualTo}}},
+			{
+				Name:   "kill",
+				Action: configs.Trap,
+				Args: []*configs.Arg{
+					{Index: 0, Value: 1, Op: configs.EqualTo},
+					{Index: 1, Value: uint64(syscall.SIGKILL), Op: configs.EqualTo},
+				},
+			},
+		},
+	}
+	var args [][maxArgs]uint64
+	for _, v := range []uint64{0, 2, 3, 4, 8, 0x108, 0x100000003} {
+		args = append(args, [maxArgs]uint64{v, uint64(syscall.SIGKILL)})
+	}
+	args = append(args, [maxArgs]uint64{1, uint64(syscall.SIGKILL)}, [maxArgs]uint64{1, uint64(syscall.SIGTERM)})
+	compareVerdicts(t, config, nil,
+		[]string{"read", "write", "close", "dup", "dup2", "dup3", "personality", "kill", "open"},
+		args)
+}
+
+func TestCompareMultipleArches(t *testing.T) {
+	native, err := nativeArch()
+	if err != nil || native != "amd64" {
+		t.Skip("test requires amd64")
+	}
+	config := &configs.Seccomp{
+		DefaultAction: configs.Allow,
+		Architectures: []string{"x86", "x32"},
+		Syscalls: []*configs.Syscall{
+			{Name: "mount", Action: configs.Errno},
+			{Name: "execve", Action: configs.Trap},
+		},
+	}
+	compareVerdicts(t, config, []string{"x86", "x32", "arm"},
+		[]string{"mount", "execve", "read"},
+		[][maxArgs]uint64{{}})
+}
+
+func TestNeedsBPF(t *testing.T) {
+	for _, test := range []struct {
+		config   *configs.Seccomp
+		expected bool
+	}{
+		{&configs.Seccomp{DefaultAction: configs.Errno, Flags: []configs.SeccompFlag{configs.FlagTsync}}, false},
+		{&configs.Seccomp{DefaultAction: configs.KillProcess}, true},
+		{&configs.Seccomp{DefaultAction: configs.Allow, Syscalls: []*configs.Syscall{{Name: "mount", Action: configs.Log}}}, true},
+		{&configs.Seccomp{DefaultAction: configs.Allow, Syscalls: []*configs.Syscall{{Name: "mount", Action: configs.Notify}}}, true},
+		{&configs.Seccomp{DefaultAction: configs.Notify}, true},
+		{&configs.Seccomp{DefaultAction: configs.Allow, Flags: []configs.SeccompFlag{configs.FlagLog}}, true},
+		{&configs.Seccomp{DefaultAction: configs.Allow, Flags: []configs.SeccompFlag{configs.FlagSpecAllow}}, true},
+	} {
+		if needsBPF(test.config) != test.expected {
+			t.Errorf("expected needsBPF of %+v to be %v", test.config, test.expected)
+		}
+		if test.expected {
+			// The BPF compiler has to accept what libseccomp rejects.
+			if _, err := compileFilter(test.config, "amd64"); err != nil {
+				t.Errorf("compiling %+v: %v", test.config, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,523 @@
+// +build linux
+
+package seccomp
+
+import (
+	"fmt"
+	"runtime"
+	"syscall"
+
+	"github.com/opencontainers/runc/libcontainer/configs"
+)
+
+// Classic BPF opcodes used by the filter compiler, from linux/filter.h.
+const (
+	bpfLD  = 0x00
+	bpfALU = 0x04
+	bpfJMP = 0x05
+	bpfRET = 0x06
+
+	bpfW   = 0x00
+	bpfABS = 0x20
+
+	bpfAND = 0x50
+
+	bpfJA  = 0x00
+	bpfJEQ = 0x10
+	bpfJGT = 0x20
+	bpfJGE = 0x30
+
+	bpfK = 0x00
+
+	// bpfMaxInsns is BPF_MAXINSNS, the largest program the kernel accepts.
+	bpfMaxInsns = 4096
+)
+
+// Seccomp filter return values, from linux/seccomp.h.
+const (
+	retKillProcess = 0x80000000
+	retKillThread  = 0x00000000
+	retTrap        = 0x00030000
+	retErrno       = 0x00050000
+	retUserNotif   = 0x7fc00000
+	retTrace       = 0x7ff00000
+	retLog         = 0x7ffc0000
+	retAllow       = 0x7fff0000
+	retData        = 0x0000ffff
+)
+
+// Offsets of the fields of struct seccomp_data.
+const (
+	offsetNr   = 0
+	offsetArch = 4
+	offsetArgs = 16
+	maxArgs    = 6
+)
+
+// x32SyscallBit is __X32_SYSCALL_BIT. x32 shares its audit architecture with
+// amd64 and is told apart by this bit in the syscall number.
+const x32SyscallBit = 0x40000000
+
+// sockFilter is struct sock_filter, a single classic BPF instruction.
+type sockFilter struct {
+	Code uint16
+	Jt   uint8
+	Jf   uint8
+	K    uint32
+}
+
+type archInfo struct {
+	// audit is the AUDIT_ARCH_* value the kernel reports in seccomp_data.
+	audit uint32
+	// args64 is set if syscall arguments are 64 bits wide.
+	args64    bool
+	bigEndian bool
+}
+
+var archInfos = map[string]archInfo{
+	"x86":      {audit: 0x40000003},
+	"amd64":    {audit: 0xc000003e, args64: true},
+	"x32":      {audit: 0xc000003e},
+	"arm":      {audit: 0x40000028},
+	"arm64":    {audit: 0xc00000b7, args64: true},
+	"mips":     {audit: 0x00000008, bigEndian: true},
+	"mipsel":   {audit: 0x40000008},
+	"mips64":   {audit: 0x80000008, args64: true, bigEndian: true},
+	"mipsel64": {audit: 0xc0000008, args64: true},
+}
+
+var goarchToArch = map[string]string{
+	"386":      "x86",
+	"amd64":    "amd64",
+	"arm":      "arm",
+	"arm64":    "arm64",
+	"mips":     "mips",
+	"mipsle":   "mipsel",
+	"mips64":   "mips64",
+	"mips64le": "mipsel64",
+}
+
+// nativeArch returns the seccomp name of the architecture runc was built for.
+func nativeArch() (string, error) {
+	arch, ok := goarchToArch[runtime.GOARCH]
+	if !ok {
+		return "", fmt.Errorf("seccomp is not supported on %s", runtime.GOARCH)
+	}
+	return arch, nil
+}
+
+// label identifies a position in a program that jumps can refer to before
+// it is known.
+type label int
+
+// next is the label of the instruction following a jump.
+const next label = 0
+
+type instruction struct {
+	sockFilter
+	jt, jf label
+}
+
+// program assembles a BPF program with forward jumps to labels.
+type program struct {
+	insns     []instruction
+	positions []int
+}
+
+func newProgram() *program {
+	return &program{positions: []int{-1}}
+}
+
+func (p *program) newLabel() label {
+	p.positions = append(p.positions, -1)
+	return label(len(p.positions) - 1)
+}
+
+func (p *program) bind(l label) {
+	p.positions[l] = len(p.insns)
+}
+
+func (p *program) emit(code uint16, k uint32, jt, jf label) {
+	p.insns = append(p.insns, instruction{
+		sockFilter: sockFilter{Code: code, K: k},
+		jt:         jt,
+		jf:         jf,
+	})
+}
+
+func (p *program) load(offset uint32) {
+	p.emit(bpfLD|bpfW|bpfABS, offset, next, next)
+}
+
+func (p *program) and(k uint32) {
+	p.emit(bpfALU|bpfAND|bpfK, k, next, next)
+}
+
+func (p *program) ret(k uint32) {
+	p.emit(bpfRET|bpfK, k, next, next)
+}
+
+func (p *program) jump(op uint16, k uint32, jt, jf label) {
+	p.emit(bpfJMP|op|bpfK, k, jt, jf)
+}
+
+// jumpTo jumps unconditionally to l, which may be any distance away.
+func (p *program) jumpTo(l label) {
+	p.emit(bpfJMP|bpfJA, 0, l, next)
+}
+
+// jumpIf jumps to l, which may be any distance away, if comparing the
+// accumulator to k with op holds.
+func (p *program) jumpIf(op uint16, k uint32, l label) {
+	skip := p.newLabel()
+	p.jump(op, k, next, skip)
+	p.jumpTo(l)
+	p.bind(skip)
+}
+
+func (p *program) offset(i int, l label) (int, error) {
+	if l == next {
+		return 0, nil
+	}
+	pos := p.positions[l]
+	if pos < 0 {
+		return 0, fmt.Errorf("jump to unbound label %d", l)
+	}
+	if pos <= i {
+		return 0, fmt.Errorf("backward jump from %d to %d", i, pos)
+	}
+	return pos - i - 1, nil
+}
+
+// assemble resolves the jump offsets of the program.
+func (p *program) assemble() ([]sockFilter, error) {
+	if len(p.insns) > bpfMaxInsns {
+		return nil, fmt.Errorf("filter is too large: %d instructions, the kernel accepts %d", len(p.insns), bpfMaxInsns)
+	}
+	out := make([]sockFilter, len(p.insns))
+	for i, insn := range p.insns {
+		out[i] = insn.sockFilter
+		if insn.Code&0x07 != bpfJMP {
+			continue
+		}
+		jt, err := p.offset(i, insn.jt)
+		if err != nil {
+			return nil, err
+		}
+		if insn.Code == bpfJMP|bpfJA {
+			out[i].K = uint32(jt)
+			continue
+		}
+		jf, err := p.offset(i, insn.jf)
+		if err != nil {
+			return nil, err
+		}
+		if jt > 0xff || jf > 0xff {
+			return nil, fmt.Errorf("conditional jump at %d is out of range", i)
+		}
+		out[i].Jt, out[i].Jf = uint8(jt), uint8(jf)
+	}
+	return out, nil
+}
+
+// actionToRet converts a Libcontainer Action to a seccomp filter return value.
+func actionToRet(act configs.Action, errnoRet *uint) (uint32, error) {
+	data := uint32(syscall.EPERM)
+	if errnoRet != nil {
+		data = uint32(*errnoRet) & retData
+	}
+	switch act {
+	case configs.Kill:
+		return retKillThread, nil
+	case configs.KillProcess:
+		return retKillProcess, nil
+	case configs.Trap:
+		return retTrap, nil
+	case configs.Errno:
+		return retErrno | data, nil
+	case configs.Trace:
+		return retTrace | data, nil
+	case configs.Allow:
+		return retAllow, nil
+	case configs.Log:
+		return retLog, nil
+	case configs.Notify:
+		return retUserNotif, nil
+	default:
+		return 0, fmt.Errorf("invalid action, cannot use in rule")
+	}
+}
+
+// rule is a syscall rule resolved for a single architecture.
+type rule struct {
+	ret  uint32
+	args []*configs.Arg
+}
+
+// syscallRules holds the rules of a syscall in the order they were given.
+type syscallRules struct {
+	nr    uint32
+	rules []rule
+}
+
+// unconditional returns the action of the first rule of the syscall that does
+// not check any arguments.
+func (g *syscallRules) unconditional() (uint32, bool) {
+	for _, r := range g.rules {
+		if len(r.args) == 0 {
+			return r.ret, true
+		}
+	}
+	return 0, false
+}
+
+// resolveRules looks up the syscalls for arch, skipping the ones arch does not
+// have, and groups their rules by syscall number.
+func resolveRules(config *configs.Seccomp, arch string) ([]*syscallRules, error) {
+	var (
+		table  = syscallTables[arch]
+		groups []*syscallRules
+		byNr   = make(map[uint32]*syscallRules)
+	)
+	for _, call := range config.Syscalls {
+		if call == nil {
+			return nil, fmt.Errorf("encountered nil syscall while initializing Seccomp")
+		}
+		if len(call.Name) == 0 {
+			return nil, fmt.Errorf("empty string is not a valid syscall")
+		}
+		ret, err := actionToRet(call.Action, call.ErrnoRet)
+		if err != nil {
+			return nil, err
+		}
+		for _, arg := range call.Args {
+			if arg == nil {
+				return nil, fmt.Errorf("cannot convert nil to syscall condition")
+			}
+			if arg.Index >= maxArgs {
+				return nil, fmt.Errorf("syscall %s: argument index %d is out of range", call.Name, arg.Index)
+			}
+		}
+		// If we can't resolve the syscall, assume it's not supported on
+		// this architecture. Ignore it, don't error out.
+		nr, ok := table[call.Name]
+		if !ok {
+			continue
+		}
+		g, ok := byNr[nr]
+		if !ok {
+			g = &syscallRules{nr: nr}
+			byNr[nr] = g
+			groups = append(groups, g)
+		}
+		g.rules = append(g.rules, rule{ret: ret, args: call.Args})
+	}
+	return groups, nil
+}
+
+// compileArg emits a check of a single argument condition that falls through
+// when it matches and jumps to fail otherwise.
+func (p *program) compileArg(info archInfo, arg *configs.Arg, fail label) error {
+	lo := uint32(offsetArgs + 8*arg.Index)
+	hi := lo + 4
+	if info.bigEndian {
+		lo, hi = hi, lo
+	}
+	var (
+		ok         = p.newLabel()
+		vlo, vhi   = uint32(arg.Value), uint32(arg.Value >> 32)
+		v2lo, v2hi = uint32(arg.ValueTwo), uint32(arg.ValueTwo >> 32)
+	)
+	if !info.args64 {
+		// Only the low word is meaningful; compare it alone.
+		p.load(lo)
+		switch arg.Op {
+		case configs.EqualTo:
+			p.jump(bpfJEQ, vlo, ok, fail)
+		case configs.NotEqualTo:
+			p.jump(bpfJEQ, vlo, fail, ok)
+		case configs.GreaterThan:
+			p.jump(bpfJGT, vlo, ok, fail)
+		case configs.GreaterThanOrEqualTo:
+			p.jump(bpfJGE, vlo, ok, fail)
+		case configs.LessThan:
+			p.jump(bpfJGE, vlo, fail, ok)
+		case configs.LessThanOrEqualTo:
+			p.jump(bpfJGT, vlo, fail, ok)
+		case configs.MaskEqualTo:
+			p.and(vlo)
+			p.jump(bpfJEQ, v2lo, ok, fail)
+		default:
+			return fmt.Errorf("invalid operator, cannot use in rule")
+		}
+		p.bind(ok)
+		return nil
+	}
+	// 64-bit comparisons decide on the high words and only look at the low
+	// words when the high words are equal.
+	p.load(hi)
+	switch arg.Op {
+	case configs.EqualTo:
+		p.jump(bpfJEQ, vhi, next, fail)
+		p.load(lo)
+		p.jump(bpfJEQ, vlo, ok, fail)
+	case configs.NotEqualTo:
+		p.jump(bpfJEQ, vhi, next, ok)
+		p.load(lo)
+		p.jump(bpfJEQ, vlo, fail, ok)
+	case configs.GreaterThan:
+		p.jump(bpfJGT, vhi, ok, next)
+		p.jump(bpfJEQ, vhi, next, fail)
+		p.load(lo)
+		p.jump(bpfJGT, vlo, ok, fail)
+	case configs.GreaterThanOrEqualTo:
+		p.jump(bpfJGT, vhi, ok, next)
+		p.jump(bpfJEQ, vhi, next, fail)
+		p.load(lo)
+		p.jump(bpfJGE, vlo, ok, fail)
+	case configs.LessThan:
+		p.jump(bpfJGE, vhi, next, ok)
+		p.jump(bpfJEQ, vhi, next, fail)
+		p.load(lo)
+		p.jump(bpfJGE, vlo, fail, ok)
+	case configs.LessThanOrEqualTo:
+		p.jump(bpfJGE, vhi, next, ok)
+		p.jump(bpfJEQ, vhi, next, fail)
+		p.load(lo)
+		p.jump(bpfJGT, vlo, fail, ok)
+	case configs.MaskEqualTo:
+		p.and(vhi)
+		p.jump(bpfJEQ, v2hi, next, fail)
+		p.load(lo)
+		p.and(vlo)
+		p.jump(bpfJEQ, v2lo, ok, fail)
+	default:
+		return fmt.Errorf("invalid operator, cannot use in rule")
+	}
+	p.bind(ok)
+	return nil
+}
+
+// compileArch emits the rules of a single architecture. The syscalls are
+// matched in order. An unconditional rule for a syscall takes precedence over
+// its conditional rules, as it does in libseccomp; otherwise the first
+// conditional rule whose arguments all match decides.
+func (p *program) compileArch(config *configs.Seccomp, arch string, defaultRet uint32) error {
+	groups, err := resolveRules(config, arch)
+	if err != nil {
+		return err
+	}
+	// Syscalls with an unconditional rule return straight from the
+	// dispatch, the others jump to a block checking their arguments.
+	var (
+		conditional []*syscallRules
+		labels      []label
+	)
+	p.load(offsetNr)
+	for _, g := range groups {
+		if ret, ok := g.unconditional(); ok {
+			skip := p.newLabel()
+			p.jump(bpfJEQ, g.nr, next, skip)
+			p.ret(ret)
+			p.bind(skip)
+			continue
+		}
+		l := p.newLabel()
+		p.jumpIf(bpfJEQ, g.nr, l)
+		conditional = append(conditional, g)
+		labels = append(labels, l)
+	}
+	p.ret(defaultRet)
+	for i, g := range conditional {
+		p.bind(labels[i])
+		for _, r := range g.rules {
+			fail := p.newLabel()
+			for _, arg := range r.args {
+				if err := p.compileArg(archInfos[arch], arg, fail); err != nil {
+					return err
+				}
+			}
+			p.ret(r.ret)
+			p.bind(fail)
+		}
+		p.ret(defaultRet)
+	}
+	return nil
+}
+
+// compileFilter compiles a seccomp configuration into a BPF program. As with
+// libseccomp, the native architecture is always allowed, and syscalls from
+// architectures that are not part of the filter kill the calling thread.
+func compileFilter(config *configs.Seccomp, native string) ([]sockFilter, error) {
+	if config == nil {
+		return nil, fmt.Errorf("cannot initialize Seccomp - nil config passed")
+	}
+	defaultRet, err := actionToRet(config.DefaultAction, config.DefaultErrnoRet)
+	if err != nil {
+		return nil, fmt.Errorf("error initializing seccomp - invalid default action: %s", err)
+	}
+
+	arches := []string{native}
+	present := map[string]bool{native: true}
+	for _, arch := range config.Architectures {
+		if present[arch] {
+			continue
+		}
+		arches = append(arches, arch)
+		present[arch] = true
+	}
+	for _, arch := range arches {
+		if _, ok := archInfos[arch]; !ok {
+			return nil, fmt.Errorf("architecture %s is not supported by the seccomp filter compiler", arch)
+		}
+	}
+
+	// Dispatch on the audit architecture. amd64 and x32 share a section.
+	var (
+		p       = newProgram()
+		audits  []uint32
+		entries = make(map[uint32]label)
+	)
+	p.load(offsetArch)
+	for _, arch := range arches {
+		audit := archInfos[arch].audit
+		if _, ok := entries[audit]; ok {
+			continue
+		}
+		entries[audit] = p.newLabel()
+		audits = append(audits, audit)
+		p.jumpIf(bpfJEQ, audit, entries[audit])
+	}
+	p.ret(retKillThread)
+
+	for _, audit := range audits {
+		p.bind(entries[audit])
+		if audit != archInfos["amd64"].audit {
+			for _, arch := range arches {
+				if archInfos[arch].audit == audit {
+					if err := p.compileArch(config, arch, defaultRet); err != nil {
+						return nil, err
+					}
+					break
+				}
+			}
+			continue
+		}
+		x32 := p.newLabel()
+		p.load(offsetNr)
+		p.jumpIf(bpfJGE, x32SyscallBit, x32)
+		for _, arch := range []string{"amd64", "x32"} {
+			if arch == "x32" {
+				p.bind(x32)
+			}
+			if !present[arch] {
+				p.ret(retKillThread)
+				continue
+			}
+			if err := p.compileArch(config, arch, defaultRet); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return p.assemble()
+}
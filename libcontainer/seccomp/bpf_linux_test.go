@@ -0,0 +1,438 @@
+// +build linux
+
+package seccomp
+
+import (
+	"encoding/binary"
+	"fmt"
+	"syscall"
+	"testing"
+
+	"github.com/opencontainers/runc/libcontainer/configs"
+)
+
+// Classic BPF opcodes only emitted by libseccomp, understood by the
+// interpreter below.
+const (
+	bpfLDX  = 0x01
+	bpfST   = 0x02
+	bpfSTX  = 0x03
+	bpfMISC = 0x07
+	bpfMEM  = 0x60
+	bpfJSET = 0x40
+	bpfX    = 0x08
+	bpfTAX  = 0x00
+	bpfTXA  = 0x80
+)
+
+// seccompData is struct seccomp_data.
+type seccompData struct {
+	nr   uint32
+	arch string
+	args [maxArgs]uint64
+}
+
+func (d seccompData) marshal() []byte {
+	var order binary.ByteOrder = binary.LittleEndian
+	if archInfos[d.arch].bigEndian {
+		order = binary.BigEndian
+	}
+	b := make([]byte, offsetArgs+8*maxArgs)
+	order.PutUint32(b[offsetNr:], d.nr)
+	order.PutUint32(b[offsetArch:], archInfos[d.arch].audit)
+	for i, arg := range d.args {
+		order.PutUint64(b[offsetArgs+8*i:], arg)
+	}
+	return b
+}
+
+// runFilter interprets a classic BPF program the way the kernel runs a
+// seccomp filter and returns its verdict.
+func runFilter(prog []sockFilter, d seccompData) (uint32, error) {
+	var order binary.ByteOrder = binary.LittleEndian
+	if archInfos[d.arch].bigEndian {
+		order = binary.BigEndian
+	}
+	var (
+		data    = d.marshal()
+		a, x    uint32
+		scratch [16]uint32
+	)
+	for pc := 0; pc < len(prog); pc++ {
+		insn := prog[pc]
+		src := insn.K
+		if insn.Code&bpfX != 0 {
+			src = x
+		}
+		switch insn.Code & 0x07 {
+		case bpfLD:
+			switch insn.Code &^ 0x07 {
+			case bpfW | bpfABS:
+				if int(insn.K)+4 > len(data) {
+					return 0, fmt.Errorf("load out of bounds at %d", pc)
+				}
+				a = order.Uint32(data[insn.K:])
+			case bpfMEM:
+				a = scratch[insn.K]
+			default:
+				a = insn.K
+			}
+		case bpfLDX:
+			if insn.Code&bpfMEM == bpfMEM {
+				x = scratch[insn.K]
+			} else {
+				x = insn.K
+			}
+		case bpfST:
+			scratch[insn.K] = a
+		case bpfSTX:
+			scratch[insn.K] = x
+		case bpfALU:
+			switch insn.Code & 0xf0 {
+			case 0x00:
+				a += src
+			case 0x10:
+				a -= src
+			case 0x40:
+				a |= src
+			case bpfAND:
+				a &= src
+			default:
+				return 0, fmt.Errorf("unsupported ALU instruction %#x at %d", insn.Code, pc)
+			}
+		case bpfJMP:
+			var cond bool
+			switch insn.Code & 0xf0 {
+			case bpfJA:
+				pc += int(insn.K)
+				continue
+			case bpfJEQ:
+				cond = a == src
+			case bpfJGT:
+				cond = a > src
+			case bpfJGE:
+				cond = a >= src
+			case bpfJSET:
+				cond = a&src != 0
+			default:
+				return 0, fmt.Errorf("unsupported jump %#x at %d", insn.Code, pc)
+			}
+			if cond {
+				pc += int(insn.Jt)
+			} else {
+				pc += int(insn.Jf)
+			}
+		case bpfRET:
+			if insn.Code&0x18 == 0x10 {
+				return a, nil
+			}
+			return insn.K, nil
+		case bpfMISC:
+			if insn.Code&0xf8 == bpfTXA {
+				a = x
+			} else {
+				x = a
+			}
+		}
+	}
+	return 0, fmt.Errorf("program ran off its end")
+}
+
+func nr(t *testing.T, arch, name string) uint32 {
+	n, ok := syscallTables[arch][name]
+	if !ok {
+		t.Fatalf("no syscall %s on %s", name, arch)
+	}
+	return n
+}
+
+func checkVerdicts(t *testing.T, config *configs.Seccomp, native string, cases []seccompData, expected []uint32) {
+	prog, err := compileFilter(config, native)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, d := range cases {
+		ret, err := runFilter(prog, d)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ret != expected[i] {
+			t.Errorf("case %d (%s nr %d args %v): expected %#x, got %#x", i, d.arch, d.nr, d.args, expected[i], ret)
+		}
+	}
+}
+
+func TestCompileFilterActions(t *testing.T) {
+	enosys := uint(syscall.ENOSYS)
+	config := &configs.Seccomp{
+		DefaultAction: configs.Allow,
+		Syscalls: []*configs.Syscall{
+			{Name: "mount", Action: configs.Errno},
+			{Name: "umount2", Action: configs.Errno, ErrnoRet: &enosys},
+			{Name: "ptrace", Action: configs.Trace},
+			{Name: "kexec_load", Action: configs.KillProcess},
+			{Name: "reboot", Action: configs.Kill},
+			{Name: "swapon", Action: configs.Trap},
+			{Name: "acct", Action: configs.Log},
+			{Name: "mknod", Action: configs.Notify},
+			{Name: "no_such_syscall", Action: configs.Kill},
+		},
+	}
+	checkVerdicts(t, config, "amd64", []seccompData{
+		{arch: "amd64", nr: nr(t, "amd64", "mount")},
+		{arch: "amd64", nr: nr(t, "amd64", "umount2")},
+		{arch: "amd64", nr: nr(t, "amd64", "ptrace")},
+		{arch: "amd64", nr: nr(t, "amd64", "kexec_load")},
+		{arch: "amd64", nr: nr(t, "amd64", "reboot")},
+		{arch: "amd64", nr: nr(t, "amd64", "swapon")},
+		{arch: "amd64", nr: nr(t, "amd64", "acct")},
+		{arch: "amd64", nr: nr(t, "amd64", "mknod")},
+		{arch: "amd64", nr: nr(t, "amd64", "read")},
+		{arch: "x86", nr: nr(t, "x86", "read")},
+		{arch: "x32", nr: nr(t, "x32", "read")},
+	}, []uint32{
+		retErrno | uint32(syscall.EPERM),
+		retErrno | uint32(syscall.ENOSYS),
+		retTrace | uint32(syscall.EPERM),
+		retKillProcess,
+		retKillThread,
+		retTrap,
+		retLog,
+		retUserNotif,
+		retAllow,
+		retKillThread,
+		retKillThread,
+	})
+}
+
+func TestCompileFilterDefaultErrno(t *testing.T) {
+	enosys := uint(syscall.ENOSYS)
+	config := &configs.Seccomp{
+		DefaultAction:   configs.Errno,
+		DefaultErrnoRet: &enosys,
+		Syscalls: []*configs.Syscall{
+			{Name: "read", Action: configs.Allow},
+		},
+	}
+	checkVerdicts(t, config, "arm64", []seccompData{
+		{arch: "arm64", nr: nr(t, "arm64", "read")},
+		{arch: "arm64", nr: nr(t, "arm64", "write")},
+	}, []uint32{
+		retAllow,
+		retErrno | uint32(syscall.ENOSYS),
+	})
+}
+
+func TestCompileFilterArgs64(t *testing.T) {
+	const big = 0x100000002
+	var syscalls []*configs.Syscall
+	ops := []configs.Operator{
+		configs.EqualTo,
+		configs.NotEqualTo,
+		configs.GreaterThan,
+		configs.GreaterThanOrEqualTo,
+		configs.LessThan,
+		configs.LessThanOrEqualTo,
+	}
+	names := []string{"read", "write", "close", "dup", "dup2", "dup3"}
+	for i, op := range ops {
+		syscalls = append(syscalls, &configs.Syscall{
+			Name:   names[i],
+			Action: configs.Allow,
+			Args:   []*configs.Arg{{Index: 1, Value: big, Op: op}},
+		})
+	}
+	syscalls = append(syscalls, &configs.Syscall{
+		Name:   "personality",
+		Action: configs.Allow,
+		Args:   []*configs.Arg{{Index: 0, Value: 0xff000000000000ff, ValueTwo: 0x1200000000000008, Op: configs.MaskEqualTo}},
+	})
+	config := &configs.Seccomp{
+		DefaultAction: configs.Errno,
+		Syscalls:      syscalls,
+	}
+
+	values := []uint64{0, 2, 0x100000001, big, 0x100000003, 0x200000000}
+	expect := func(op configs.Operator, v uint64) bool {
+		switch op {
+		case configs.EqualTo:
+			return v == big
+		case configs.NotEqualTo:
+			return v != big
+		case configs.GreaterThan:
+			return v > big
+		case configs.GreaterThanOrEqualTo:
+			return v >= big
+		case configs.LessThan:
+			return v < big
+		default:
+			return v <= big
+		}
+	}
+
+	for _, arch := range []string{"amd64", "mips64", "mipsel64"} {
+		var (
+			cases    []seccompData
+			expected []uint32
+		)
+		for i, op := range ops {
+			for _, v := range values {
+				cases = append(cases, seccompData{arch: arch, nr: nr(t, arch, names[i]), args: [maxArgs]uint64{0, v}})
+				if expect(op, v) {
+					expected = append(expected, retAllow)
+				} else {
+					expected = append(expected, retErrno|uint32(syscall.EPERM))
+				}
+			}
+		}
+		for v, allowed := range map[uint64]bool{
+			0x1200000000000008: true,
+			0x12345678abcdef08: true,
+			0x1200000000000009: false,
+			0x1300000000000008: false,
+		} {
+			cases = append(cases, seccompData{arch: arch, nr: nr(t, arch, "personality"), args: [maxArgs]uint64{v}})
+			if allowed {
+				expected = append(expected, retAllow)
+			} else {
+				expected = append(expected, retErrno|uint32(syscall.EPERM))
+			}
+		}
+		checkVerdicts(t, config, arch, cases, expected)
+	}
+}
+
+func TestCompileFilterArgs32(t *testing.T) {
+	config := &configs.Seccomp{
+		DefaultAction: configs.Allow,
+		Syscalls: []*configs.Syscall{
+			{
+				Name:   "socket",
+				Action: configs.Errno,
+				Args: []*configs.Arg{
+					{Index: 0, Value: syscall.AF_NETLINK, Op: configs.EqualTo},
+					{Index: 2, Value: 9, Op: configs.NotEqualTo},
+				},
+			},
+		},
+	}
+	for _, arch := range []string{"x86", "arm", "mips", "mipsel"} {
+		socket := nr(t, arch, "socket")
+		if arch == "x86" {
+			// i386 only has socketcall, use a syscall that exists.
+			config.Syscalls[0].Name = "kill"
+			socket = nr(t, arch, "kill")
+		}
+		checkVerdicts(t, config, arch, []seccompData{
+			{arch: arch, nr: socket, args: [maxArgs]uint64{syscall.AF_NETLINK, 0, 0}},
+			{arch: arch, nr: socket, args: [maxArgs]uint64{syscall.AF_NETLINK, 0, 9}},
+			{arch: arch, nr: socket, args: [maxArgs]uint64{syscall.AF_INET, 0, 0}},
+		}, []uint32{
+			retErrno | uint32(syscall.EPERM),
+			retAllow,
+			retAllow,
+		})
+		config.Syscalls[0].Name = "socket"
+	}
+}
+
+func TestCompileFilterUnconditionalPrecedence(t *testing.T) {
+	config := &configs.Seccomp{
+		DefaultAction: configs.Allow,
+		Syscalls: []*configs.Syscall{
+			{Name: "clone", Action: configs.Errno, Args: []*configs.Arg{{Index: 0, Value: 1, Op: configs.EqualTo}}},
+			{Name: "clone", Action: configs.Trap},
+			{Name: "unshare", Action: configs.Errno, Args: []*configs.Arg{{Index: 0, Value: 1, Op: configs.EqualTo}}},
+			{Name: "unshare", Action: configs.Trap, Args: []*configs.Arg{{Index: 0, Value: 1, Op: configs.GreaterThanOrEqualTo}}},
+		},
+	}
+	checkVerdicts(t, config, "amd64", []seccompData{
+		{arch: "amd64", nr: nr(t, "amd64", "clone"), args: [maxArgs]uint64{1}},
+		{arch: "amd64", nr: nr(t, "amd64", "clone"), args: [maxArgs]uint64{2}},
+		{arch: "amd64", nr: nr(t, "amd64", "unshare"), args: [maxArgs]uint64{1}},
+		{arch: "amd64", nr: nr(t, "amd64", "unshare"), args: [maxArgs]uint64{2}},
+		{arch: "amd64", nr: nr(t, "amd64", "unshare"), args: [maxArgs]uint64{0}},
+	}, []uint32{
+		retTrap,
+		retTrap,
+		retErrno | uint32(syscall.EPERM),
+		retTrap,
+		retAllow,
+	})
+}
+
+func TestCompileFilterMultipleArches(t *testing.T) {
+	config := &configs.Seccomp{
+		DefaultAction: configs.Allow,
+		Architectures: []string{"x86", "x32"},
+		Syscalls: []*configs.Syscall{
+			{Name: "mount", Action: configs.Errno},
+			{Name: "execve", Action: configs.Trap},
+		},
+	}
+	eperm := retErrno | uint32(syscall.EPERM)
+	checkVerdicts(t, config, "amd64", []seccompData{
+		{arch: "amd64", nr: nr(t, "amd64", "mount")},
+		{arch: "x86", nr: nr(t, "x86", "mount")},
+		{arch: "x32", nr: nr(t, "x32", "mount")},
+		{arch: "amd64", nr: nr(t, "amd64", "execve")},
+		{arch: "x86", nr: nr(t, "x86", "execve")},
+		{arch: "x32", nr: nr(t, "x32", "execve")},
+		{arch: "x86", nr: nr(t, "x86", "read")},
+		{arch: "x32", nr: nr(t, "x32", "read")},
+		{arch: "arm", nr: nr(t, "arm", "read")},
+	}, []uint32{
+		eperm, eperm, eperm,
+		retTrap, retTrap, retTrap,
+		retAllow, retAllow,
+		retKillThread,
+	})
+}
+
+func TestCompileFilterLarge(t *testing.T) {
+	config := &configs.Seccomp{
+		DefaultAction: configs.Errno,
+		Architectures: []string{"x86", "x32"},
+	}
+	for name := range syscallTables["amd64"] {
+		config.Syscalls = append(config.Syscalls, &configs.Syscall{
+			Name:   name,
+			Action: configs.Allow,
+		})
+	}
+	// Conditional rules at the end of the dispatch are out of reach of
+	// conditional jumps.
+	config.Syscalls = append(config.Syscalls, &configs.Syscall{
+		Name:   "mount",
+		Action: configs.Trap,
+		Args:   []*configs.Arg{{Index: 5, Value: 1, Op: configs.NotEqualTo}},
+	})
+	prog, err := compileFilter(config, "amd64")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for d, expected := range map[seccompData]uint32{
+		{arch: "x86", nr: nr(t, "x86", "write")}:     retAllow,
+		{arch: "x32", nr: nr(t, "x32", "write")}:     retAllow,
+		{arch: "amd64", nr: nr(t, "amd64", "write")}: retAllow,
+		{arch: "amd64", nr: 1000}:                    retErrno | uint32(syscall.EPERM),
+	} {
+		ret, err := runFilter(prog, d)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ret != expected {
+			t.Errorf("%s nr %d: expected %#x, got %#x", d.arch, d.nr, expected, ret)
+		}
+	}
+}
+
+func TestCompileFilterUnsupportedArch(t *testing.T) {
+	config := &configs.Seccomp{
+		DefaultAction: configs.Allow,
+		Architectures: []string{"mips64n32"},
+	}
+	if _, err := compileFilter(config, "amd64"); err == nil {
+		t.Fatal("expected an error for an unsupported architecture")
+	}
+}
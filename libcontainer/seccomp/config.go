@@ -17,11 +17,20 @@ var operators = map[string]configs.Operator{
 }
 
 var actions = map[string]configs.Action{
-	"SCMP_ACT_KILL":  configs.Kill,
-	"SCMP_ACT_ERRNO": configs.Errno,
-	"SCMP_ACT_TRAP":  configs.Trap,
-	"SCMP_ACT_ALLOW": configs.Allow,
-	"SCMP_ACT_TRACE": configs.Trace,
+	"SCMP_ACT_KILL":         configs.Kill,
+	"SCMP_ACT_KILL_PROCESS": configs.KillProcess,
+	"SCMP_ACT_ERRNO":        configs.Errno,
+	"SCMP_ACT_TRAP":         configs.Trap,
+	"SCMP_ACT_ALLOW":        configs.Allow,
+	"SCMP_ACT_TRACE":        configs.Trace,
+	"SCMP_ACT_LOG":          configs.Log,
+	"SCMP_ACT_NOTIFY":       configs.Notify,
+}
+
+var flags = map[string]configs.SeccompFlag{
+	"SECCOMP_FILTER_FLAG_TSYNC":      configs.FlagTsync,
+	"SECCOMP_FILTER_FLAG_LOG":        configs.FlagLog,
+	"SECCOMP_FILTER_FLAG_SPEC_ALLOW": configs.FlagSpecAllow,
 }
 
 var archs = map[string]string{
@@ -62,6 +71,17 @@ func ConvertStringToAction(in string) (configs.Action, error) {
 	return 0, fmt.Errorf("string %s is not a valid action for seccomp", in)
 }
 
+// ConvertStringToFlag converts a string into a Seccomp filter flag.
+// Flags use the names they are assigned in the kernel's seccomp header.
+// Attempting to convert a string that is not a valid flag results in an
+// error.
+func ConvertStringToFlag(in string) (configs.SeccompFlag, error) {
+	if flag, ok := flags[in]; ok == true {
+		return flag, nil
+	}
+	return 0, fmt.Errorf("string %s is not a valid flag for seccomp", in)
+}
+
 // ConvertStringToArch converts a string into a Seccomp comparison arch.
 func ConvertStringToArch(in string) (string, error) {
 	if arch, ok := archs[in]; ok == true {
@@ -69,3 +89,35 @@ func ConvertStringToArch(in string) (string, error) {
 	}
 	return "", fmt.Errorf("string %s is not a valid arch for seccomp", in)
 }
+
+// ConvertArchToString converts a Seccomp arch into its string representation.
+func ConvertArchToString(in string) (string, error) {
+	for name, arch := range archs {
+		if arch == in {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("arch %s is not a valid arch for seccomp", in)
+}
+
+// actionName returns the Libseccomp name of a Seccomp action, for use in
+// error messages.
+func actionName(act configs.Action) string {
+	for name, a := range actions {
+		if a == act {
+			return name
+		}
+	}
+	return fmt.Sprintf("action(%d)", act)
+}
+
+// flagName returns the kernel name of a Seccomp filter flag, for use in error
+// messages.
+func flagName(flag configs.SeccompFlag) string {
+	for name, f := range flags {
+		if f == flag {
+			return name
+		}
+	}
+	return fmt.Sprintf("flag(%d)", flag)
+}
@@ -0,0 +1,208 @@
+// +build linux
+
+package seccomp
+
+import (
+	"runtime"
+	"sort"
+	"sync"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+// seccompNotif is struct seccomp_notif, from linux/seccomp.h.
+type seccompNotif struct {
+	id    uint64
+	pid   uint32
+	flags uint32
+	nr    int32
+	arch  uint32
+	ip    uint64
+	args  [maxArgs]uint64
+}
+
+// seccompNotifResp is struct seccomp_notif_resp, from linux/seccomp.h.
+type seccompNotifResp struct {
+	id    uint64
+	val   int64
+	error int32
+	flags uint32
+}
+
+// seccompUserNotifFlagContinue lets the notified syscall run as if the filter
+// had allowed it.
+const seccompUserNotifFlagContinue = 1
+
+var (
+	ioctlNotifRecv = iowr('!', 0, unsafe.Sizeof(seccompNotif{}))
+	ioctlNotifSend = iowr('!', 1, unsafe.Sizeof(seccompNotifResp{}))
+)
+
+// iowr is the _IOWR macro of linux/ioctl.h.
+func iowr(typ, nr, size uintptr) uintptr {
+	switch runtime.GOARCH {
+	case "mips", "mipsle", "mips64", "mips64le", "ppc64", "ppc64le":
+		return 6<<29 | size<<16 | typ<<8 | nr
+	default:
+		return 3<<30 | size<<16 | typ<<8 | nr
+	}
+}
+
+// learnPollInterval is how often a Learner checks whether it was stopped
+// while waiting for notifications.
+const learnPollInterval = 100 * time.Millisecond
+
+// compatArches lists the architectures whose processes can run next to the
+// ones of the native architecture.
+var compatArches = map[string][]string{
+	"amd64":    {"x86", "x32"},
+	"arm64":    {"arm"},
+	"mips64":   {"mips"},
+	"mipsel64": {"mipsel"},
+}
+
+// LearnArches returns the architectures a filter learning the syscalls of a
+// container should cover: the native one and its compat architectures.
+func LearnArches() ([]string, error) {
+	native, err := nativeArch()
+	if err != nil {
+		return nil, err
+	}
+	return append([]string{native}, compatArches[native]...), nil
+}
+
+// Learner is a seccomp agent recording the syscalls notified by the listeners
+// it watches, and letting all of them continue.
+type Learner struct {
+	mu       sync.Mutex
+	syscalls map[learntSyscall]bool
+	stop     chan struct{}
+	wg       sync.WaitGroup
+}
+
+// learntSyscall is a syscall as seen by a filter.
+type learntSyscall struct {
+	audit uint32
+	nr    uint32
+}
+
+// NewLearner returns a Learner that has not recorded any syscall yet.
+func NewLearner() *Learner {
+	return &Learner{
+		syscalls: make(map[learntSyscall]bool),
+		stop:     make(chan struct{}),
+	}
+}
+
+// Watch records the syscalls notified by the seccomp listener fd until the
+// Learner is stopped or the filter has no users left. The fd is closed then.
+func (l *Learner) Watch(fd int) {
+	l.wg.Add(1)
+	go func() {
+		defer l.wg.Done()
+		defer syscall.Close(fd)
+		for {
+			select {
+			case <-l.stop:
+				return
+			default:
+			}
+			ready, hup, err := pollListener(fd, learnPollInterval)
+			if err != nil {
+				return
+			}
+			if ready {
+				l.handle(fd)
+			} else if hup {
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops watching all listeners and waits for them to be closed.
+func (l *Learner) Stop() {
+	close(l.stop)
+	l.wg.Wait()
+}
+
+// Syscalls returns the sorted names of the syscalls recorded, per
+// architecture.
+func (l *Learner) Syscalls() map[string][]string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	out := make(map[string][]string)
+	for s := range l.syscalls {
+		if arch, name, ok := lookupSyscall(s.audit, s.nr); ok {
+			out[arch] = append(out[arch], name)
+		}
+	}
+	for _, names := range out {
+		sort.Strings(names)
+	}
+	return out
+}
+
+// handle receives a notification, records its syscall and lets it continue.
+func (l *Learner) handle(fd int) {
+	var notif seccompNotif
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), ioctlNotifRecv, uintptr(unsafe.Pointer(&notif))); errno != 0 {
+		// The notifying process may have been killed in the meantime.
+		return
+	}
+	l.mu.Lock()
+	l.syscalls[learntSyscall{audit: notif.arch, nr: uint32(notif.nr)}] = true
+	l.mu.Unlock()
+	resp := seccompNotifResp{
+		id:    notif.id,
+		flags: seccompUserNotifFlagContinue,
+	}
+	syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), ioctlNotifSend, uintptr(unsafe.Pointer(&resp)))
+}
+
+// lookupSyscall returns the architecture and name of syscall nr of the audit
+// architecture.
+func lookupSyscall(audit uint32, nr uint32) (string, string, bool) {
+	for arch, info := range archInfos {
+		if info.audit != audit {
+			continue
+		}
+		// amd64 and x32 share an audit architecture, x32 syscalls are
+		// told apart by their number.
+		if info.audit == archInfos["x32"].audit && (arch == "x32") != (nr&x32SyscallBit != 0) {
+			continue
+		}
+		for name, n := range syscallTables[arch] {
+			if n == nr {
+				return arch, name, true
+			}
+		}
+	}
+	return "", "", false
+}
+
+// pollListener waits up to timeout for a notification on the listener fd. It
+// also reports whether the filter has no users left.
+func pollListener(fd int, timeout time.Duration) (bool, bool, error) {
+	const (
+		pollIn  = 0x1
+		pollHup = 0x10
+	)
+	fds := [1]struct {
+		fd      int32
+		events  int16
+		revents int16
+	}{{fd: int32(fd), events: pollIn}}
+	ts := syscall.NsecToTimespec(int64(timeout))
+	for {
+		_, _, errno := syscall.Syscall6(syscall.SYS_PPOLL, uintptr(unsafe.Pointer(&fds[0])), 1, uintptr(unsafe.Pointer(&ts)), 0, 0, 0)
+		if errno == syscall.EINTR {
+			continue
+		}
+		if errno != 0 {
+			return false, false, errno
+		}
+		return fds[0].revents&pollIn != 0, fds[0].revents&pollHup != 0, nil
+	}
+}
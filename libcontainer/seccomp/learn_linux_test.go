@@ -0,0 +1,27 @@
+// +build linux
+
+package seccomp
+
+import "testing"
+
+func TestLookupSyscall(t *testing.T) {
+	for _, c := range []struct {
+		arch, name string
+	}{
+		{"amd64", "read"},
+		{"amd64", "openat"},
+		{"x32", "read"},
+		{"x32", "execve"},
+		{"x86", "socketcall"},
+		{"arm64", "openat"},
+		{"mipsel64", "write"},
+	} {
+		arch, name, ok := lookupSyscall(archInfos[c.arch].audit, nr(t, c.arch, c.name))
+		if !ok || arch != c.arch || name != c.name {
+			t.Errorf("expected %s %s, got %s %s (%v)", c.arch, c.name, arch, name, ok)
+		}
+	}
+	if _, _, ok := lookupSyscall(archInfos["amd64"].audit, 0xffff); ok {
+		t.Error("expected unknown syscall not to be found")
+	}
+}
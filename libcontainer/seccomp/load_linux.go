@@ -0,0 +1,120 @@
+// +build linux
+
+package seccomp
+
+import (
+	"fmt"
+	"syscall"
+	"unsafe"
+
+	"github.com/opencontainers/runc/libcontainer/configs"
+)
+
+// Operations and flags of the seccomp(2) syscall, from linux/seccomp.h.
+const (
+	seccompSetModeFilter = 1
+
+	seccompFilterFlagTsync       = 1
+	seccompFilterFlagLog         = 2
+	seccompFilterFlagSpecAllow   = 4
+	seccompFilterFlagNewListener = 8
+	seccompFilterFlagTsyncEsrch  = 16
+)
+
+// sockFprog is struct sock_fprog.
+type sockFprog struct {
+	Len    uint16
+	Filter *sockFilter
+}
+
+// initBPF compiles config into a BPF program and loads it with prctl(2), or
+// with seccomp(2) when filter flags are set or a listener is needed.
+func initBPF(config *configs.Seccomp) (int, error) {
+	if config == nil {
+		return -1, fmt.Errorf("cannot initialize Seccomp - nil config passed")
+	}
+
+	native, err := nativeArch()
+	if err != nil {
+		return -1, err
+	}
+
+	filter, err := compileFilter(config, native)
+	if err != nil {
+		return -1, fmt.Errorf("error compiling seccomp filter: %s", err)
+	}
+
+	fd, err := loadFilter(filter, config.Flags, usesNotify(config), native)
+	if err != nil {
+		return -1, fmt.Errorf("error loading seccomp filter into kernel: %s", err)
+	}
+
+	return fd, nil
+}
+
+// usesNotify reports whether the filter hands syscalls to a listener.
+func usesNotify(config *configs.Seccomp) bool {
+	if config.DefaultAction == configs.Notify {
+		return true
+	}
+	for _, call := range config.Syscalls {
+		if call != nil && call.Action == configs.Notify {
+			return true
+		}
+	}
+	return false
+}
+
+func loadFilter(filter []sockFilter, flags []configs.SeccompFlag, listener bool, native string) (int, error) {
+	prog := sockFprog{
+		Len:    uint16(len(filter)),
+		Filter: &filter[0],
+	}
+
+	if len(flags) == 0 && !listener {
+		if _, _, errno := syscall.RawSyscall(syscall.SYS_PRCTL, syscall.PR_SET_SECCOMP, SeccompModeFilter, uintptr(unsafe.Pointer(&prog))); errno != 0 {
+			return -1, errno
+		}
+		return -1, nil
+	}
+
+	var kernelFlags uintptr
+	for _, flag := range flags {
+		switch flag {
+		case configs.FlagTsync:
+			kernelFlags |= seccompFilterFlagTsync
+		case configs.FlagLog:
+			kernelFlags |= seccompFilterFlagLog
+		case configs.FlagSpecAllow:
+			kernelFlags |= seccompFilterFlagSpecAllow
+		default:
+			return -1, fmt.Errorf("invalid seccomp flag %d", flag)
+		}
+	}
+	if listener {
+		kernelFlags |= seccompFilterFlagNewListener
+		// The return value cannot be both a thread id and a listener
+		// fd, so TSYNC failures have to be reported as ESRCH.
+		if kernelFlags&seccompFilterFlagTsync != 0 {
+			kernelFlags |= seccompFilterFlagTsyncEsrch
+		}
+	}
+
+	nr, ok := syscallTables[native]["seccomp"]
+	if !ok {
+		return -1, fmt.Errorf("seccomp flags are not supported on %s", native)
+	}
+	ret, _, errno := syscall.RawSyscall(uintptr(nr), seccompSetModeFilter, kernelFlags, uintptr(unsafe.Pointer(&prog)))
+	if errno != 0 {
+		return -1, errno
+	}
+	if listener {
+		return int(ret), nil
+	}
+	if ret != 0 {
+		// With TSYNC, a positive return value is the thread that could
+		// not be synchronized.
+		return -1, fmt.Errorf("thread %d could not be synchronized to the filter", ret)
+	}
+	return -1, nil
+}
@@ -0,0 +1,164 @@
+// +build ignore
+
+// mksyscalls generates the per-architecture syscall number tables used by the
+// pure Go seccomp filter compiler from the zsysnum_linux_*.go files of
+// golang.org/x/sys/unix.
+//
+// Usage: go run mksyscalls.go $GOPATH/src/golang.org/x/sys/unix > zsyscalls_linux.go
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// sources maps the seccomp architecture names to the x/sys/unix GOARCH whose
+// table they share.
+var sources = []struct {
+	arch   string
+	goarch string
+}{
+	{"x86", "386"},
+	{"amd64", "amd64"},
+	{"arm", "arm"},
+	{"arm64", "arm64"},
+	{"mips", "mips"},
+	{"mipsel", "mipsle"},
+	{"mips64", "mips64"},
+	{"mipsel64", "mips64le"},
+}
+
+// x32Bit is __X32_SYSCALL_BIT, set on every syscall number of the x32 ABI.
+const x32Bit = 0x40000000
+
+// x32Compat lists the syscalls that x32 does not share with amd64 but
+// implements with a compat entry point, see arch/x86/entry/syscalls/syscall_64.tbl.
+var x32Compat = map[string]uint32{
+	"rt_sigaction":      512,
+	"rt_sigreturn":      513,
+	"ioctl":             514,
+	"readv":             515,
+	"writev":            516,
+	"recvfrom":          517,
+	"sendmsg":           518,
+	"recvmsg":           519,
+	"execve":            520,
+	"ptrace":            521,
+	"rt_sigpending":     522,
+	"rt_sigtimedwait":   523,
+	"rt_sigqueueinfo":   524,
+	"sigaltstack":       525,
+	"timer_create":      526,
+	"mq_notify":         527,
+	"kexec_load":        528,
+	"waitid":            529,
+	"set_robust_list":   530,
+	"get_robust_list":   531,
+	"vmsplice":          532,
+	"move_pages":        533,
+	"preadv":            534,
+	"pwritev":           535,
+	"rt_tgsigqueueinfo": 536,
+	"recvmmsg":          537,
+	"sendmmsg":          538,
+	"process_vm_readv":  539,
+	"process_vm_writev": 540,
+	"setsockopt":        541,
+	"getsockopt":        542,
+	"io_setup":          543,
+	"io_submit":         544,
+	"execveat":          545,
+	"preadv2":           546,
+	"pwritev2":          547,
+}
+
+var sysnum = regexp.MustCompile(`^\s*SYS_([A-Z0-9_]+)\s*=\s*([0-9]+)`)
+
+func parse(path string) (map[string]uint32, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	table := make(map[string]uint32)
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		m := sysnum.FindStringSubmatch(s.Text())
+		if m == nil {
+			continue
+		}
+		n, err := strconv.ParseUint(m[2], 10, 32)
+		if err != nil {
+			return nil, err
+		}
+		table[strings.ToLower(m[1])] = uint32(n)
+	}
+	return table, s.Err()
+}
+
+func writeTable(buf *bytes.Buffer, arch string, table map[string]uint32) {
+	names := make([]string, 0, len(table))
+	for name := range table {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Fprintf(buf, "\t%q: {\n", arch)
+	for _, name := range names {
+		if table[name]&x32Bit != 0 {
+			fmt.Fprintf(buf, "\t\t%q: %#x,\n", name, table[name])
+		} else {
+			fmt.Fprintf(buf, "\t\t%q: %d,\n", name, table[name])
+		}
+	}
+	fmt.Fprintf(buf, "\t},\n")
+}
+
+func main() {
+	if len(os.Args) != 2 {
+		fmt.Fprintf(os.Stderr, "usage: %s <golang.org/x/sys/unix directory>\n", os.Args[0])
+		os.Exit(1)
+	}
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by mksyscalls.go; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "// +build linux\n\npackage seccomp\n\n")
+	fmt.Fprintf(&buf, "// syscallTables maps a seccomp architecture name to its syscall numbers.\n")
+	fmt.Fprintf(&buf, "var syscallTables = map[string]map[string]uint32{\n")
+	for _, src := range sources {
+		table, err := parse(filepath.Join(os.Args[1], "zsysnum_linux_"+src.goarch+".go"))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		writeTable(&buf, src.arch, table)
+		if src.arch == "amd64" {
+			x32 := make(map[string]uint32, len(table))
+			for name, n := range table {
+				// The 64-bit entry points replaced by compat ones are
+				// not reachable from x32.
+				if _, ok := x32Compat[name]; !ok {
+					x32[name] = n | x32Bit
+				}
+			}
+			for name, n := range x32Compat {
+				x32[name] = n | x32Bit
+			}
+			writeTable(&buf, "x32", x32)
+		}
+	}
+	fmt.Fprintf(&buf, "}\n")
+	out, err := format.Source(buf.Bytes())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	// Keep the build constraint in the same form as the rest of the package.
+	os.Stdout.Write(bytes.Replace(out, []byte("//go:build linux\n"), nil, 1))
+}
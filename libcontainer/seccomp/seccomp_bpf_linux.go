@@ -0,0 +1,20 @@
+// +build linux
+// +build !cgo !seccomp
+
+package seccomp
+
+import "github.com/opencontainers/runc/libcontainer/configs"
+
+// Filters given syscalls in a container, preventing them from being used
+// Started in the container init process, and carried over to all child processes
+// Setns calls, however, require a separate invocation, as they are not children
+// of the init until they join the namespace
+//
+// Without libseccomp, the configuration is compiled into a BPF program by runc
+// itself and loaded with prctl(2), or with seccomp(2) when filter flags are set.
+//
+// When any syscall uses the Notify action, the filter is loaded with a new
+// listener, whose fd is returned. Otherwise the returned fd is -1.
+func InitSeccomp(config *configs.Seccomp) (int, error) {
+	return initBPF(config)
+}
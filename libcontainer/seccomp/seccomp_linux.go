@@ -3,10 +3,7 @@
 package seccomp
 
 import (
-	"bufio"
 	"fmt"
-	"os"
-	"strings"
 	"syscall"
 
 	"github.com/opencontainers/runc/libcontainer/configs"
@@ -19,30 +16,88 @@ var (
 	actKill  = libseccomp.ActKill
 	actTrace = libseccomp.ActTrace.SetReturnCode(int16(syscall.EPERM))
 	actErrno = libseccomp.ActErrno.SetReturnCode(int16(syscall.EPERM))
-
-	// SeccompModeFilter refers to the syscall argument SECCOMP_MODE_FILTER.
-	SeccompModeFilter = uintptr(2)
 )
 
 // Filters given syscalls in a container, preventing them from being used
 // Started in the container init process, and carried over to all child processes
 // Setns calls, however, require a separate invocation, as they are not children
 // of the init until they join the namespace
-func InitSeccomp(config *configs.Seccomp) error {
+//
+// The actions and flags the libseccomp bindings runc was built with do not
+// support are left to the BPF compiler of runc, see needsBPF.
+//
+// When any syscall uses the Notify action, the filter is loaded with a new
+// listener, whose fd is returned. Otherwise the returned fd is -1.
+func InitSeccomp(config *configs.Seccomp) (int, error) {
+	if config != nil && needsBPF(config) {
+		return initBPF(config)
+	}
+	filter, err := newFilter(config)
+	if err != nil {
+		return -1, err
+	}
+	defer filter.Release()
+
+	if err = filter.Load(); err != nil {
+		return -1, fmt.Errorf("error loading seccomp filter into kernel: %s", err)
+	}
+
+	return -1, nil
+}
+
+// needsBPF reports whether config uses actions or flags the libseccomp
+// bindings do not support, and so has to be compiled by runc itself.
+func needsBPF(config *configs.Seccomp) bool {
+	for _, flag := range config.Flags {
+		if flag == configs.FlagLog || flag == configs.FlagSpecAllow {
+			return true
+		}
+	}
+	if !hasLibseccompAction(config.DefaultAction) {
+		return true
+	}
+	for _, call := range config.Syscalls {
+		if call != nil && !hasLibseccompAction(call.Action) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasLibseccompAction reports whether the libseccomp bindings support act.
+func hasLibseccompAction(act configs.Action) bool {
+	switch act {
+	case configs.KillProcess, configs.Log, configs.Notify:
+		return false
+	}
+	return true
+}
+
+// newFilter builds the libseccomp filter for config without loading it.
+func newFilter(config *configs.Seccomp) (*libseccomp.ScmpFilter, error) {
 	if config == nil {
-		return fmt.Errorf("cannot initialize Seccomp - nil config passed")
+		return nil, fmt.Errorf("cannot initialize Seccomp - nil config passed")
 	}
 
-	defaultAction, err := getAction(config.DefaultAction)
+	defaultAction, err := getAction(config.DefaultAction, config.DefaultErrnoRet)
 	if err != nil {
-		return fmt.Errorf("error initializing seccomp - invalid default action")
+		return nil, fmt.Errorf("error initializing seccomp - invalid default action: %s", err)
 	}
 
 	filter, err := libseccomp.NewFilter(defaultAction)
 	if err != nil {
-		return fmt.Errorf("error creating filter: %s", err)
+		return nil, fmt.Errorf("error creating filter: %s", err)
+	}
+
+	if err := addRules(filter, config); err != nil {
+		filter.Release()
+		return nil, err
 	}
 
+	return filter, nil
+}
+
+func addRules(filter *libseccomp.ScmpFilter, config *configs.Seccomp) error {
 	// Add extra architectures
 	for _, arch := range config.Architectures {
 		scmpArch, err := libseccomp.GetArchFromString(arch)
@@ -60,60 +115,68 @@ func InitSeccomp(config *configs.Seccomp) error {
 		return fmt.Errorf("error setting no new privileges: %s", err)
 	}
 
+	for _, flag := range config.Flags {
+		if err := setFlag(filter, flag); err != nil {
+			return err
+		}
+	}
+
 	// Add a rule for each syscall
 	for _, call := range config.Syscalls {
 		if call == nil {
 			return fmt.Errorf("encountered nil syscall while initializing Seccomp")
 		}
 
-		if err = matchCall(filter, call); err != nil {
+		if err := matchCall(filter, call); err != nil {
 			return err
 		}
 	}
 
-	if err = filter.Load(); err != nil {
-		return fmt.Errorf("error loading seccomp filter into kernel: %s", err)
-	}
-
 	return nil
 }
 
-// IsEnabled returns if the kernel has been configured to support seccomp.
-func IsEnabled() bool {
-	// Try to read from /proc/self/status for kernels > 3.8
-	s, err := parseStatusFile("/proc/self/status")
-	if err != nil {
-		// Check if Seccomp is supported, via CONFIG_SECCOMP.
-		if _, _, err := syscall.RawSyscall(syscall.SYS_PRCTL, syscall.PR_GET_SECCOMP, 0, 0); err != syscall.EINVAL {
-			// Make sure the kernel has CONFIG_SECCOMP_FILTER.
-			if _, _, err := syscall.RawSyscall(syscall.SYS_PRCTL, syscall.PR_SET_SECCOMP, SeccompModeFilter, 0); err != syscall.EINVAL {
-				return true
-			}
-		}
-		return false
-	}
-	_, ok := s["Seccomp"]
-	return ok
-}
-
 // Convert Libcontainer Action to Libseccomp ScmpAction
-func getAction(act configs.Action) (libseccomp.ScmpAction, error) {
+// errnoRet, if set, overrides the return code of the Errno and Trace actions.
+func getAction(act configs.Action, errnoRet *uint) (libseccomp.ScmpAction, error) {
 	switch act {
 	case configs.Kill:
 		return actKill, nil
 	case configs.Errno:
+		if errnoRet != nil {
+			return libseccomp.ActErrno.SetReturnCode(int16(*errnoRet)), nil
+		}
 		return actErrno, nil
 	case configs.Trap:
 		return actTrap, nil
 	case configs.Allow:
 		return actAllow, nil
 	case configs.Trace:
+		if errnoRet != nil {
+			return libseccomp.ActTrace.SetReturnCode(int16(*errnoRet)), nil
+		}
 		return actTrace, nil
+	case configs.KillProcess, configs.Log, configs.Notify:
+		return libseccomp.ActInvalid, fmt.Errorf("action %s is not supported by the libseccomp bindings runc was built with", actionName(act))
 	default:
 		return libseccomp.ActInvalid, fmt.Errorf("invalid action, cannot use in rule")
 	}
 }
 
+// Apply a Libcontainer SeccompFlag to the filter before it is loaded
+func setFlag(filter *libseccomp.ScmpFilter, flag configs.SeccompFlag) error {
+	switch flag {
+	case configs.FlagTsync:
+		if err := filter.SetTsync(true); err != nil {
+			return fmt.Errorf("error setting seccomp flag %s: %s", flagName(flag), err)
+		}
+		return nil
+	case configs.FlagLog, configs.FlagSpecAllow:
+		return fmt.Errorf("seccomp flag %s is not supported by the libseccomp bindings runc was built with", flagName(flag))
+	default:
+		return fmt.Errorf("invalid seccomp flag %d", flag)
+	}
+}
+
 // Convert Libcontainer Operator to Libseccomp ScmpCompareOp
 func getOperator(op configs.Operator) (libseccomp.ScmpCompareOp, error) {
 	switch op {
@@ -170,7 +233,7 @@ func matchCall(filter *libseccomp.ScmpFilter, call *configs.Syscall) error {
 	}
 
 	// Convert the call's action to the libseccomp equivalent
-	callAct, err := getAction(call.Action)
+	callAct, err := getAction(call.Action, call.ErrnoRet)
 	if err != nil {
 		return err
 	}
@@ -200,30 +263,3 @@ func matchCall(filter *libseccomp.ScmpFilter, call *configs.Syscall) error {
 
 	return nil
 }
-
-func parseStatusFile(path string) (map[string]string, error) {
-	f, err := os.Open(path)
-	if err != nil {
-		return nil, err
-	}
-	defer f.Close()
-
-	s := bufio.NewScanner(f)
-	status := make(map[string]string)
-
-	for s.Scan() {
-		if err := s.Err(); err != nil {
-			return nil, err
-		}
-
-		text := s.Text()
-		parts := strings.Split(text, ":")
-
-		if len(parts) <= 1 {
-			continue
-		}
-
-		status[parts[0]] = parts[1]
-	}
-	return status, nil
-}
@@ -1,4 +1,4 @@
-// +build linux,cgo,seccomp
+// +build linux
 
 package seccomp
 
@@ -0,0 +1,3651 @@
+// Code generated by mksyscalls.go; DO NOT EDIT.
+
+// +build linux
+
+package seccomp
+
+// syscallTables maps a seccomp architecture name to its syscall numbers.
+var syscallTables = map[string]map[string]uint32{
+	"x86": {
+		"_llseek":                      140,
+		"_newselect":                   142,
+		"_sysctl":                      149,
+		"accept4":                      364,
+		"access":                       33,
+		"acct":                         51,
+		"add_key":                      286,
+		"adjtimex":                     124,
+		"afs_syscall":                  137,
+		"alarm":                        27,
+		"arch_prctl":                   384,
+		"bdflush":                      134,
+		"bind":                         361,
+		"bpf":                          357,
+		"break":                        17,
+		"brk":                          45,
+		"cachestat":                    451,
+		"capget":                       184,
+		"capset":                       185,
+		"chdir":                        12,
+		"chmod":                        15,
+		"chown":                        182,
+		"chown32":                      212,
+		"chroot":                       61,
+		"clock_adjtime":                343,
+		"clock_adjtime64":              405,
+		"clock_getres":                 266,
+		"clock_getres_time64":          406,
+		"clock_gettime":                265,
+		"clock_gettime64":              403,
+		"clock_nanosleep":              267,
+		"clock_nanosleep_time64":       407,
+		"clock_settime":                264,
+		"clock_settime64":              404,
+		"clone":                        120,
+		"clone3":                       435,
+		"close":                        6,
+		"close_range":                  436,
+		"connect":                      362,
+		"copy_file_range":              377,
+		"creat":                        8,
+		"create_module":                127,
+		"delete_module":                129,
+		"dup":                          41,
+		"dup2":                         63,
+		"dup3":                         330,
+		"epoll_create":                 254,
+		"epoll_create1":                329,
+		"epoll_ctl":                    255,
+		"epoll_pwait":                  319,
+		"epoll_pwait2":                 441,
+		"epoll_wait":                   256,
+		"eventfd":                      323,
+		"eventfd2":                     328,
+		"execve":                       11,
+		"execveat":                     358,
+		"exit":                         1,
+		"exit_group":                   252,
+		"faccessat":                    307,
+		"faccessat2":                   439,
+		"fadvise64":                    250,
+		"fadvise64_64":                 272,
+		"fallocate":                    324,
+		"fanotify_init":                338,
+		"fanotify_mark":                339,
+		"fchdir":                       133,
+		"fchmod":                       94,
+		"fchmodat":                     306,
+		"fchmodat2":                    452,
+		"fchown":                       95,
+		"fchown32":                     207,
+		"fchownat":                     298,
+		"fcntl":                        55,
+		"fcntl64":                      221,
+		"fdatasync":                    148,
+		"fgetxattr":                    231,
+		"file_getattr":                 468,
+		"file_setattr":                 469,
+		"finit_module":                 350,
+		"flistxattr":                   234,
+		"flock":                        143,
+		"fork":                         2,
+		"fremovexattr":                 237,
+		"fsconfig":                     431,
+		"fsetxattr":                    228,
+		"fsmount":                      432,
+		"fsopen":                       430,
+		"fspick":                       433,
+		"fstat":                        108,
+		"fstat64":                      197,
+		"fstatat64":                    300,
+		"fstatfs":                      100,
+		"fstatfs64":                    269,
+		"fsync":                        118,
+		"ftime":                        35,
+		"ftruncate":                    93,
+		"ftruncate64":                  194,
+		"futex":                        240,
+		"futex_requeue":                456,
+		"futex_time64":                 422,
+		"futex_wait":                   455,
+		"futex_waitv":                  449,
+		"futex_wake":                   454,
+		"futimesat":                    299,
+		"get_kernel_syms":              130,
+		"get_mempolicy":                275,
+		"get_robust_list":              312,
+		"get_thread_area":              244,
+		"getcpu":                       318,
+		"getcwd":                       183,
+		"getdents":                     141,
+		"getdents64":                   220,
+		"getegid":                      50,
+		"getegid32":                    202,
+		"geteuid":                      49,
+		"geteuid32":                    201,
+		"getgid":                       47,
+		"getgid32":                     200,
+		"getgroups":                    80,
+		"getgroups32":                  205,
+		"getitimer":                    105,
+		"getpeername":                  368,
+		"getpgid":                      132,
+		"getpgrp":                      65,
+		"getpid":                       20,
+		"getpmsg":                      188,
+		"getppid":                      64,
+		"getpriority":                  96,
+		"getrandom":                    355,
+		"getresgid":                    171,
+		"getresgid32":                  211,
+		"getresuid":                    165,
+		"getresuid32":                  209,
+		"getrlimit":                    76,
+		"getrusage":                    77,
+		"getsid":                       147,
+		"getsockname":                  367,
+		"getsockopt":                   365,
+		"gettid":                       224,
+		"gettimeofday":                 78,
+		"getuid":                       24,
+		"getuid32":                     199,
+		"getxattr":                     229,
+		"getxattrat":                   464,
+		"gtty":                         32,
+		"idle":                         112,
+		"init_module":                  128,
+		"inotify_add_watch":            292,
+		"inotify_init":                 291,
+		"inotify_init1":                332,
+		"inotify_rm_watch":             293,
+		"io_cancel":                    249,
+		"io_destroy":                   246,
+		"io_getevents":                 247,
+		"io_pgetevents":                385,
+		"io_pgetevents_time64":         416,
+		"io_setup":                     245,
+		"io_submit":                    248,
+		"io_uring_enter":               426,
+		"io_uring_register":            427,
+		"io_uring_setup":               425,
+		"ioctl":                        54,
+		"ioperm":                       101,
+		"iopl":                         110,
+		"ioprio_get":                   290,
+		"ioprio_set":                   289,
+		"ipc":                          117,
+		"kcmp":                         349,
+		"kexec_load":                   283,
+		"keyctl":                       288,
+		"kill":                         37,
+		"landlock_add_rule":            445,
+		"landlock_create_ruleset":      444,
+		"landlock_restrict_self":       446,
+		"lchown":                       16,
+		"lchown32":                     198,
+		"lgetxattr":                    230,
+		"link":                         9,
+		"linkat":                       303,
+		"listen":                       363,
+		"listmount":                    458,
+		"listns":                       470,
+		"listxattr":                    232,
+		"listxattrat":                  465,
+		"llistxattr":                   233,
+		"lock":                         53,
+		"lookup_dcookie":               253,
+		"lremovexattr":                 236,
+		"lseek":                        19,
+		"lsetxattr":                    227,
+		"lsm_get_self_attr":            459,
+		"lsm_list_modules":             461,
+		"lsm_set_self_attr":            460,
+		"lstat":                        107,
+		"lstat64":                      196,
+		"madvise":                      219,
+		"map_shadow_stack":             453,
+		"mbind":                        274,
+		"membarrier":                   375,
+		"memfd_create":                 356,
+		"memfd_secret":                 447,
+		"migrate_pages":                294,
+		"mincore":                      218,
+		"mkdir":                        39,
+		"mkdirat":                      296,
+		"mknod":                        14,
+		"mknodat":                      297,
+		"mlock":                        150,
+		"mlock2":                       376,
+		"mlockall":                     152,
+		"mmap":                         90,
+		"mmap2":                        192,
+		"modify_ldt":                   123,
+		"mount":                        21,
+		"mount_setattr":                442,
+		"move_mount":                   429,
+		"move_pages":                   317,
+		"mprotect":                     125,
+		"mpx":                          56,
+		"mq_getsetattr":                282,
+		"mq_notify":                    281,
+		"mq_open":                      277,
+		"mq_timedreceive":              280,
+		"mq_timedreceive_time64":       419,
+		"mq_timedsend":                 279,
+		"mq_timedsend_time64":          418,
+		"mq_unlink":                    278,
+		"mremap":                       163,
+		"mseal":                        462,
+		"msgctl":                       402,
+		"msgget":                       399,
+		"msgrcv":                       401,
+		"msgsnd":                       400,
+		"msync":                        144,
+		"munlock":                      151,
+		"munlockall":                   153,
+		"munmap":                       91,
+		"name_to_handle_at":            341,
+		"nanosleep":                    162,
+		"nfsservctl":                   169,
+		"nice":                         34,
+		"oldfstat":                     28,
+		"oldlstat":                     84,
+		"oldolduname":                  59,
+		"oldstat":                      18,
+		"olduname":                     109,
+		"open":                         5,
+		"open_by_handle_at":            342,
+		"open_tree":                    428,
+		"open_tree_attr":               467,
+		"openat":                       295,
+		"openat2":                      437,
+		"pause":                        29,
+		"perf_event_open":              336,
+		"personality":                  136,
+		"pidfd_getfd":                  438,
+		"pidfd_open":                   434,
+		"pidfd_send_signal":            424,
+		"pipe":                         42,
+		"pipe2":                        331,
+		"pivot_root":                   217,
+		"pkey_alloc":                   381,
+		"pkey_free":                    382,
+		"pkey_mprotect":                380,
+		"poll":                         168,
+		"ppoll":                        309,
+		"ppoll_time64":                 414,
+		"prctl":                        172,
+		"pread64":                      180,
+		"preadv":                       333,
+		"preadv2":                      378,
+		"prlimit64":                    340,
+		"process_madvise":              440,
+		"process_mrelease":             448,
+		"process_vm_readv":             347,
+		"process_vm_writev":            348,
+		"prof":                         44,
+		"profil":                       98,
+		"pselect6":                     308,
+		"pselect6_time64":              413,
+		"ptrace":                       26,
+		"putpmsg":                      189,
+		"pwrite64":                     181,
+		"pwritev":                      334,
+		"pwritev2":                     379,
+		"query_module":                 167,
+		"quotactl":                     131,
+		"quotactl_fd":                  443,
+		"read":                         3,
+		"readahead":                    225,
+		"readdir":                      89,
+		"readlink":                     85,
+		"readlinkat":                   305,
+		"readv":                        145,
+		"reboot":                       88,
+		"recvfrom":                     371,
+		"recvmmsg":                     337,
+		"recvmmsg_time64":              417,
+		"recvmsg":                      372,
+		"remap_file_pages":             257,
+		"removexattr":                  235,
+		"removexattrat":                466,
+		"rename":                       38,
+		"renameat":                     302,
+		"renameat2":                    353,
+		"request_key":                  287,
+		"restart_syscall":              0,
+		"rmdir":                        40,
+		"rseq":                         386,
+		"rseq_slice_yield":             471,
+		"rt_sigaction":                 174,
+		"rt_sigpending":                176,
+		"rt_sigprocmask":               175,
+		"rt_sigqueueinfo":              178,
+		"rt_sigreturn":                 173,
+		"rt_sigsuspend":                179,
+		"rt_sigtimedwait":              177,
+		"rt_sigtimedwait_time64":       421,
+		"rt_tgsigqueueinfo":            335,
+		"sched_get_priority_max":       159,
+		"sched_get_priority_min":       160,
+		"sched_getaffinity":            242,
+		"sched_getattr":                352,
+		"sched_getparam":               155,
+		"sched_getscheduler":           157,
+		"sched_rr_get_interval":        161,
+		"sched_rr_get_interval_time64": 423,
+		"sched_setaffinity":            241,
+		"sched_setattr":                351,
+		"sched_setparam":               154,
+		"sched_setscheduler":           156,
+		"sched_yield":                  158,
+		"seccomp":                      354,
+		"select":                       82,
+		"semctl":                       394,
+		"semget":                       393,
+		"semtimedop_time64":            420,
+		"sendfile":                     187,
+		"sendfile64":                   239,
+		"sendmmsg":                     345,
+		"sendmsg":                      370,
+		"sendto":                       369,
+		"set_mempolicy":                276,
+		"set_mempolicy_home_node":      450,
+		"set_robust_list":              311,
+		"set_thread_area":              243,
+		"set_tid_address":              258,
+		"setdomainname":                121,
+		"setfsgid":                     139,
+		"setfsgid32":                   216,
+		"setfsuid":                     138,
+		"setfsuid32":                   215,
+		"setgid":                       46,
+		"setgid32":                     214,
+		"setgroups":                    81,
+		"setgroups32":                  206,
+		"sethostname":                  74,
+		"setitimer":                    104,
+		"setns":                        346,
+		"setpgid":                      57,
+		"setpriority":                  97,
+		"setregid":                     71,
+		"setregid32":                   204,
+		"setresgid":                    170,
+		"setresgid32":                  210,
+		"setresuid":                    164,
+		"setresuid32":                  208,
+		"setreuid":                     70,
+		"setreuid32":                   203,
+		"setrlimit":                    75,
+		"setsid":                       66,
+		"setsockopt":                   366,
+		"settimeofday":                 79,
+		"setuid":                       23,
+		"setuid32":                     213,
+		"setxattr":                     226,
+		"setxattrat":                   463,
+		"sgetmask":                     68,
+		"shmat":                        397,
+		"shmctl":                       396,
+		"shmdt":                        398,
+		"shmget":                       395,
+		"shutdown":                     373,
+		"sigaction":                    67,
+		"sigaltstack":                  186,
+		"signal":                       48,
+		"signalfd":                     321,
+		"signalfd4":                    327,
+		"sigpending":                   73,
+		"sigprocmask":                  126,
+		"sigreturn":                    119,
+		"sigsuspend":                   72,
+		"socket":                       359,
+		"socketcall":                   102,
+		"socketpair":                   360,
+		"splice":                       313,
+		"ssetmask":                     69,
+		"stat":                         106,
+		"stat64":                       195,
+		"statfs":                       99,
+		"statfs64":                     268,
+		"statmount":                    457,
+		"statx":                        383,
+		"stime":                        25,
+		"stty":                         31,
+		"swapoff":                      115,
+		"swapon":                       87,
+		"symlink":                      83,
+		"symlinkat":                    304,
+		"sync":                         36,
+		"sync_file_range":              314,
+		"syncfs":                       344,
+		"sysfs":                        135,
+		"sysinfo":                      116,
+		"syslog":                       103,
+		"tee":                          315,
+		"tgkill":                       270,
+		"time":                         13,
+		"timer_create":                 259,
+		"timer_delete":                 263,
+		"timer_getoverrun":             262,
+		"timer_gettime":                261,
+		"timer_gettime64":              408,
+		"timer_settime":                260,
+		"timer_settime64":              409,
+		"timerfd_create":               322,
+		"timerfd_gettime":              326,
+		"timerfd_gettime64":            410,
+		"timerfd_settime":              325,
+		"timerfd_settime64":            411,
+		"times":                        43,
+		"tkill":                        238,
+		"truncate":                     92,
+		"truncate64":                   193,
+		"ugetrlimit":                   191,
+		"ulimit":                       58,
+		"umask":                        60,
+		"umount":                       22,
+		"umount2":                      52,
+		"uname":                        122,
+		"unlink":                       10,
+		"unlinkat":                     301,
+		"unshare":                      310,
+		"uselib":                       86,
+		"userfaultfd":                  374,
+		"ustat":                        62,
+		"utime":                        30,
+		"utimensat":                    320,
+		"utimensat_time64":             412,
+		"utimes":                       271,
+		"vfork":                        190,
+		"vhangup":                      111,
+		"vm86":                         166,
+		"vm86old":                      113,
+		"vmsplice":                     316,
+		"vserver":                      273,
+		"wait4":                        114,
+		"waitid":                       284,
+		"waitpid":                      7,
+		"write":                        4,
+		"writev":                       146,
+	},
+	"amd64": {
+		"_sysctl":                 156,
+		"accept":                  43,
+		"accept4":                 288,
+		"access":                  21,
+		"acct":                    163,
+		"add_key":                 248,
+		"adjtimex":                159,
+		"afs_syscall":             183,
+		"alarm":                   37,
+		"arch_prctl":              158,
+		"bind":                    49,
+		"bpf":                     321,
+		"brk":                     12,
+		"cachestat":               451,
+		"capget":                  125,
+		"capset":                  126,
+		"chdir":                   80,
+		"chmod":                   90,
+		"chown":                   92,
+		"chroot":                  161,
+		"clock_adjtime":           305,
+		"clock_getres":            229,
+		"clock_gettime":           228,
+		"clock_nanosleep":         230,
+		"clock_settime":           227,
+		"clone":                   56,
+		"clone3":                  435,
+		"close":                   3,
+		"close_range":             436,
+		"connect":                 42,
+		"copy_file_range":         326,
+		"creat":                   85,
+		"create_module":           174,
+		"delete_module":           176,
+		"dup":                     32,
+		"dup2":                    33,
+		"dup3":                    292,
+		"epoll_create":            213,
+		"epoll_create1":           291,
+		"epoll_ctl":               233,
+		"epoll_ctl_old":           214,
+		"epoll_pwait":             281,
+		"epoll_pwait2":            441,
+		"epoll_wait":              232,
+		"epoll_wait_old":          215,
+		"eventfd":                 284,
+		"eventfd2":                290,
+		"execve":                  59,
+		"execveat":                322,
+		"exit":                    60,
+		"exit_group":              231,
+		"faccessat":               269,
+		"faccessat2":              439,
+		"fadvise64":               221,
+		"fallocate":               285,
+		"fanotify_init":           300,
+		"fanotify_mark":           301,
+		"fchdir":                  81,
+		"fchmod":                  91,
+		"fchmodat":                268,
+		"fchmodat2":               452,
+		"fchown":                  93,
+		"fchownat":                260,
+		"fcntl":                   72,
+		"fdatasync":               75,
+		"fgetxattr":               193,
+		"file_getattr":            468,
+		"file_setattr":            469,
+		"finit_module":            313,
+		"flistxattr":              196,
+		"flock":                   73,
+		"fork":                    57,
+		"fremovexattr":            199,
+		"fsconfig":                431,
+		"fsetxattr":               190,
+		"fsmount":                 432,
+		"fsopen":                  430,
+		"fspick":                  433,
+		"fstat":                   5,
+		"fstatfs":                 138,
+		"fsync":                   74,
+		"ftruncate":               77,
+		"futex":                   202,
+		"futex_requeue":           456,
+		"futex_wait":              455,
+		"futex_waitv":             449,
+		"futex_wake":              454,
+		"futimesat":               261,
+		"get_kernel_syms":         177,
+		"get_mempolicy":           239,
+		"get_robust_list":         274,
+		"get_thread_area":         211,
+		"getcpu":                  309,
+		"getcwd":                  79,
+		"getdents":                78,
+		"getdents64":              217,
+		"getegid":                 108,
+		"geteuid":                 107,
+		"getgid":                  104,
+		"getgroups":               115,
+		"getitimer":               36,
+		"getpeername":             52,
+		"getpgid":                 121,
+		"getpgrp":                 111,
+		"getpid":                  39,
+		"getpmsg":                 181,
+		"getppid":                 110,
+		"getpriority":             140,
+		"getrandom":               318,
+		"getresgid":               120,
+		"getresuid":               118,
+		"getrlimit":               97,
+		"getrusage":               98,
+		"getsid":                  124,
+		"getsockname":             51,
+		"getsockopt":              55,
+		"gettid":                  186,
+		"gettimeofday":            96,
+		"getuid":                  102,
+		"getxattr":                191,
+		"getxattrat":              464,
+		"init_module":             175,
+		"inotify_add_watch":       254,
+		"inotify_init":            253,
+		"inotify_init1":           294,
+		"inotify_rm_watch":        255,
+		"io_cancel":               210,
+		"io_destroy":              207,
+		"io_getevents":            208,
+		"io_pgetevents":           333,
+		"io_setup":                206,
+		"io_submit":               209,
+		"io_uring_enter":          426,
+		"io_uring_register":       427,
+		"io_uring_setup":          425,
+		"ioctl":                   16,
+		"ioperm":                  173,
+		"iopl":                    172,
+		"ioprio_get":              252,
+		"ioprio_set":              251,
+		"kcmp":                    312,
+		"kexec_file_load":         320,
+		"kexec_load":              246,
+		"keyctl":                  250,
+		"kill":                    62,
+		"landlock_add_rule":       445,
+		"landlock_create_ruleset": 444,
+		"landlock_restrict_self":  446,
+		"lchown":                  94,
+		"lgetxattr":               192,
+		"link":                    86,
+		"linkat":                  265,
+		"listen":                  50,
+		"listmount":               458,
+		"listns":                  470,
+		"listxattr":               194,
+		"listxattrat":             465,
+		"llistxattr":              195,
+		"lookup_dcookie":          212,
+		"lremovexattr":            198,
+		"lseek":                   8,
+		"lsetxattr":               189,
+		"lsm_get_self_attr":       459,
+		"lsm_list_modules":        461,
+		"lsm_set_self_attr":       460,
+		"lstat":                   6,
+		"madvise":                 28,
+		"map_shadow_stack":        453,
+		"mbind":                   237,
+		"membarrier":              324,
+		"memfd_create":            319,
+		"memfd_secret":            447,
+		"migrate_pages":           256,
+		"mincore":                 27,
+		"mkdir":                   83,
+		"mkdirat":                 258,
+		"mknod":                   133,
+		"mknodat":                 259,
+		"mlock":                   149,
+		"mlock2":                  325,
+		"mlockall":                151,
+		"mmap":                    9,
+		"modify_ldt":              154,
+		"mount":                   165,
+		"mount_setattr":           442,
+		"move_mount":              429,
+		"move_pages":              279,
+		"mprotect":                10,
+		"mq_getsetattr":           245,
+		"mq_notify":               244,
+		"mq_open":                 240,
+		"mq_timedreceive":         243,
+		"mq_timedsend":            242,
+		"mq_unlink":               241,
+		"mremap":                  25,
+		"mseal":                   462,
+		"msgctl":                  71,
+		"msgget":                  68,
+		"msgrcv":                  70,
+		"msgsnd":                  69,
+		"msync":                   26,
+		"munlock":                 150,
+		"munlockall":              152,
+		"munmap":                  11,
+		"name_to_handle_at":       303,
+		"nanosleep":               35,
+		"newfstatat":              262,
+		"nfsservctl":              180,
+		"open":                    2,
+		"open_by_handle_at":       304,
+		"open_tree":               428,
+		"open_tree_attr":          467,
+		"openat":                  257,
+		"openat2":                 437,
+		"pause":                   34,
+		"perf_event_open":         298,
+		"personality":             135,
+		"pidfd_getfd":             438,
+		"pidfd_open":              434,
+		"pidfd_send_signal":       424,
+		"pipe":                    22,
+		"pipe2":                   293,
+		"pivot_root":              155,
+		"pkey_alloc":              330,
+		"pkey_free":               331,
+		"pkey_mprotect":           329,
+		"poll":                    7,
+		"ppoll":                   271,
+		"prctl":                   157,
+		"pread64":                 17,
+		"preadv":                  295,
+		"preadv2":                 327,
+		"prlimit64":               302,
+		"process_madvise":         440,
+		"process_mrelease":        448,
+		"process_vm_readv":        310,
+		"process_vm_writev":       311,
+		"pselect6":                270,
+		"ptrace":                  101,
+		"putpmsg":                 182,
+		"pwrite64":                18,
+		"pwritev":                 296,
+		"pwritev2":                328,
+		"query_module":            178,
+		"quotactl":                179,
+		"quotactl_fd":             443,
+		"read":                    0,
+		"readahead":               187,
+		"readlink":                89,
+		"readlinkat":              267,
+		"readv":                   19,
+		"reboot":                  169,
+		"recvfrom":                45,
+		"recvmmsg":                299,
+		"recvmsg":                 47,
+		"remap_file_pages":        216,
+		"removexattr":             197,
+		"removexattrat":           466,
+		"rename":                  82,
+		"renameat":                264,
+		"renameat2":               316,
+		"request_key":             249,
+		"restart_syscall":         219,
+		"rmdir":                   84,
+		"rseq":                    334,
+		"rseq_slice_yield":        471,
+		"rt_sigaction":            13,
+		"rt_sigpending":           127,
+		"rt_sigprocmask":          14,
+		"rt_sigqueueinfo":         129,
+		"rt_sigreturn":            15,
+		"rt_sigsuspend":           130,
+		"rt_sigtimedwait":         128,
+		"rt_tgsigqueueinfo":       297,
+		"sched_get_priority_max":  146,
+		"sched_get_priority_min":  147,
+		"sched_getaffinity":       204,
+		"sched_getattr":           315,
+		"sched_getparam":          143,
+		"sched_getscheduler":      145,
+		"sched_rr_get_interval":   148,
+		"sched_setaffinity":       203,
+		"sched_setattr":           314,
+		"sched_setparam":          142,
+		"sched_setscheduler":      144,
+		"sched_yield":             24,
+		"seccomp":                 317,
+		"security":                185,
+		"select":                  23,
+		"semctl":                  66,
+		"semget":                  64,
+		"semop":                   65,
+		"semtimedop":              220,
+		"sendfile":                40,
+		"sendmmsg":                307,
+		"sendmsg":                 46,
+		"sendto":                  44,
+		"set_mempolicy":           238,
+		"set_mempolicy_home_node": 450,
+		"set_robust_list":         273,
+		"set_thread_area":         205,
+		"set_tid_address":         218,
+		"setdomainname":           171,
+		"setfsgid":                123,
+		"setfsuid":                122,
+		"setgid":                  106,
+		"setgroups":               116,
+		"sethostname":             170,
+		"setitimer":               38,
+		"setns":                   308,
+		"setpgid":                 109,
+		"setpriority":             141,
+		"setregid":                114,
+		"setresgid":               119,
+		"setresuid":               117,
+		"setreuid":                113,
+		"setrlimit":               160,
+		"setsid":                  112,
+		"setsockopt":              54,
+		"settimeofday":            164,
+		"setuid":                  105,
+		"setxattr":                188,
+		"setxattrat":              463,
+		"shmat":                   30,
+		"shmctl":                  31,
+		"shmdt":                   67,
+		"shmget":                  29,
+		"shutdown":                48,
+		"sigaltstack":             131,
+		"signalfd":                282,
+		"signalfd4":               289,
+		"socket":                  41,
+		"socketpair":              53,
+		"splice":                  275,
+		"stat":                    4,
+		"statfs":                  137,
+		"statmount":               457,
+		"statx":                   332,
+		"swapoff":                 168,
+		"swapon":                  167,
+		"symlink":                 88,
+		"symlinkat":               266,
+		"sync":                    162,
+		"sync_file_range":         277,
+		"syncfs":                  306,
+		"sysfs":                   139,
+		"sysinfo":                 99,
+		"syslog":                  103,
+		"tee":                     276,
+		"tgkill":                  234,
+		"time":                    201,
+		"timer_create":            222,
+		"timer_delete":            226,
+		"timer_getoverrun":        225,
+		"timer_gettime":           224,
+		"timer_settime":           223,
+		"timerfd_create":          283,
+		"timerfd_gettime":         287,
+		"timerfd_settime":         286,
+		"times":                   100,
+		"tkill":                   200,
+		"truncate":                76,
+		"tuxcall":                 184,
+		"umask":                   95,
+		"umount2":                 166,
+		"uname":                   63,
+		"unlink":                  87,
+		"unlinkat":                263,
+		"unshare":                 272,
+		"uprobe":                  336,
+		"uretprobe":               335,
+		"uselib":                  134,
+		"userfaultfd":             323,
+		"ustat":                   136,
+		"utime":                   132,
+		"utimensat":               280,
+		"utimes":                  235,
+		"vfork":                   58,
+		"vhangup":                 153,
+		"vmsplice":                278,
+		"vserver":                 236,
+		"wait4":                   61,
+		"waitid":                  247,
+		"write":                   1,
+		"writev":                  20,
+	},
+	"x32": {
+		"_sysctl":                 0x4000009c,
+		"accept":                  0x4000002b,
+		"accept4":                 0x40000120,
+		"access":                  0x40000015,
+		"acct":                    0x400000a3,
+		"add_key":                 0x400000f8,
+		"adjtimex":                0x4000009f,
+		"afs_syscall":             0x400000b7,
+		"alarm":                   0x40000025,
+		"arch_prctl":              0x4000009e,
+		"bind":                    0x40000031,
+		"bpf":                     0x40000141,
+		"brk":                     0x4000000c,
+		"cachestat":               0x400001c3,
+		"capget":                  0x4000007d,
+		"capset":                  0x4000007e,
+		"chdir":                   0x40000050,
+		"chmod":                   0x4000005a,
+		"chown":                   0x4000005c,
+		"chroot":                  0x400000a1,
+		"clock_adjtime":           0x40000131,
+		"clock_getres":            0x400000e5,
+		"clock_gettime":           0x400000e4,
+		"clock_nanosleep":         0x400000e6,
+		"clock_settime":           0x400000e3,
+		"clone":                   0x40000038,
+		"clone3":                  0x400001b3,
+		"close":                   0x40000003,
+		"close_range":             0x400001b4,
+		"connect":                 0x4000002a,
+		"copy_file_range":         0x40000146,
+		"creat":                   0x40000055,
+		"create_module":           0x400000ae,
+		"delete_module":           0x400000b0,
+		"dup":                     0x40000020,
+		"dup2":                    0x40000021,
+		"dup3":                    0x40000124,
+		"epoll_create":            0x400000d5,
+		"epoll_create1":           0x40000123,
+		"epoll_ctl":               0x400000e9,
+		"epoll_ctl_old":           0x400000d6,
+		"epoll_pwait":             0x40000119,
+		"epoll_pwait2":            0x400001b9,
+		"epoll_wait":              0x400000e8,
+		"epoll_wait_old":          0x400000d7,
+		"eventfd":                 0x4000011c,
+		"eventfd2":                0x40000122,
+		"execve":                  0x40000208,
+		"execveat":                0x40000221,
+		"exit":                    0x4000003c,
+		"exit_group":              0x400000e7,
+		"faccessat":               0x4000010d,
+		"faccessat2":              0x400001b7,
+		"fadvise64":               0x400000dd,
+		"fallocate":               0x4000011d,
+		"fanotify_init":           0x4000012c,
+		"fanotify_mark":           0x4000012d,
+		"fchdir":                  0x40000051,
+		"fchmod":                  0x4000005b,
+		"fchmodat":                0x4000010c,
+		"fchmodat2":               0x400001c4,
+		"fchown":                  0x4000005d,
+		"fchownat":                0x40000104,
+		"fcntl":                   0x40000048,
+		"fdatasync":               0x4000004b,
+		"fgetxattr":               0x400000c1,
+		"file_getattr":            0x400001d4,
+		"file_setattr":            0x400001d5,
+		"finit_module":            0x40000139,
+		"flistxattr":              0x400000c4,
+		"flock":                   0x40000049,
+		"fork":                    0x40000039,
+		"fremovexattr":            0x400000c7,
+		"fsconfig":                0x400001af,
+		"fsetxattr":               0x400000be,
+		"fsmount":                 0x400001b0,
+		"fsopen":                  0x400001ae,
+		"fspick":                  0x400001b1,
+		"fstat":                   0x40000005,
+		"fstatfs":                 0x4000008a,
+		"fsync":                   0x4000004a,
+		"ftruncate":               0x4000004d,
+		"futex":                   0x400000ca,
+		"futex_requeue":           0x400001c8,
+		"futex_wait":              0x400001c7,
+		"futex_waitv":             0x400001c1,
+		"futex_wake":              0x400001c6,
+		"futimesat":               0x40000105,
+		"get_kernel_syms":         0x400000b1,
+		"get_mempolicy":           0x400000ef,
+		"get_robust_list":         0x40000213,
+		"get_thread_area":         0x400000d3,
+		"getcpu":                  0x40000135,
+		"getcwd":                  0x4000004f,
+		"getdents":                0x4000004e,
+		"getdents64":              0x400000d9,
+		"getegid":                 0x4000006c,
+		"geteuid":                 0x4000006b,
+		"getgid":                  0x40000068,
+		"getgroups":               0x40000073,
+		"getitimer":               0x40000024,
+		"getpeername":             0x40000034,
+		"getpgid":                 0x40000079,
+		"getpgrp":                 0x4000006f,
+		"getpid":                  0x40000027,
+		"getpmsg":                 0x400000b5,
+		"getppid":                 0x4000006e,
+		"getpriority":             0x4000008c,
+		"getrandom":               0x4000013e,
+		"getresgid":               0x40000078,
+		"getresuid":               0x40000076,
+		"getrlimit":               0x40000061,
+		"getrusage":               0x40000062,
+		"getsid":                  0x4000007c,
+		"getsockname":             0x40000033,
+		"getsockopt":              0x4000021e,
+		"gettid":                  0x400000ba,
+		"gettimeofday":            0x40000060,
+		"getuid":                  0x40000066,
+		"getxattr":                0x400000bf,
+		"getxattrat":              0x400001d0,
+		"init_module":             0x400000af,
+		"inotify_add_watch":       0x400000fe,
+		"inotify_init":            0x400000fd,
+		"inotify_init1":           0x40000126,
+		"inotify_rm_watch":        0x400000ff,
+		"io_cancel":               0x400000d2,
+		"io_destroy":              0x400000cf,
+		"io_getevents":            0x400000d0,
+		"io_pgetevents":           0x4000014d,
+		"io_setup":                0x4000021f,
+		"io_submit":               0x40000220,
+		"io_uring_enter":          0x400001aa,
+		"io_uring_register":       0x400001ab,
+		"io_uring_setup":          0x400001a9,
+		"ioctl":                   0x40000202,
+		"ioperm":                  0x400000ad,
+		"iopl":                    0x400000ac,
+		"ioprio_get":              0x400000fc,
+		"ioprio_set":              0x400000fb,
+		"kcmp":                    0x40000138,
+		"kexec_file_load":         0x40000140,
+		"kexec_load":              0x40000210,
+		"keyctl":                  0x400000fa,
+		"kill":                    0x4000003e,
+		"landlock_add_rule":       0x400001bd,
+		"landlock_create_ruleset": 0x400001bc,
+		"landlock_restrict_self":  0x400001be,
+		"lchown":                  0x4000005e,
+		"lgetxattr":               0x400000c0,
+		"link":                    0x40000056,
+		"linkat":                  0x40000109,
+		"listen":                  0x40000032,
+		"listmount":               0x400001ca,
+		"listns":                  0x400001d6,
+		"listxattr":               0x400000c2,
+		"listxattrat":             0x400001d1,
+		"llistxattr":              0x400000c3,
+		"lookup_dcookie":          0x400000d4,
+		"lremovexattr":            0x400000c6,
+		"lseek":                   0x40000008,
+		"lsetxattr":               0x400000bd,
+		"lsm_get_self_attr":       0x400001cb,
+		"lsm_list_modules":        0x400001cd,
+		"lsm_set_self_attr":       0x400001cc,
+		"lstat":                   0x40000006,
+		"madvise":                 0x4000001c,
+		"map_shadow_stack":        0x400001c5,
+		"mbind":                   0x400000ed,
+		"membarrier":              0x40000144,
+		"memfd_create":            0x4000013f,
+		"memfd_secret":            0x400001bf,
+		"migrate_pages":           0x40000100,
+		"mincore":                 0x4000001b,
+		"mkdir":                   0x40000053,
+		"mkdirat":                 0x40000102,
+		"mknod":                   0x40000085,
+		"mknodat":                 0x40000103,
+		"mlock":                   0x40000095,
+		"mlock2":                  0x40000145,
+		"mlockall":                0x40000097,
+		"mmap":                    0x40000009,
+		"modify_ldt":              0x4000009a,
+		"mount":                   0x400000a5,
+		"mount_setattr":           0x400001ba,
+		"move_mount":              0x400001ad,
+		"move_pages":              0x40000215,
+		"mprotect":                0x4000000a,
+		"mq_getsetattr":           0x400000f5,
+		"mq_notify":               0x4000020f,
+		"mq_open":                 0x400000f0,
+		"mq_timedreceive":         0x400000f3,
+		"mq_timedsend":            0x400000f2,
+		"mq_unlink":               0x400000f1,
+		"mremap":                  0x40000019,
+		"mseal":                   0x400001ce,
+		"msgctl":                  0x40000047,
+		"msgget":                  0x40000044,
+		"msgrcv":                  0x40000046,
+		"msgsnd":                  0x40000045,
+		"msync":                   0x4000001a,
+		"munlock":                 0x40000096,
+		"munlockall":              0x40000098,
+		"munmap":                  0x4000000b,
+		"name_to_handle_at":       0x4000012f,
+		"nanosleep":               0x40000023,
+		"newfstatat":              0x40000106,
+		"nfsservctl":              0x400000b4,
+		"open":                    0x40000002,
+		"open_by_handle_at":       0x40000130,
+		"open_tree":               0x400001ac,
+		"open_tree_attr":          0x400001d3,
+		"openat":                  0x40000101,
+		"openat2":                 0x400001b5,
+		"pause":                   0x40000022,
+		"perf_event_open":         0x4000012a,
+		"personality":             0x40000087,
+		"pidfd_getfd":             0x400001b6,
+		"pidfd_open":              0x400001b2,
+		"pidfd_send_signal":       0x400001a8,
+		"pipe":                    0x40000016,
+		"pipe2":                   0x40000125,
+		"pivot_root":              0x4000009b,
+		"pkey_alloc":              0x4000014a,
+		"pkey_free":               0x4000014b,
+		"pkey_mprotect":           0x40000149,
+		"poll":                    0x40000007,
+		"ppoll":                   0x4000010f,
+		"prctl":                   0x4000009d,
+		"pread64":                 0x40000011,
+		"preadv":                  0x40000216,
+		"preadv2":                 0x40000222,
+		"prlimit64":               0x4000012e,
+		"process_madvise":         0x400001b8,
+		"process_mrelease":        0x400001c0,
+		"process_vm_readv":        0x4000021b,
+		"process_vm_writev":       0x4000021c,
+		"pselect6":                0x4000010e,
+		"ptrace":                  0x40000209,
+		"putpmsg":                 0x400000b6,
+		"pwrite64":                0x40000012,
+		"pwritev":                 0x40000217,
+		"pwritev2":                0x40000223,
+		"query_module":            0x400000b2,
+		"quotactl":                0x400000b3,
+		"quotactl_fd":             0x400001bb,
+		"read":                    0x40000000,
+		"readahead":               0x400000bb,
+		"readlink":                0x40000059,
+		"readlinkat":              0x4000010b,
+		"readv":                   0x40000203,
+		"reboot":                  0x400000a9,
+		"recvfrom":                0x40000205,
+		"recvmmsg":                0x40000219,
+		"recvmsg":                 0x40000207,
+		"remap_file_pages":        0x400000d8,
+		"removexattr":             0x400000c5,
+		"removexattrat":           0x400001d2,
+		"rename":                  0x40000052,
+		"renameat":                0x40000108,
+		"renameat2":               0x4000013c,
+		"request_key":             0x400000f9,
+		"restart_syscall":         0x400000db,
+		"rmdir":                   0x40000054,
+		"rseq":                    0x4000014e,
+		"rseq_slice_yield":        0x400001d7,
+		"rt_sigaction":            0x40000200,
+		"rt_sigpending":           0x4000020a,
+		"rt_sigprocmask":          0x4000000e,
+		"rt_sigqueueinfo":         0x4000020c,
+		"rt_sigreturn":            0x40000201,
+		"rt_sigsuspend":           0x40000082,
+		"rt_sigtimedwait":         0x4000020b,
+		"rt_tgsigqueueinfo":       0x40000218,
+		"sched_get_priority_max":  0x40000092,
+		"sched_get_priority_min":  0x40000093,
+		"sched_getaffinity":       0x400000cc,
+		"sched_getattr":           0x4000013b,
+		"sched_getparam":          0x4000008f,
+		"sched_getscheduler":      0x40000091,
+		"sched_rr_get_interval":   0x40000094,
+		"sched_setaffinity":       0x400000cb,
+		"sched_setattr":           0x4000013a,
+		"sched_setparam":          0x4000008e,
+		"sched_setscheduler":      0x40000090,
+		"sched_yield":             0x40000018,
+		"seccomp":                 0x4000013d,
+		"security":                0x400000b9,
+		"select":                  0x40000017,
+		"semctl":                  0x40000042,
+		"semget":                  0x40000040,
+		"semop":                   0x40000041,
+		"semtimedop":              0x400000dc,
+		"sendfile":                0x40000028,
+		"sendmmsg":                0x4000021a,
+		"sendmsg":                 0x40000206,
+		"sendto":                  0x4000002c,
+		"set_mempolicy":           0x400000ee,
+		"set_mempolicy_home_node": 0x400001c2,
+		"set_robust_list":         0x40000212,
+		"set_thread_area":         0x400000cd,
+		"set_tid_address":         0x400000da,
+		"setdomainname":           0x400000ab,
+		"setfsgid":                0x4000007b,
+		"setfsuid":                0x4000007a,
+		"setgid":                  0x4000006a,
+		"setgroups":               0x40000074,
+		"sethostname":             0x400000aa,
+		"setitimer":               0x40000026,
+		"setns":                   0x40000134,
+		"setpgid":                 0x4000006d,
+		"setpriority":             0x4000008d,
+		"setregid":                0x40000072,
+		"setresgid":               0x40000077,
+		"setresuid":               0x40000075,
+		"setreuid":                0x40000071,
+		"setrlimit":               0x400000a0,
+		"setsid":                  0x40000070,
+		"setsockopt":              0x4000021d,
+		"settimeofday":            0x400000a4,
+		"setuid":                  0x40000069,
+		"setxattr":                0x400000bc,
+		"setxattrat":              0x400001cf,
+		"shmat":                   0x4000001e,
+		"shmctl":                  0x4000001f,
+		"shmdt":                   0x40000043,
+		"shmget":                  0x4000001d,
+		"shutdown":                0x40000030,
+		"sigaltstack":             0x4000020d,
+		"signalfd":                0x4000011a,
+		"signalfd4":               0x40000121,
+		"socket":                  0x40000029,
+		"socketpair":              0x40000035,
+		"splice":                  0x40000113,
+		"stat":                    0x40000004,
+		"statfs":                  0x40000089,
+		"statmount":               0x400001c9,
+		"statx":                   0x4000014c,
+		"swapoff":                 0x400000a8,
+		"swapon":                  0x400000a7,
+		"symlink":                 0x40000058,
+		"symlinkat":               0x4000010a,
+		"sync":                    0x400000a2,
+		"sync_file_range":         0x40000115,
+		"syncfs":                  0x40000132,
+		"sysfs":                   0x4000008b,
+		"sysinfo":                 0x40000063,
+		"syslog":                  0x40000067,
+		"tee":                     0x40000114,
+		"tgkill":                  0x400000ea,
+		"time":                    0x400000c9,
+		"timer_create":            0x4000020e,
+		"timer_delete":            0x400000e2,
+		"timer_getoverrun":        0x400000e1,
+		"timer_gettime":           0x400000e0,
+		"timer_settime":           0x400000df,
+		"timerfd_create":          0x4000011b,
+		"timerfd_gettime":         0x4000011f,
+		"timerfd_settime":         0x4000011e,
+		"times":                   0x40000064,
+		"tkill":                   0x400000c8,
+		"truncate":                0x4000004c,
+		"tuxcall":                 0x400000b8,
+		"umask":                   0x4000005f,
+		"umount2":                 0x400000a6,
+		"uname":                   0x4000003f,
+		"unlink":                  0x40000057,
+		"unlinkat":                0x40000107,
+		"unshare":                 0x40000110,
+		"uprobe":                  0x40000150,
+		"uretprobe":               0x4000014f,
+		"uselib":                  0x40000086,
+		"userfaultfd":             0x40000143,
+		"ustat":                   0x40000088,
+		"utime":                   0x40000084,
+		"utimensat":               0x40000118,
+		"utimes":                  0x400000eb,
+		"vfork":                   0x4000003a,
+		"vhangup":                 0x40000099,
+		"vmsplice":                0x40000214,
+		"vserver":                 0x400000ec,
+		"wait4":                   0x4000003d,
+		"waitid":                  0x40000211,
+		"write":                   0x40000001,
+		"writev":                  0x40000204,
+	},
+	"arm": {
+		"_llseek":                      140,
+		"_newselect":                   142,
+		"_sysctl":                      149,
+		"accept":                       285,
+		"accept4":                      366,
+		"access":                       33,
+		"acct":                         51,
+		"add_key":                      309,
+		"adjtimex":                     124,
+		"arm_fadvise64_64":             270,
+		"arm_sync_file_range":          341,
+		"bdflush":                      134,
+		"bind":                         282,
+		"bpf":                          386,
+		"brk":                          45,
+		"cachestat":                    451,
+		"capget":                       184,
+		"capset":                       185,
+		"chdir":                        12,
+		"chmod":                        15,
+		"chown":                        182,
+		"chown32":                      212,
+		"chroot":                       61,
+		"clock_adjtime":                372,
+		"clock_adjtime64":              405,
+		"clock_getres":                 264,
+		"clock_getres_time64":          406,
+		"clock_gettime":                263,
+		"clock_gettime64":              403,
+		"clock_nanosleep":              265,
+		"clock_nanosleep_time64":       407,
+		"clock_settime":                262,
+		"clock_settime64":              404,
+		"clone":                        120,
+		"clone3":                       435,
+		"close":                        6,
+		"close_range":                  436,
+		"connect":                      283,
+		"copy_file_range":              391,
+		"creat":                        8,
+		"delete_module":                129,
+		"dup":                          41,
+		"dup2":                         63,
+		"dup3":                         358,
+		"epoll_create":                 250,
+		"epoll_create1":                357,
+		"epoll_ctl":                    251,
+		"epoll_pwait":                  346,
+		"epoll_pwait2":                 441,
+		"epoll_wait":                   252,
+		"eventfd":                      351,
+		"eventfd2":                     356,
+		"execve":                       11,
+		"execveat":                     387,
+		"exit":                         1,
+		"exit_group":                   248,
+		"faccessat":                    334,
+		"faccessat2":                   439,
+		"fallocate":                    352,
+		"fanotify_init":                367,
+		"fanotify_mark":                368,
+		"fchdir":                       133,
+		"fchmod":                       94,
+		"fchmodat":                     333,
+		"fchmodat2":                    452,
+		"fchown":                       95,
+		"fchown32":                     207,
+		"fchownat":                     325,
+		"fcntl":                        55,
+		"fcntl64":                      221,
+		"fdatasync":                    148,
+		"fgetxattr":                    231,
+		"file_getattr":                 468,
+		"file_setattr":                 469,
+		"finit_module":                 379,
+		"flistxattr":                   234,
+		"flock":                        143,
+		"fork":                         2,
+		"fremovexattr":                 237,
+		"fsconfig":                     431,
+		"fsetxattr":                    228,
+		"fsmount":                      432,
+		"fsopen":                       430,
+		"fspick":                       433,
+		"fstat":                        108,
+		"fstat64":                      197,
+		"fstatat64":                    327,
+		"fstatfs":                      100,
+		"fstatfs64":                    267,
+		"fsync":                        118,
+		"ftruncate":                    93,
+		"ftruncate64":                  194,
+		"futex":                        240,
+		"futex_requeue":                456,
+		"futex_time64":                 422,
+		"futex_wait":                   455,
+		"futex_waitv":                  449,
+		"futex_wake":                   454,
+		"futimesat":                    326,
+		"get_mempolicy":                320,
+		"get_robust_list":              339,
+		"getcpu":                       345,
+		"getcwd":                       183,
+		"getdents":                     141,
+		"getdents64":                   217,
+		"getegid":                      50,
+		"getegid32":                    202,
+		"geteuid":                      49,
+		"geteuid32":                    201,
+		"getgid":                       47,
+		"getgid32":                     200,
+		"getgroups":                    80,
+		"getgroups32":                  205,
+		"getitimer":                    105,
+		"getpeername":                  287,
+		"getpgid":                      132,
+		"getpgrp":                      65,
+		"getpid":                       20,
+		"getppid":                      64,
+		"getpriority":                  96,
+		"getrandom":                    384,
+		"getresgid":                    171,
+		"getresgid32":                  211,
+		"getresuid":                    165,
+		"getresuid32":                  209,
+		"getrusage":                    77,
+		"getsid":                       147,
+		"getsockname":                  286,
+		"getsockopt":                   295,
+		"gettid":                       224,
+		"gettimeofday":                 78,
+		"getuid":                       24,
+		"getuid32":                     199,
+		"getxattr":                     229,
+		"getxattrat":                   464,
+		"init_module":                  128,
+		"inotify_add_watch":            317,
+		"inotify_init":                 316,
+		"inotify_init1":                360,
+		"inotify_rm_watch":             318,
+		"io_cancel":                    247,
+		"io_destroy":                   244,
+		"io_getevents":                 245,
+		"io_pgetevents":                399,
+		"io_pgetevents_time64":         416,
+		"io_setup":                     243,
+		"io_submit":                    246,
+		"io_uring_enter":               426,
+		"io_uring_register":            427,
+		"io_uring_setup":               425,
+		"ioctl":                        54,
+		"ioprio_get":                   315,
+		"ioprio_set":                   314,
+		"kcmp":                         378,
+		"kexec_file_load":              401,
+		"kexec_load":                   347,
+		"keyctl":                       311,
+		"kill":                         37,
+		"landlock_add_rule":            445,
+		"landlock_create_ruleset":      444,
+		"landlock_restrict_self":       446,
+		"lchown":                       16,
+		"lchown32":                     198,
+		"lgetxattr":                    230,
+		"link":                         9,
+		"linkat":                       330,
+		"listen":                       284,
+		"listmount":                    458,
+		"listns":                       470,
+		"listxattr":                    232,
+		"listxattrat":                  465,
+		"llistxattr":                   233,
+		"lookup_dcookie":               249,
+		"lremovexattr":                 236,
+		"lseek":                        19,
+		"lsetxattr":                    227,
+		"lsm_get_self_attr":            459,
+		"lsm_list_modules":             461,
+		"lsm_set_self_attr":            460,
+		"lstat":                        107,
+		"lstat64":                      196,
+		"madvise":                      220,
+		"map_shadow_stack":             453,
+		"mbind":                        319,
+		"membarrier":                   389,
+		"memfd_create":                 385,
+		"migrate_pages":                400,
+		"mincore":                      219,
+		"mkdir":                        39,
+		"mkdirat":                      323,
+		"mknod":                        14,
+		"mknodat":                      324,
+		"mlock":                        150,
+		"mlock2":                       390,
+		"mlockall":                     152,
+		"mmap2":                        192,
+		"mount":                        21,
+		"mount_setattr":                442,
+		"move_mount":                   429,
+		"move_pages":                   344,
+		"mprotect":                     125,
+		"mq_getsetattr":                279,
+		"mq_notify":                    278,
+		"mq_open":                      274,
+		"mq_timedreceive":              277,
+		"mq_timedreceive_time64":       419,
+		"mq_timedsend":                 276,
+		"mq_timedsend_time64":          418,
+		"mq_unlink":                    275,
+		"mremap":                       163,
+		"mseal":                        462,
+		"msgctl":                       304,
+		"msgget":                       303,
+		"msgrcv":                       302,
+		"msgsnd":                       301,
+		"msync":                        144,
+		"munlock":                      151,
+		"munlockall":                   153,
+		"munmap":                       91,
+		"name_to_handle_at":            370,
+		"nanosleep":                    162,
+		"nfsservctl":                   169,
+		"nice":                         34,
+		"open":                         5,
+		"open_by_handle_at":            371,
+		"open_tree":                    428,
+		"open_tree_attr":               467,
+		"openat":                       322,
+		"openat2":                      437,
+		"pause":                        29,
+		"pciconfig_iobase":             271,
+		"pciconfig_read":               272,
+		"pciconfig_write":              273,
+		"perf_event_open":              364,
+		"personality":                  136,
+		"pidfd_getfd":                  438,
+		"pidfd_open":                   434,
+		"pidfd_send_signal":            424,
+		"pipe":                         42,
+		"pipe2":                        359,
+		"pivot_root":                   218,
+		"pkey_alloc":                   395,
+		"pkey_free":                    396,
+		"pkey_mprotect":                394,
+		"poll":                         168,
+		"ppoll":                        336,
+		"ppoll_time64":                 414,
+		"prctl":                        172,
+		"pread64":                      180,
+		"preadv":                       361,
+		"preadv2":                      392,
+		"prlimit64":                    369,
+		"process_madvise":              440,
+		"process_mrelease":             448,
+		"process_vm_readv":             376,
+		"process_vm_writev":            377,
+		"pselect6":                     335,
+		"pselect6_time64":              413,
+		"ptrace":                       26,
+		"pwrite64":                     181,
+		"pwritev":                      362,
+		"pwritev2":                     393,
+		"quotactl":                     131,
+		"quotactl_fd":                  443,
+		"read":                         3,
+		"readahead":                    225,
+		"readlink":                     85,
+		"readlinkat":                   332,
+		"readv":                        145,
+		"reboot":                       88,
+		"recv":                         291,
+		"recvfrom":                     292,
+		"recvmmsg":                     365,
+		"recvmmsg_time64":              417,
+		"recvmsg":                      297,
+		"remap_file_pages":             253,
+		"removexattr":                  235,
+		"removexattrat":                466,
+		"rename":                       38,
+		"renameat":                     329,
+		"renameat2":                    382,
+		"request_key":                  310,
+		"restart_syscall":              0,
+		"rmdir":                        40,
+		"rseq":                         398,
+		"rseq_slice_yield":             471,
+		"rt_sigaction":                 174,
+		"rt_sigpending":                176,
+		"rt_sigprocmask":               175,
+		"rt_sigqueueinfo":              178,
+		"rt_sigreturn":                 173,
+		"rt_sigsuspend":                179,
+		"rt_sigtimedwait":              177,
+		"rt_sigtimedwait_time64":       421,
+		"rt_tgsigqueueinfo":            363,
+		"sched_get_priority_max":       159,
+		"sched_get_priority_min":       160,
+		"sched_getaffinity":            242,
+		"sched_getattr":                381,
+		"sched_getparam":               155,
+		"sched_getscheduler":           157,
+		"sched_rr_get_interval":        161,
+		"sched_rr_get_interval_time64": 423,
+		"sched_setaffinity":            241,
+		"sched_setattr":                380,
+		"sched_setparam":               154,
+		"sched_setscheduler":           156,
+		"sched_yield":                  158,
+		"seccomp":                      383,
+		"semctl":                       300,
+		"semget":                       299,
+		"semop":                        298,
+		"semtimedop":                   312,
+		"semtimedop_time64":            420,
+		"send":                         289,
+		"sendfile":                     187,
+		"sendfile64":                   239,
+		"sendmmsg":                     374,
+		"sendmsg":                      296,
+		"sendto":                       290,
+		"set_mempolicy":                321,
+		"set_mempolicy_home_node":      450,
+		"set_robust_list":              338,
+		"set_tid_address":              256,
+		"setdomainname":                121,
+		"setfsgid":                     139,
+		"setfsgid32":                   216,
+		"setfsuid":                     138,
+		"setfsuid32":                   215,
+		"setgid":                       46,
+		"setgid32":                     214,
+		"setgroups":                    81,
+		"setgroups32":                  206,
+		"sethostname":                  74,
+		"setitimer":                    104,
+		"setns":                        375,
+		"setpgid":                      57,
+		"setpriority":                  97,
+		"setregid":                     71,
+		"setregid32":                   204,
+		"setresgid":                    170,
+		"setresgid32":                  210,
+		"setresuid":                    164,
+		"setresuid32":                  208,
+		"setreuid":                     70,
+		"setreuid32":                   203,
+		"setrlimit":                    75,
+		"setsid":                       66,
+		"setsockopt":                   294,
+		"settimeofday":                 79,
+		"setuid":                       23,
+		"setuid32":                     213,
+		"setxattr":                     226,
+		"setxattrat":                   463,
+		"shmat":                        305,
+		"shmctl":                       308,
+		"shmdt":                        306,
+		"shmget":                       307,
+		"shutdown":                     293,
+		"sigaction":                    67,
+		"sigaltstack":                  186,
+		"signalfd":                     349,
+		"signalfd4":                    355,
+		"sigpending":                   73,
+		"sigprocmask":                  126,
+		"sigreturn":                    119,
+		"sigsuspend":                   72,
+		"socket":                       281,
+		"socketpair":                   288,
+		"splice":                       340,
+		"stat":                         106,
+		"stat64":                       195,
+		"statfs":                       99,
+		"statfs64":                     266,
+		"statmount":                    457,
+		"statx":                        397,
+		"swapoff":                      115,
+		"swapon":                       87,
+		"symlink":                      83,
+		"symlinkat":                    331,
+		"sync":                         36,
+		"syncfs":                       373,
+		"syscall_mask":                 0,
+		"sysfs":                        135,
+		"sysinfo":                      116,
+		"syslog":                       103,
+		"tee":                          342,
+		"tgkill":                       268,
+		"timer_create":                 257,
+		"timer_delete":                 261,
+		"timer_getoverrun":             260,
+		"timer_gettime":                259,
+		"timer_gettime64":              408,
+		"timer_settime":                258,
+		"timer_settime64":              409,
+		"timerfd_create":               350,
+		"timerfd_gettime":              354,
+		"timerfd_gettime64":            410,
+		"timerfd_settime":              353,
+		"timerfd_settime64":            411,
+		"times":                        43,
+		"tkill":                        238,
+		"truncate":                     92,
+		"truncate64":                   193,
+		"ugetrlimit":                   191,
+		"umask":                        60,
+		"umount2":                      52,
+		"uname":                        122,
+		"unlink":                       10,
+		"unlinkat":                     328,
+		"unshare":                      337,
+		"uselib":                       86,
+		"userfaultfd":                  388,
+		"ustat":                        62,
+		"utimensat":                    348,
+		"utimensat_time64":             412,
+		"utimes":                       269,
+		"vfork":                        190,
+		"vhangup":                      111,
+		"vmsplice":                     343,
+		"vserver":                      313,
+		"wait4":                        114,
+		"waitid":                       280,
+		"write":                        4,
+		"writev":                       146,
+	},
+	"arm64": {
+		"accept":                  202,
+		"accept4":                 242,
+		"acct":                    89,
+		"add_key":                 217,
+		"adjtimex":                171,
+		"arch_specific_syscall":   244,
+		"bind":                    200,
+		"bpf":                     280,
+		"brk":                     214,
+		"cachestat":               451,
+		"capget":                  90,
+		"capset":                  91,
+		"chdir":                   49,
+		"chroot":                  51,
+		"clock_adjtime":           266,
+		"clock_getres":            114,
+		"clock_gettime":           113,
+		"clock_nanosleep":         115,
+		"clock_settime":           112,
+		"clone":                   220,
+		"clone3":                  435,
+		"close":                   57,
+		"close_range":             436,
+		"connect":                 203,
+		"copy_file_range":         285,
+		"delete_module":           106,
+		"dup":                     23,
+		"dup3":                    24,
+		"epoll_create1":           20,
+		"epoll_ctl":               21,
+		"epoll_pwait":             22,
+		"epoll_pwait2":            441,
+		"eventfd2":                19,
+		"execve":                  221,
+		"execveat":                281,
+		"exit":                    93,
+		"exit_group":              94,
+		"faccessat":               48,
+		"faccessat2":              439,
+		"fadvise64":               223,
+		"fallocate":               47,
+		"fanotify_init":           262,
+		"fanotify_mark":           263,
+		"fchdir":                  50,
+		"fchmod":                  52,
+		"fchmodat":                53,
+		"fchmodat2":               452,
+		"fchown":                  55,
+		"fchownat":                54,
+		"fcntl":                   25,
+		"fdatasync":               83,
+		"fgetxattr":               10,
+		"file_getattr":            468,
+		"file_setattr":            469,
+		"finit_module":            273,
+		"flistxattr":              13,
+		"flock":                   32,
+		"fremovexattr":            16,
+		"fsconfig":                431,
+		"fsetxattr":               7,
+		"fsmount":                 432,
+		"fsopen":                  430,
+		"fspick":                  433,
+		"fstat":                   80,
+		"fstatfs":                 44,
+		"fsync":                   82,
+		"ftruncate":               46,
+		"futex":                   98,
+		"futex_requeue":           456,
+		"futex_wait":              455,
+		"futex_waitv":             449,
+		"futex_wake":              454,
+		"get_mempolicy":           236,
+		"get_robust_list":         100,
+		"getcpu":                  168,
+		"getcwd":                  17,
+		"getdents64":              61,
+		"getegid":                 177,
+		"geteuid":                 175,
+		"getgid":                  176,
+		"getgroups":               158,
+		"getitimer":               102,
+		"getpeername":             205,
+		"getpgid":                 155,
+		"getpid":                  172,
+		"getppid":                 173,
+		"getpriority":             141,
+		"getrandom":               278,
+		"getresgid":               150,
+		"getresuid":               148,
+		"getrlimit":               163,
+		"getrusage":               165,
+		"getsid":                  156,
+		"getsockname":             204,
+		"getsockopt":              209,
+		"gettid":                  178,
+		"gettimeofday":            169,
+		"getuid":                  174,
+		"getxattr":                8,
+		"getxattrat":              464,
+		"init_module":             105,
+		"inotify_add_watch":       27,
+		"inotify_init1":           26,
+		"inotify_rm_watch":        28,
+		"io_cancel":               3,
+		"io_destroy":              1,
+		"io_getevents":            4,
+		"io_pgetevents":           292,
+		"io_setup":                0,
+		"io_submit":               2,
+		"io_uring_enter":          426,
+		"io_uring_register":       427,
+		"io_uring_setup":          425,
+		"ioctl":                   29,
+		"ioprio_get":              31,
+		"ioprio_set":              30,
+		"kcmp":                    272,
+		"kexec_file_load":         294,
+		"kexec_load":              104,
+		"keyctl":                  219,
+		"kill":                    129,
+		"landlock_add_rule":       445,
+		"landlock_create_ruleset": 444,
+		"landlock_restrict_self":  446,
+		"lgetxattr":               9,
+		"linkat":                  37,
+		"listen":                  201,
+		"listmount":               458,
+		"listns":                  470,
+		"listxattr":               11,
+		"listxattrat":             465,
+		"llistxattr":              12,
+		"lookup_dcookie":          18,
+		"lremovexattr":            15,
+		"lseek":                   62,
+		"lsetxattr":               6,
+		"lsm_get_self_attr":       459,
+		"lsm_list_modules":        461,
+		"lsm_set_self_attr":       460,
+		"madvise":                 233,
+		"map_shadow_stack":        453,
+		"mbind":                   235,
+		"membarrier":              283,
+		"memfd_create":            279,
+		"memfd_secret":            447,
+		"migrate_pages":           238,
+		"mincore":                 232,
+		"mkdirat":                 34,
+		"mknodat":                 33,
+		"mlock":                   228,
+		"mlock2":                  284,
+		"mlockall":                230,
+		"mmap":                    222,
+		"mount":                   40,
+		"mount_setattr":           442,
+		"move_mount":              429,
+		"move_pages":              239,
+		"mprotect":                226,
+		"mq_getsetattr":           185,
+		"mq_notify":               184,
+		"mq_open":                 180,
+		"mq_timedreceive":         183,
+		"mq_timedsend":            182,
+		"mq_unlink":               181,
+		"mremap":                  216,
+		"mseal":                   462,
+		"msgctl":                  187,
+		"msgget":                  186,
+		"msgrcv":                  188,
+		"msgsnd":                  189,
+		"msync":                   227,
+		"munlock":                 229,
+		"munlockall":              231,
+		"munmap":                  215,
+		"name_to_handle_at":       264,
+		"nanosleep":               101,
+		"newfstatat":              79,
+		"nfsservctl":              42,
+		"open_by_handle_at":       265,
+		"open_tree":               428,
+		"open_tree_attr":          467,
+		"openat":                  56,
+		"openat2":                 437,
+		"perf_event_open":         241,
+		"personality":             92,
+		"pidfd_getfd":             438,
+		"pidfd_open":              434,
+		"pidfd_send_signal":       424,
+		"pipe2":                   59,
+		"pivot_root":              41,
+		"pkey_alloc":              289,
+		"pkey_free":               290,
+		"pkey_mprotect":           288,
+		"ppoll":                   73,
+		"prctl":                   167,
+		"pread64":                 67,
+		"preadv":                  69,
+		"preadv2":                 286,
+		"prlimit64":               261,
+		"process_madvise":         440,
+		"process_mrelease":        448,
+		"process_vm_readv":        270,
+		"process_vm_writev":       271,
+		"pselect6":                72,
+		"ptrace":                  117,
+		"pwrite64":                68,
+		"pwritev":                 70,
+		"pwritev2":                287,
+		"quotactl":                60,
+		"quotactl_fd":             443,
+		"read":                    63,
+		"readahead":               213,
+		"readlinkat":              78,
+		"readv":                   65,
+		"reboot":                  142,
+		"recvfrom":                207,
+		"recvmmsg":                243,
+		"recvmsg":                 212,
+		"remap_file_pages":        234,
+		"removexattr":             14,
+		"removexattrat":           466,
+		"renameat":                38,
+		"renameat2":               276,
+		"request_key":             218,
+		"restart_syscall":         128,
+		"rseq":                    293,
+		"rseq_slice_yield":        471,
+		"rt_sigaction":            134,
+		"rt_sigpending":           136,
+		"rt_sigprocmask":          135,
+		"rt_sigqueueinfo":         138,
+		"rt_sigreturn":            139,
+		"rt_sigsuspend":           133,
+		"rt_sigtimedwait":         137,
+		"rt_tgsigqueueinfo":       240,
+		"sched_get_priority_max":  125,
+		"sched_get_priority_min":  126,
+		"sched_getaffinity":       123,
+		"sched_getattr":           275,
+		"sched_getparam":          121,
+		"sched_getscheduler":      120,
+		"sched_rr_get_interval":   127,
+		"sched_setaffinity":       122,
+		"sched_setattr":           274,
+		"sched_setparam":          118,
+		"sched_setscheduler":      119,
+		"sched_yield":             124,
+		"seccomp":                 277,
+		"semctl":                  191,
+		"semget":                  190,
+		"semop":                   193,
+		"semtimedop":              192,
+		"sendfile":                71,
+		"sendmmsg":                269,
+		"sendmsg":                 211,
+		"sendto":                  206,
+		"set_mempolicy":           237,
+		"set_mempolicy_home_node": 450,
+		"set_robust_list":         99,
+		"set_tid_address":         96,
+		"setdomainname":           162,
+		"setfsgid":                152,
+		"setfsuid":                151,
+		"setgid":                  144,
+		"setgroups":               159,
+		"sethostname":             161,
+		"setitimer":               103,
+		"setns":                   268,
+		"setpgid":                 154,
+		"setpriority":             140,
+		"setregid":                143,
+		"setresgid":               149,
+		"setresuid":               147,
+		"setreuid":                145,
+		"setrlimit":               164,
+		"setsid":                  157,
+		"setsockopt":              208,
+		"settimeofday":            170,
+		"setuid":                  146,
+		"setxattr":                5,
+		"setxattrat":              463,
+		"shmat":                   196,
+		"shmctl":                  195,
+		"shmdt":                   197,
+		"shmget":                  194,
+		"shutdown":                210,
+		"sigaltstack":             132,
+		"signalfd4":               74,
+		"socket":                  198,
+		"socketpair":              199,
+		"splice":                  76,
+		"statfs":                  43,
+		"statmount":               457,
+		"statx":                   291,
+		"swapoff":                 225,
+		"swapon":                  224,
+		"symlinkat":               36,
+		"sync":                    81,
+		"sync_file_range":         84,
+		"syncfs":                  267,
+		"sysinfo":                 179,
+		"syslog":                  116,
+		"tee":                     77,
+		"tgkill":                  131,
+		"timer_create":            107,
+		"timer_delete":            111,
+		"timer_getoverrun":        109,
+		"timer_gettime":           108,
+		"timer_settime":           110,
+		"timerfd_create":          85,
+		"timerfd_gettime":         87,
+		"timerfd_settime":         86,
+		"times":                   153,
+		"tkill":                   130,
+		"truncate":                45,
+		"umask":                   166,
+		"umount2":                 39,
+		"uname":                   160,
+		"unlinkat":                35,
+		"unshare":                 97,
+		"userfaultfd":             282,
+		"utimensat":               88,
+		"vhangup":                 58,
+		"vmsplice":                75,
+		"wait4":                   260,
+		"waitid":                  95,
+		"write":                   64,
+		"writev":                  66,
+	},
+	"mips": {
+		"_llseek":                      4140,
+		"_newselect":                   4142,
+		"_sysctl":                      4153,
+		"accept":                       4168,
+		"accept4":                      4334,
+		"access":                       4033,
+		"acct":                         4051,
+		"add_key":                      4280,
+		"adjtimex":                     4124,
+		"afs_syscall":                  4137,
+		"alarm":                        4027,
+		"bdflush":                      4134,
+		"bind":                         4169,
+		"bpf":                          4355,
+		"break":                        4017,
+		"brk":                          4045,
+		"cachectl":                     4148,
+		"cacheflush":                   4147,
+		"cachestat":                    4451,
+		"capget":                       4204,
+		"capset":                       4205,
+		"chdir":                        4012,
+		"chmod":                        4015,
+		"chown":                        4202,
+		"chroot":                       4061,
+		"clock_adjtime":                4341,
+		"clock_adjtime64":              4405,
+		"clock_getres":                 4264,
+		"clock_getres_time64":          4406,
+		"clock_gettime":                4263,
+		"clock_gettime64":              4403,
+		"clock_nanosleep":              4265,
+		"clock_nanosleep_time64":       4407,
+		"clock_settime":                4262,
+		"clock_settime64":              4404,
+		"clone":                        4120,
+		"clone3":                       4435,
+		"close":                        4006,
+		"close_range":                  4436,
+		"connect":                      4170,
+		"copy_file_range":              4360,
+		"creat":                        4008,
+		"create_module":                4127,
+		"delete_module":                4129,
+		"dup":                          4041,
+		"dup2":                         4063,
+		"dup3":                         4327,
+		"epoll_create":                 4248,
+		"epoll_create1":                4326,
+		"epoll_ctl":                    4249,
+		"epoll_pwait":                  4313,
+		"epoll_pwait2":                 4441,
+		"epoll_wait":                   4250,
+		"eventfd":                      4319,
+		"eventfd2":                     4325,
+		"execve":                       4011,
+		"execveat":                     4356,
+		"exit":                         4001,
+		"exit_group":                   4246,
+		"faccessat":                    4300,
+		"faccessat2":                   4439,
+		"fadvise64":                    4254,
+		"fallocate":                    4320,
+		"fanotify_init":                4336,
+		"fanotify_mark":                4337,
+		"fchdir":                       4133,
+		"fchmod":                       4094,
+		"fchmodat":                     4299,
+		"fchmodat2":                    4452,
+		"fchown":                       4095,
+		"fchownat":                     4291,
+		"fcntl":                        4055,
+		"fcntl64":                      4220,
+		"fdatasync":                    4152,
+		"fgetxattr":                    4229,
+		"file_getattr":                 4468,
+		"file_setattr":                 4469,
+		"finit_module":                 4348,
+		"flistxattr":                   4232,
+		"flock":                        4143,
+		"fork":                         4002,
+		"fremovexattr":                 4235,
+		"fsconfig":                     4431,
+		"fsetxattr":                    4226,
+		"fsmount":                      4432,
+		"fsopen":                       4430,
+		"fspick":                       4433,
+		"fstat":                        4108,
+		"fstat64":                      4215,
+		"fstatat64":                    4293,
+		"fstatfs":                      4100,
+		"fstatfs64":                    4256,
+		"fsync":                        4118,
+		"ftime":                        4035,
+		"ftruncate":                    4093,
+		"ftruncate64":                  4212,
+		"futex":                        4238,
+		"futex_requeue":                4456,
+		"futex_time64":                 4422,
+		"futex_wait":                   4455,
+		"futex_waitv":                  4449,
+		"futex_wake":                   4454,
+		"futimesat":                    4292,
+		"get_kernel_syms":              4130,
+		"get_mempolicy":                4269,
+		"get_robust_list":              4310,
+		"getcpu":                       4312,
+		"getcwd":                       4203,
+		"getdents":                     4141,
+		"getdents64":                   4219,
+		"getegid":                      4050,
+		"geteuid":                      4049,
+		"getgid":                       4047,
+		"getgroups":                    4080,
+		"getitimer":                    4105,
+		"getpeername":                  4171,
+		"getpgid":                      4132,
+		"getpgrp":                      4065,
+		"getpid":                       4020,
+		"getpmsg":                      4208,
+		"getppid":                      4064,
+		"getpriority":                  4096,
+		"getrandom":                    4353,
+		"getresgid":                    4191,
+		"getresuid":                    4186,
+		"getrlimit":                    4076,
+		"getrusage":                    4077,
+		"getsid":                       4151,
+		"getsockname":                  4172,
+		"getsockopt":                   4173,
+		"gettid":                       4222,
+		"gettimeofday":                 4078,
+		"getuid":                       4024,
+		"getxattr":                     4227,
+		"getxattrat":                   4464,
+		"gtty":                         4032,
+		"idle":                         4112,
+		"init_module":                  4128,
+		"inotify_add_watch":            4285,
+		"inotify_init":                 4284,
+		"inotify_init1":                4329,
+		"inotify_rm_watch":             4286,
+		"io_cancel":                    4245,
+		"io_destroy":                   4242,
+		"io_getevents":                 4243,
+		"io_pgetevents":                4368,
+		"io_pgetevents_time64":         4416,
+		"io_setup":                     4241,
+		"io_submit":                    4244,
+		"io_uring_enter":               4426,
+		"io_uring_register":            4427,
+		"io_uring_setup":               4425,
+		"ioctl":                        4054,
+		"ioperm":                       4101,
+		"iopl":                         4110,
+		"ioprio_get":                   4315,
+		"ioprio_set":                   4314,
+		"ipc":                          4117,
+		"kcmp":                         4347,
+		"kexec_load":                   4311,
+		"keyctl":                       4282,
+		"kill":                         4037,
+		"landlock_add_rule":            4445,
+		"landlock_create_ruleset":      4444,
+		"landlock_restrict_self":       4446,
+		"lchown":                       4016,
+		"lgetxattr":                    4228,
+		"link":                         4009,
+		"linkat":                       4296,
+		"listen":                       4174,
+		"listmount":                    4458,
+		"listns":                       4470,
+		"listxattr":                    4230,
+		"listxattrat":                  4465,
+		"llistxattr":                   4231,
+		"lock":                         4053,
+		"lookup_dcookie":               4247,
+		"lremovexattr":                 4234,
+		"lseek":                        4019,
+		"lsetxattr":                    4225,
+		"lsm_get_self_attr":            4459,
+		"lsm_list_modules":             4461,
+		"lsm_set_self_attr":            4460,
+		"lstat":                        4107,
+		"lstat64":                      4214,
+		"madvise":                      4218,
+		"map_shadow_stack":             4453,
+		"mbind":                        4268,
+		"membarrier":                   4358,
+		"memfd_create":                 4354,
+		"migrate_pages":                4287,
+		"mincore":                      4217,
+		"mkdir":                        4039,
+		"mkdirat":                      4289,
+		"mknod":                        4014,
+		"mknodat":                      4290,
+		"mlock":                        4154,
+		"mlock2":                       4359,
+		"mlockall":                     4156,
+		"mmap":                         4090,
+		"mmap2":                        4210,
+		"modify_ldt":                   4123,
+		"mount":                        4021,
+		"mount_setattr":                4442,
+		"move_mount":                   4429,
+		"move_pages":                   4308,
+		"mprotect":                     4125,
+		"mpx":                          4056,
+		"mq_getsetattr":                4276,
+		"mq_notify":                    4275,
+		"mq_open":                      4271,
+		"mq_timedreceive":              4274,
+		"mq_timedreceive_time64":       4419,
+		"mq_timedsend":                 4273,
+		"mq_timedsend_time64":          4418,
+		"mq_unlink":                    4272,
+		"mremap":                       4167,
+		"mseal":                        4462,
+		"msgctl":                       4402,
+		"msgget":                       4399,
+		"msgrcv":                       4401,
+		"msgsnd":                       4400,
+		"msync":                        4144,
+		"munlock":                      4155,
+		"munlockall":                   4157,
+		"munmap":                       4091,
+		"name_to_handle_at":            4339,
+		"nanosleep":                    4166,
+		"nfsservctl":                   4189,
+		"nice":                         4034,
+		"open":                         4005,
+		"open_by_handle_at":            4340,
+		"open_tree":                    4428,
+		"open_tree_attr":               4467,
+		"openat":                       4288,
+		"openat2":                      4437,
+		"pause":                        4029,
+		"perf_event_open":              4333,
+		"personality":                  4136,
+		"pidfd_getfd":                  4438,
+		"pidfd_open":                   4434,
+		"pidfd_send_signal":            4424,
+		"pipe":                         4042,
+		"pipe2":                        4328,
+		"pivot_root":                   4216,
+		"pkey_alloc":                   4364,
+		"pkey_free":                    4365,
+		"pkey_mprotect":                4363,
+		"poll":                         4188,
+		"ppoll":                        4302,
+		"ppoll_time64":                 4414,
+		"prctl":                        4192,
+		"pread64":                      4200,
+		"preadv":                       4330,
+		"preadv2":                      4361,
+		"prlimit64":                    4338,
+		"process_madvise":              4440,
+		"process_mrelease":             4448,
+		"process_vm_readv":             4345,
+		"process_vm_writev":            4346,
+		"prof":                         4044,
+		"profil":                       4098,
+		"pselect6":                     4301,
+		"pselect6_time64":              4413,
+		"ptrace":                       4026,
+		"putpmsg":                      4209,
+		"pwrite64":                     4201,
+		"pwritev":                      4331,
+		"pwritev2":                     4362,
+		"query_module":                 4187,
+		"quotactl":                     4131,
+		"quotactl_fd":                  4443,
+		"read":                         4003,
+		"readahead":                    4223,
+		"readdir":                      4089,
+		"readlink":                     4085,
+		"readlinkat":                   4298,
+		"readv":                        4145,
+		"reboot":                       4088,
+		"recv":                         4175,
+		"recvfrom":                     4176,
+		"recvmmsg":                     4335,
+		"recvmmsg_time64":              4417,
+		"recvmsg":                      4177,
+		"remap_file_pages":             4251,
+		"removexattr":                  4233,
+		"removexattrat":                4466,
+		"rename":                       4038,
+		"renameat":                     4295,
+		"renameat2":                    4351,
+		"request_key":                  4281,
+		"reserved221":                  4221,
+		"reserved82":                   4082,
+		"restart_syscall":              4253,
+		"rmdir":                        4040,
+		"rseq":                         4367,
+		"rseq_slice_yield":             4471,
+		"rt_sigaction":                 4194,
+		"rt_sigpending":                4196,
+		"rt_sigprocmask":               4195,
+		"rt_sigqueueinfo":              4198,
+		"rt_sigreturn":                 4193,
+		"rt_sigsuspend":                4199,
+		"rt_sigtimedwait":              4197,
+		"rt_sigtimedwait_time64":       4421,
+		"rt_tgsigqueueinfo":            4332,
+		"sched_get_priority_max":       4163,
+		"sched_get_priority_min":       4164,
+		"sched_getaffinity":            4240,
+		"sched_getattr":                4350,
+		"sched_getparam":               4159,
+		"sched_getscheduler":           4161,
+		"sched_rr_get_interval":        4165,
+		"sched_rr_get_interval_time64": 4423,
+		"sched_setaffinity":            4239,
+		"sched_setattr":                4349,
+		"sched_setparam":               4158,
+		"sched_setscheduler":           4160,
+		"sched_yield":                  4162,
+		"seccomp":                      4352,
+		"semctl":                       4394,
+		"semget":                       4393,
+		"semtimedop_time64":            4420,
+		"send":                         4178,
+		"sendfile":                     4207,
+		"sendfile64":                   4237,
+		"sendmmsg":                     4343,
+		"sendmsg":                      4179,
+		"sendto":                       4180,
+		"set_mempolicy":                4270,
+		"set_mempolicy_home_node":      4450,
+		"set_robust_list":              4309,
+		"set_thread_area":              4283,
+		"set_tid_address":              4252,
+		"setdomainname":                4121,
+		"setfsgid":                     4139,
+		"setfsuid":                     4138,
+		"setgid":                       4046,
+		"setgroups":                    4081,
+		"sethostname":                  4074,
+		"setitimer":                    4104,
+		"setns":                        4344,
+		"setpgid":                      4057,
+		"setpriority":                  4097,
+		"setregid":                     4071,
+		"setresgid":                    4190,
+		"setresuid":                    4185,
+		"setreuid":                     4070,
+		"setrlimit":                    4075,
+		"setsid":                       4066,
+		"setsockopt":                   4181,
+		"settimeofday":                 4079,
+		"setuid":                       4023,
+		"setxattr":                     4224,
+		"setxattrat":                   4463,
+		"sgetmask":                     4068,
+		"shmat":                        4397,
+		"shmctl":                       4396,
+		"shmdt":                        4398,
+		"shmget":                       4395,
+		"shutdown":                     4182,
+		"sigaction":                    4067,
+		"sigaltstack":                  4206,
+		"signal":                       4048,
+		"signalfd":                     4317,
+		"signalfd4":                    4324,
+		"sigpending":                   4073,
+		"sigprocmask":                  4126,
+		"sigreturn":                    4119,
+		"sigsuspend":                   4072,
+		"socket":                       4183,
+		"socketcall":                   4102,
+		"socketpair":                   4184,
+		"splice":                       4304,
+		"ssetmask":                     4069,
+		"stat":                         4106,
+		"stat64":                       4213,
+		"statfs":                       4099,
+		"statfs64":                     4255,
+		"statmount":                    4457,
+		"statx":                        4366,
+		"stime":                        4025,
+		"stty":                         4031,
+		"swapoff":                      4115,
+		"swapon":                       4087,
+		"symlink":                      4083,
+		"symlinkat":                    4297,
+		"sync":                         4036,
+		"sync_file_range":              4305,
+		"syncfs":                       4342,
+		"syscall":                      4000,
+		"sysfs":                        4135,
+		"sysinfo":                      4116,
+		"syslog":                       4103,
+		"sysmips":                      4149,
+		"tee":                          4306,
+		"tgkill":                       4266,
+		"time":                         4013,
+		"timer_create":                 4257,
+		"timer_delete":                 4261,
+		"timer_getoverrun":             4260,
+		"timer_gettime":                4259,
+		"timer_gettime64":              4408,
+		"timer_settime":                4258,
+		"timer_settime64":              4409,
+		"timerfd":                      4318,
+		"timerfd_create":               4321,
+		"timerfd_gettime":              4322,
+		"timerfd_gettime64":            4410,
+		"timerfd_settime":              4323,
+		"timerfd_settime64":            4411,
+		"times":                        4043,
+		"tkill":                        4236,
+		"truncate":                     4092,
+		"truncate64":                   4211,
+		"ulimit":                       4058,
+		"umask":                        4060,
+		"umount":                       4022,
+		"umount2":                      4052,
+		"uname":                        4122,
+		"unlink":                       4010,
+		"unlinkat":                     4294,
+		"unshare":                      4303,
+		"unused109":                    4109,
+		"unused150":                    4150,
+		"unused18":                     4018,
+		"unused28":                     4028,
+		"unused59":                     4059,
+		"unused84":                     4084,
+		"uselib":                       4086,
+		"userfaultfd":                  4357,
+		"ustat":                        4062,
+		"utime":                        4030,
+		"utimensat":                    4316,
+		"utimensat_time64":             4412,
+		"utimes":                       4267,
+		"vhangup":                      4111,
+		"vm86":                         4113,
+		"vmsplice":                     4307,
+		"vserver":                      4277,
+		"wait4":                        4114,
+		"waitid":                       4278,
+		"waitpid":                      4007,
+		"write":                        4004,
+		"writev":                       4146,
+	},
+	"mipsel": {
+		"_llseek":                      4140,
+		"_newselect":                   4142,
+		"_sysctl":                      4153,
+		"accept":                       4168,
+		"accept4":                      4334,
+		"access":                       4033,
+		"acct":                         4051,
+		"add_key":                      4280,
+		"adjtimex":                     4124,
+		"afs_syscall":                  4137,
+		"alarm":                        4027,
+		"bdflush":                      4134,
+		"bind":                         4169,
+		"bpf":                          4355,
+		"break":                        4017,
+		"brk":                          4045,
+		"cachectl":                     4148,
+		"cacheflush":                   4147,
+		"cachestat":                    4451,
+		"capget":                       4204,
+		"capset":                       4205,
+		"chdir":                        4012,
+		"chmod":                        4015,
+		"chown":                        4202,
+		"chroot":                       4061,
+		"clock_adjtime":                4341,
+		"clock_adjtime64":              4405,
+		"clock_getres":                 4264,
+		"clock_getres_time64":          4406,
+		"clock_gettime":                4263,
+		"clock_gettime64":              4403,
+		"clock_nanosleep":              4265,
+		"clock_nanosleep_time64":       4407,
+		"clock_settime":                4262,
+		"clock_settime64":              4404,
+		"clone":                        4120,
+		"clone3":                       4435,
+		"close":                        4006,
+		"close_range":                  4436,
+		"connect":                      4170,
+		"copy_file_range":              4360,
+		"creat":                        4008,
+		"create_module":                4127,
+		"delete_module":                4129,
+		"dup":                          4041,
+		"dup2":                         4063,
+		"dup3":                         4327,
+		"epoll_create":                 4248,
+		"epoll_create1":                4326,
+		"epoll_ctl":                    4249,
+		"epoll_pwait":                  4313,
+		"epoll_pwait2":                 4441,
+		"epoll_wait":                   4250,
+		"eventfd":                      4319,
+		"eventfd2":                     4325,
+		"execve":                       4011,
+		"execveat":                     4356,
+		"exit":                         4001,
+		"exit_group":                   4246,
+		"faccessat":                    4300,
+		"faccessat2":                   4439,
+		"fadvise64":                    4254,
+		"fallocate":                    4320,
+		"fanotify_init":                4336,
+		"fanotify_mark":                4337,
+		"fchdir":                       4133,
+		"fchmod":                       4094,
+		"fchmodat":                     4299,
+		"fchmodat2":                    4452,
+		"fchown":                       4095,
+		"fchownat":                     4291,
+		"fcntl":                        4055,
+		"fcntl64":                      4220,
+		"fdatasync":                    4152,
+		"fgetxattr":                    4229,
+		"file_getattr":                 4468,
+		"file_setattr":                 4469,
+		"finit_module":                 4348,
+		"flistxattr":                   4232,
+		"flock":                        4143,
+		"fork":                         4002,
+		"fremovexattr":                 4235,
+		"fsconfig":                     4431,
+		"fsetxattr":                    4226,
+		"fsmount":                      4432,
+		"fsopen":                       4430,
+		"fspick":                       4433,
+		"fstat":                        4108,
+		"fstat64":                      4215,
+		"fstatat64":                    4293,
+		"fstatfs":                      4100,
+		"fstatfs64":                    4256,
+		"fsync":                        4118,
+		"ftime":                        4035,
+		"ftruncate":                    4093,
+		"ftruncate64":                  4212,
+		"futex":                        4238,
+		"futex_requeue":                4456,
+		"futex_time64":                 4422,
+		"futex_wait":                   4455,
+		"futex_waitv":                  4449,
+		"futex_wake":                   4454,
+		"futimesat":                    4292,
+		"get_kernel_syms":              4130,
+		"get_mempolicy":                4269,
+		"get_robust_list":              4310,
+		"getcpu":                       4312,
+		"getcwd":                       4203,
+		"getdents":                     4141,
+		"getdents64":                   4219,
+		"getegid":                      4050,
+		"geteuid":                      4049,
+		"getgid":                       4047,
+		"getgroups":                    4080,
+		"getitimer":                    4105,
+		"getpeername":                  4171,
+		"getpgid":                      4132,
+		"getpgrp":                      4065,
+		"getpid":                       4020,
+		"getpmsg":                      4208,
+		"getppid":                      4064,
+		"getpriority":                  4096,
+		"getrandom":                    4353,
+		"getresgid":                    4191,
+		"getresuid":                    4186,
+		"getrlimit":                    4076,
+		"getrusage":                    4077,
+		"getsid":                       4151,
+		"getsockname":                  4172,
+		"getsockopt":                   4173,
+		"gettid":                       4222,
+		"gettimeofday":                 4078,
+		"getuid":                       4024,
+		"getxattr":                     4227,
+		"getxattrat":                   4464,
+		"gtty":                         4032,
+		"idle":                         4112,
+		"init_module":                  4128,
+		"inotify_add_watch":            4285,
+		"inotify_init":                 4284,
+		"inotify_init1":                4329,
+		"inotify_rm_watch":             4286,
+		"io_cancel":                    4245,
+		"io_destroy":                   4242,
+		"io_getevents":                 4243,
+		"io_pgetevents":                4368,
+		"io_pgetevents_time64":         4416,
+		"io_setup":                     4241,
+		"io_submit":                    4244,
+		"io_uring_enter":               4426,
+		"io_uring_register":            4427,
+		"io_uring_setup":               4425,
+		"ioctl":                        4054,
+		"ioperm":                       4101,
+		"iopl":                         4110,
+		"ioprio_get":                   4315,
+		"ioprio_set":                   4314,
+		"ipc":                          4117,
+		"kcmp":                         4347,
+		"kexec_load":                   4311,
+		"keyctl":                       4282,
+		"kill":                         4037,
+		"landlock_add_rule":            4445,
+		"landlock_create_ruleset":      4444,
+		"landlock_restrict_self":       4446,
+		"lchown":                       4016,
+		"lgetxattr":                    4228,
+		"link":                         4009,
+		"linkat":                       4296,
+		"listen":                       4174,
+		"listmount":                    4458,
+		"listns":                       4470,
+		"listxattr":                    4230,
+		"listxattrat":                  4465,
+		"llistxattr":                   4231,
+		"lock":                         4053,
+		"lookup_dcookie":               4247,
+		"lremovexattr":                 4234,
+		"lseek":                        4019,
+		"lsetxattr":                    4225,
+		"lsm_get_self_attr":            4459,
+		"lsm_list_modules":             4461,
+		"lsm_set_self_attr":            4460,
+		"lstat":                        4107,
+		"lstat64":                      4214,
+		"madvise":                      4218,
+		"map_shadow_stack":             4453,
+		"mbind":                        4268,
+		"membarrier":                   4358,
+		"memfd_create":                 4354,
+		"migrate_pages":                4287,
+		"mincore":                      4217,
+		"mkdir":                        4039,
+		"mkdirat":                      4289,
+		"mknod":                        4014,
+		"mknodat":                      4290,
+		"mlock":                        4154,
+		"mlock2":                       4359,
+		"mlockall":                     4156,
+		"mmap":                         4090,
+		"mmap2":                        4210,
+		"modify_ldt":                   4123,
+		"mount":                        4021,
+		"mount_setattr":                4442,
+		"move_mount":                   4429,
+		"move_pages":                   4308,
+		"mprotect":                     4125,
+		"mpx":                          4056,
+		"mq_getsetattr":                4276,
+		"mq_notify":                    4275,
+		"mq_open":                      4271,
+		"mq_timedreceive":              4274,
+		"mq_timedreceive_time64":       4419,
+		"mq_timedsend":                 4273,
+		"mq_timedsend_time64":          4418,
+		"mq_unlink":                    4272,
+		"mremap":                       4167,
+		"mseal":                        4462,
+		"msgctl":                       4402,
+		"msgget":                       4399,
+		"msgrcv":                       4401,
+		"msgsnd":                       4400,
+		"msync":                        4144,
+		"munlock":                      4155,
+		"munlockall":                   4157,
+		"munmap":                       4091,
+		"name_to_handle_at":            4339,
+		"nanosleep":                    4166,
+		"nfsservctl":                   4189,
+		"nice":                         4034,
+		"open":                         4005,
+		"open_by_handle_at":            4340,
+		"open_tree":                    4428,
+		"open_tree_attr":               4467,
+		"openat":                       4288,
+		"openat2":                      4437,
+		"pause":                        4029,
+		"perf_event_open":              4333,
+		"personality":                  4136,
+		"pidfd_getfd":                  4438,
+		"pidfd_open":                   4434,
+		"pidfd_send_signal":            4424,
+		"pipe":                         4042,
+		"pipe2":                        4328,
+		"pivot_root":                   4216,
+		"pkey_alloc":                   4364,
+		"pkey_free":                    4365,
+		"pkey_mprotect":                4363,
+		"poll":                         4188,
+		"ppoll":                        4302,
+		"ppoll_time64":                 4414,
+		"prctl":                        4192,
+		"pread64":                      4200,
+		"preadv":                       4330,
+		"preadv2":                      4361,
+		"prlimit64":                    4338,
+		"process_madvise":              4440,
+		"process_mrelease":             4448,
+		"process_vm_readv":             4345,
+		"process_vm_writev":            4346,
+		"prof":                         4044,
+		"profil":                       4098,
+		"pselect6":                     4301,
+		"pselect6_time64":              4413,
+		"ptrace":                       4026,
+		"putpmsg":                      4209,
+		"pwrite64":                     4201,
+		"pwritev":                      4331,
+		"pwritev2":                     4362,
+		"query_module":                 4187,
+		"quotactl":                     4131,
+		"quotactl_fd":                  4443,
+		"read":                         4003,
+		"readahead":                    4223,
+		"readdir":                      4089,
+		"readlink":                     4085,
+		"readlinkat":                   4298,
+		"readv":                        4145,
+		"reboot":                       4088,
+		"recv":                         4175,
+		"recvfrom":                     4176,
+		"recvmmsg":                     4335,
+		"recvmmsg_time64":              4417,
+		"recvmsg":                      4177,
+		"remap_file_pages":             4251,
+		"removexattr":                  4233,
+		"removexattrat":                4466,
+		"rename":                       4038,
+		"renameat":                     4295,
+		"renameat2":                    4351,
+		"request_key":                  4281,
+		"reserved221":                  4221,
+		"reserved82":                   4082,
+		"restart_syscall":              4253,
+		"rmdir":                        4040,
+		"rseq":                         4367,
+		"rseq_slice_yield":             4471,
+		"rt_sigaction":                 4194,
+		"rt_sigpending":                4196,
+		"rt_sigprocmask":               4195,
+		"rt_sigqueueinfo":              4198,
+		"rt_sigreturn":                 4193,
+		"rt_sigsuspend":                4199,
+		"rt_sigtimedwait":              4197,
+		"rt_sigtimedwait_time64":       4421,
+		"rt_tgsigqueueinfo":            4332,
+		"sched_get_priority_max":       4163,
+		"sched_get_priority_min":       4164,
+		"sched_getaffinity":            4240,
+		"sched_getattr":                4350,
+		"sched_getparam":               4159,
+		"sched_getscheduler":           4161,
+		"sched_rr_get_interval":        4165,
+		"sched_rr_get_interval_time64": 4423,
+		"sched_setaffinity":            4239,
+		"sched_setattr":                4349,
+		"sched_setparam":               4158,
+		"sched_setscheduler":           4160,
+		"sched_yield":                  4162,
+		"seccomp":                      4352,
+		"semctl":                       4394,
+		"semget":                       4393,
+		"semtimedop_time64":            4420,
+		"send":                         4178,
+		"sendfile":                     4207,
+		"sendfile64":                   4237,
+		"sendmmsg":                     4343,
+		"sendmsg":                      4179,
+		"sendto":                       4180,
+		"set_mempolicy":                4270,
+		"set_mempolicy_home_node":      4450,
+		"set_robust_list":              4309,
+		"set_thread_area":              4283,
+		"set_tid_address":              4252,
+		"setdomainname":                4121,
+		"setfsgid":                     4139,
+		"setfsuid":                     4138,
+		"setgid":                       4046,
+		"setgroups":                    4081,
+		"sethostname":                  4074,
+		"setitimer":                    4104,
+		"setns":                        4344,
+		"setpgid":                      4057,
+		"setpriority":                  4097,
+		"setregid":                     4071,
+		"setresgid":                    4190,
+		"setresuid":                    4185,
+		"setreuid":                     4070,
+		"setrlimit":                    4075,
+		"setsid":                       4066,
+		"setsockopt":                   4181,
+		"settimeofday":                 4079,
+		"setuid":                       4023,
+		"setxattr":                     4224,
+		"setxattrat":                   4463,
+		"sgetmask":                     4068,
+		"shmat":                        4397,
+		"shmctl":                       4396,
+		"shmdt":                        4398,
+		"shmget":                       4395,
+		"shutdown":                     4182,
+		"sigaction":                    4067,
+		"sigaltstack":                  4206,
+		"signal":                       4048,
+		"signalfd":                     4317,
+		"signalfd4":                    4324,
+		"sigpending":                   4073,
+		"sigprocmask":                  4126,
+		"sigreturn":                    4119,
+		"sigsuspend":                   4072,
+		"socket":                       4183,
+		"socketcall":                   4102,
+		"socketpair":                   4184,
+		"splice":                       4304,
+		"ssetmask":                     4069,
+		"stat":                         4106,
+		"stat64":                       4213,
+		"statfs":                       4099,
+		"statfs64":                     4255,
+		"statmount":                    4457,
+		"statx":                        4366,
+		"stime":                        4025,
+		"stty":                         4031,
+		"swapoff":                      4115,
+		"swapon":                       4087,
+		"symlink":                      4083,
+		"symlinkat":                    4297,
+		"sync":                         4036,
+		"sync_file_range":              4305,
+		"syncfs":                       4342,
+		"syscall":                      4000,
+		"sysfs":                        4135,
+		"sysinfo":                      4116,
+		"syslog":                       4103,
+		"sysmips":                      4149,
+		"tee":                          4306,
+		"tgkill":                       4266,
+		"time":                         4013,
+		"timer_create":                 4257,
+		"timer_delete":                 4261,
+		"timer_getoverrun":             4260,
+		"timer_gettime":                4259,
+		"timer_gettime64":              4408,
+		"timer_settime":                4258,
+		"timer_settime64":              4409,
+		"timerfd":                      4318,
+		"timerfd_create":               4321,
+		"timerfd_gettime":              4322,
+		"timerfd_gettime64":            4410,
+		"timerfd_settime":              4323,
+		"timerfd_settime64":            4411,
+		"times":                        4043,
+		"tkill":                        4236,
+		"truncate":                     4092,
+		"truncate64":                   4211,
+		"ulimit":                       4058,
+		"umask":                        4060,
+		"umount":                       4022,
+		"umount2":                      4052,
+		"uname":                        4122,
+		"unlink":                       4010,
+		"unlinkat":                     4294,
+		"unshare":                      4303,
+		"unused109":                    4109,
+		"unused150":                    4150,
+		"unused18":                     4018,
+		"unused28":                     4028,
+		"unused59":                     4059,
+		"unused84":                     4084,
+		"uselib":                       4086,
+		"userfaultfd":                  4357,
+		"ustat":                        4062,
+		"utime":                        4030,
+		"utimensat":                    4316,
+		"utimensat_time64":             4412,
+		"utimes":                       4267,
+		"vhangup":                      4111,
+		"vm86":                         4113,
+		"vmsplice":                     4307,
+		"vserver":                      4277,
+		"wait4":                        4114,
+		"waitid":                       4278,
+		"waitpid":                      4007,
+		"write":                        4004,
+		"writev":                       4146,
+	},
+	"mips64": {
+		"_newselect":              5022,
+		"_sysctl":                 5152,
+		"accept":                  5042,
+		"accept4":                 5293,
+		"access":                  5020,
+		"acct":                    5158,
+		"add_key":                 5239,
+		"adjtimex":                5154,
+		"afs_syscall":             5176,
+		"alarm":                   5037,
+		"bind":                    5048,
+		"bpf":                     5315,
+		"brk":                     5012,
+		"cachectl":                5198,
+		"cacheflush":              5197,
+		"cachestat":               5451,
+		"capget":                  5123,
+		"capset":                  5124,
+		"chdir":                   5078,
+		"chmod":                   5088,
+		"chown":                   5090,
+		"chroot":                  5156,
+		"clock_adjtime":           5300,
+		"clock_getres":            5223,
+		"clock_gettime":           5222,
+		"clock_nanosleep":         5224,
+		"clock_settime":           5221,
+		"clone":                   5055,
+		"clone3":                  5435,
+		"close":                   5003,
+		"close_range":             5436,
+		"connect":                 5041,
+		"copy_file_range":         5320,
+		"creat":                   5083,
+		"create_module":           5167,
+		"delete_module":           5169,
+		"dup":                     5031,
+		"dup2":                    5032,
+		"dup3":                    5286,
+		"epoll_create":            5207,
+		"epoll_create1":           5285,
+		"epoll_ctl":               5208,
+		"epoll_pwait":             5272,
+		"epoll_pwait2":            5441,
+		"epoll_wait":              5209,
+		"eventfd":                 5278,
+		"eventfd2":                5284,
+		"execve":                  5057,
+		"execveat":                5316,
+		"exit":                    5058,
+		"exit_group":              5205,
+		"faccessat":               5259,
+		"faccessat2":              5439,
+		"fadvise64":               5215,
+		"fallocate":               5279,
+		"fanotify_init":           5295,
+		"fanotify_mark":           5296,
+		"fchdir":                  5079,
+		"fchmod":                  5089,
+		"fchmodat":                5258,
+		"fchmodat2":               5452,
+		"fchown":                  5091,
+		"fchownat":                5250,
+		"fcntl":                   5070,
+		"fdatasync":               5073,
+		"fgetxattr":               5185,
+		"file_getattr":            5468,
+		"file_setattr":            5469,
+		"finit_module":            5307,
+		"flistxattr":              5188,
+		"flock":                   5071,
+		"fork":                    5056,
+		"fremovexattr":            5191,
+		"fsconfig":                5431,
+		"fsetxattr":               5182,
+		"fsmount":                 5432,
+		"fsopen":                  5430,
+		"fspick":                  5433,
+		"fstat":                   5005,
+		"fstatfs":                 5135,
+		"fsync":                   5072,
+		"ftruncate":               5075,
+		"futex":                   5194,
+		"futex_requeue":           5456,
+		"futex_wait":              5455,
+		"futex_waitv":             5449,
+		"futex_wake":              5454,
+		"futimesat":               5251,
+		"get_kernel_syms":         5170,
+		"get_mempolicy":           5228,
+		"get_robust_list":         5269,
+		"getcpu":                  5271,
+		"getcwd":                  5077,
+		"getdents":                5076,
+		"getdents64":              5308,
+		"getegid":                 5106,
+		"geteuid":                 5105,
+		"getgid":                  5102,
+		"getgroups":               5113,
+		"getitimer":               5035,
+		"getpeername":             5051,
+		"getpgid":                 5119,
+		"getpgrp":                 5109,
+		"getpid":                  5038,
+		"getpmsg":                 5174,
+		"getppid":                 5108,
+		"getpriority":             5137,
+		"getrandom":               5313,
+		"getresgid":               5118,
+		"getresuid":               5116,
+		"getrlimit":               5095,
+		"getrusage":               5096,
+		"getsid":                  5122,
+		"getsockname":             5050,
+		"getsockopt":              5054,
+		"gettid":                  5178,
+		"gettimeofday":            5094,
+		"getuid":                  5100,
+		"getxattr":                5183,
+		"getxattrat":              5464,
+		"init_module":             5168,
+		"inotify_add_watch":       5244,
+		"inotify_init":            5243,
+		"inotify_init1":           5288,
+		"inotify_rm_watch":        5245,
+		"io_cancel":               5204,
+		"io_destroy":              5201,
+		"io_getevents":            5202,
+		"io_pgetevents":           5328,
+		"io_setup":                5200,
+		"io_submit":               5203,
+		"io_uring_enter":          5426,
+		"io_uring_register":       5427,
+		"io_uring_setup":          5425,
+		"ioctl":                   5015,
+		"ioprio_get":              5274,
+		"ioprio_set":              5273,
+		"kcmp":                    5306,
+		"kexec_load":              5270,
+		"keyctl":                  5241,
+		"kill":                    5060,
+		"landlock_add_rule":       5445,
+		"landlock_create_ruleset": 5444,
+		"landlock_restrict_self":  5446,
+		"lchown":                  5092,
+		"lgetxattr":               5184,
+		"link":                    5084,
+		"linkat":                  5255,
+		"listen":                  5049,
+		"listmount":               5458,
+		"listns":                  5470,
+		"listxattr":               5186,
+		"listxattrat":             5465,
+		"llistxattr":              5187,
+		"lookup_dcookie":          5206,
+		"lremovexattr":            5190,
+		"lseek":                   5008,
+		"lsetxattr":               5181,
+		"lsm_get_self_attr":       5459,
+		"lsm_list_modules":        5461,
+		"lsm_set_self_attr":       5460,
+		"lstat":                   5006,
+		"madvise":                 5027,
+		"map_shadow_stack":        5453,
+		"mbind":                   5227,
+		"membarrier":              5318,
+		"memfd_create":            5314,
+		"migrate_pages":           5246,
+		"mincore":                 5026,
+		"mkdir":                   5081,
+		"mkdirat":                 5248,
+		"mknod":                   5131,
+		"mknodat":                 5249,
+		"mlock":                   5146,
+		"mlock2":                  5319,
+		"mlockall":                5148,
+		"mmap":                    5009,
+		"mount":                   5160,
+		"mount_setattr":           5442,
+		"move_mount":              5429,
+		"move_pages":              5267,
+		"mprotect":                5010,
+		"mq_getsetattr":           5235,
+		"mq_notify":               5234,
+		"mq_open":                 5230,
+		"mq_timedreceive":         5233,
+		"mq_timedsend":            5232,
+		"mq_unlink":               5231,
+		"mremap":                  5024,
+		"mseal":                   5462,
+		"msgctl":                  5069,
+		"msgget":                  5066,
+		"msgrcv":                  5068,
+		"msgsnd":                  5067,
+		"msync":                   5025,
+		"munlock":                 5147,
+		"munlockall":              5149,
+		"munmap":                  5011,
+		"name_to_handle_at":       5298,
+		"nanosleep":               5034,
+		"newfstatat":              5252,
+		"nfsservctl":              5173,
+		"open":                    5002,
+		"open_by_handle_at":       5299,
+		"open_tree":               5428,
+		"open_tree_attr":          5467,
+		"openat":                  5247,
+		"openat2":                 5437,
+		"pause":                   5033,
+		"perf_event_open":         5292,
+		"personality":             5132,
+		"pidfd_getfd":             5438,
+		"pidfd_open":              5434,
+		"pidfd_send_signal":       5424,
+		"pipe":                    5021,
+		"pipe2":                   5287,
+		"pivot_root":              5151,
+		"pkey_alloc":              5324,
+		"pkey_free":               5325,
+		"pkey_mprotect":           5323,
+		"poll":                    5007,
+		"ppoll":                   5261,
+		"prctl":                   5153,
+		"pread64":                 5016,
+		"preadv":                  5289,
+		"preadv2":                 5321,
+		"prlimit64":               5297,
+		"process_madvise":         5440,
+		"process_mrelease":        5448,
+		"process_vm_readv":        5304,
+		"process_vm_writev":       5305,
+		"pselect6":                5260,
+		"ptrace":                  5099,
+		"putpmsg":                 5175,
+		"pwrite64":                5017,
+		"pwritev":                 5290,
+		"pwritev2":                5322,
+		"query_module":            5171,
+		"quotactl":                5172,
+		"quotactl_fd":             5443,
+		"read":                    5000,
+		"readahead":               5179,
+		"readlink":                5087,
+		"readlinkat":              5257,
+		"readv":                   5018,
+		"reboot":                  5164,
+		"recvfrom":                5044,
+		"recvmmsg":                5294,
+		"recvmsg":                 5046,
+		"remap_file_pages":        5210,
+		"removexattr":             5189,
+		"removexattrat":           5466,
+		"rename":                  5080,
+		"renameat":                5254,
+		"renameat2":               5311,
+		"request_key":             5240,
+		"reserved177":             5177,
+		"reserved193":             5193,
+		"restart_syscall":         5213,
+		"rmdir":                   5082,
+		"rseq":                    5327,
+		"rseq_slice_yield":        5471,
+		"rt_sigaction":            5013,
+		"rt_sigpending":           5125,
+		"rt_sigprocmask":          5014,
+		"rt_sigqueueinfo":         5127,
+		"rt_sigreturn":            5211,
+		"rt_sigsuspend":           5128,
+		"rt_sigtimedwait":         5126,
+		"rt_tgsigqueueinfo":       5291,
+		"sched_get_priority_max":  5143,
+		"sched_get_priority_min":  5144,
+		"sched_getaffinity":       5196,
+		"sched_getattr":           5310,
+		"sched_getparam":          5140,
+		"sched_getscheduler":      5142,
+		"sched_rr_get_interval":   5145,
+		"sched_setaffinity":       5195,
+		"sched_setattr":           5309,
+		"sched_setparam":          5139,
+		"sched_setscheduler":      5141,
+		"sched_yield":             5023,
+		"seccomp":                 5312,
+		"semctl":                  5064,
+		"semget":                  5062,
+		"semop":                   5063,
+		"semtimedop":              5214,
+		"sendfile":                5039,
+		"sendmmsg":                5302,
+		"sendmsg":                 5045,
+		"sendto":                  5043,
+		"set_mempolicy":           5229,
+		"set_mempolicy_home_node": 5450,
+		"set_robust_list":         5268,
+		"set_thread_area":         5242,
+		"set_tid_address":         5212,
+		"setdomainname":           5166,
+		"setfsgid":                5121,
+		"setfsuid":                5120,
+		"setgid":                  5104,
+		"setgroups":               5114,
+		"sethostname":             5165,
+		"setitimer":               5036,
+		"setns":                   5303,
+		"setpgid":                 5107,
+		"setpriority":             5138,
+		"setregid":                5112,
+		"setresgid":               5117,
+		"setresuid":               5115,
+		"setreuid":                5111,
+		"setrlimit":               5155,
+		"setsid":                  5110,
+		"setsockopt":              5053,
+		"settimeofday":            5159,
+		"setuid":                  5103,
+		"setxattr":                5180,
+		"setxattrat":              5463,
+		"shmat":                   5029,
+		"shmctl":                  5030,
+		"shmdt":                   5065,
+		"shmget":                  5028,
+		"shutdown":                5047,
+		"sigaltstack":             5129,
+		"signalfd":                5276,
+		"signalfd4":               5283,
+		"socket":                  5040,
+		"socketpair":              5052,
+		"splice":                  5263,
+		"stat":                    5004,
+		"statfs":                  5134,
+		"statmount":               5457,
+		"statx":                   5326,
+		"swapoff":                 5163,
+		"swapon":                  5162,
+		"symlink":                 5086,
+		"symlinkat":               5256,
+		"sync":                    5157,
+		"sync_file_range":         5264,
+		"syncfs":                  5301,
+		"sysfs":                   5136,
+		"sysinfo":                 5097,
+		"syslog":                  5101,
+		"sysmips":                 5199,
+		"tee":                     5265,
+		"tgkill":                  5225,
+		"timer_create":            5216,
+		"timer_delete":            5220,
+		"timer_getoverrun":        5219,
+		"timer_gettime":           5218,
+		"timer_settime":           5217,
+		"timerfd":                 5277,
+		"timerfd_create":          5280,
+		"timerfd_gettime":         5281,
+		"timerfd_settime":         5282,
+		"times":                   5098,
+		"tkill":                   5192,
+		"truncate":                5074,
+		"umask":                   5093,
+		"umount2":                 5161,
+		"uname":                   5061,
+		"unlink":                  5085,
+		"unlinkat":                5253,
+		"unshare":                 5262,
+		"userfaultfd":             5317,
+		"ustat":                   5133,
+		"utime":                   5130,
+		"utimensat":               5275,
+		"utimes":                  5226,
+		"vhangup":                 5150,
+		"vmsplice":                5266,
+		"vserver":                 5236,
+		"wait4":                   5059,
+		"waitid":                  5237,
+		"write":                   5001,
+		"writev":                  5019,
+	},
+	"mipsel64": {
+		"_newselect":              5022,
+		"_sysctl":                 5152,
+		"accept":                  5042,
+		"accept4":                 5293,
+		"access":                  5020,
+		"acct":                    5158,
+		"add_key":                 5239,
+		"adjtimex":                5154,
+		"afs_syscall":             5176,
+		"alarm":                   5037,
+		"bind":                    5048,
+		"bpf":                     5315,
+		"brk":                     5012,
+		"cachectl":                5198,
+		"cacheflush":              5197,
+		"cachestat":               5451,
+		"capget":                  5123,
+		"capset":                  5124,
+		"chdir":                   5078,
+		"chmod":                   5088,
+		"chown":                   5090,
+		"chroot":                  5156,
+		"clock_adjtime":           5300,
+		"clock_getres":            5223,
+		"clock_gettime":           5222,
+		"clock_nanosleep":         5224,
+		"clock_settime":           5221,
+		"clone":                   5055,
+		"clone3":                  5435,
+		"close":                   5003,
+		"close_range":             5436,
+		"connect":                 5041,
+		"copy_file_range":         5320,
+		"creat":                   5083,
+		"create_module":           5167,
+		"delete_module":           5169,
+		"dup":                     5031,
+		"dup2":                    5032,
+		"dup3":                    5286,
+		"epoll_create":            5207,
+		"epoll_create1":           5285,
+		"epoll_ctl":               5208,
+		"epoll_pwait":             5272,
+		"epoll_pwait2":            5441,
+		"epoll_wait":              5209,
+		"eventfd":                 5278,
+		"eventfd2":                5284,
+		"execve":                  5057,
+		"execveat":                5316,
+		"exit":                    5058,
+		"exit_group":              5205,
+		"faccessat":               5259,
+		"faccessat2":              5439,
+		"fadvise64":               5215,
+		"fallocate":               5279,
+		"fanotify_init":           5295,
+		"fanotify_mark":           5296,
+		"fchdir":                  5079,
+		"fchmod":                  5089,
+		"fchmodat":                5258,
+		"fchmodat2":               5452,
+		"fchown":                  5091,
+		"fchownat":                5250,
+		"fcntl":                   5070,
+		"fdatasync":               5073,
+		"fgetxattr":               5185,
+		"file_getattr":            5468,
+		"file_setattr":            5469,
+		"finit_module":            5307,
+		"flistxattr":              5188,
+		"flock":                   5071,
+		"fork":                    5056,
+		"fremovexattr":            5191,
+		"fsconfig":                5431,
+		"fsetxattr":               5182,
+		"fsmount":                 5432,
+		"fsopen":                  5430,
+		"fspick":                  5433,
+		"fstat":                   5005,
+		"fstatfs":                 5135,
+		"fsync":                   5072,
+		"ftruncate":               5075,
+		"futex":                   5194,
+		"futex_requeue":           5456,
+		"futex_wait":              5455,
+		"futex_waitv":             5449,
+		"futex_wake":              5454,
+		"futimesat":               5251,
+		"get_kernel_syms":         5170,
+		"get_mempolicy":           5228,
+		"get_robust_list":         5269,
+		"getcpu":                  5271,
+		"getcwd":                  5077,
+		"getdents":                5076,
+		"getdents64":              5308,
+		"getegid":                 5106,
+		"geteuid":                 5105,
+		"getgid":                  5102,
+		"getgroups":               5113,
+		"getitimer":               5035,
+		"getpeername":             5051,
+		"getpgid":                 5119,
+		"getpgrp":                 5109,
+		"getpid":                  5038,
+		"getpmsg":                 5174,
+		"getppid":                 5108,
+		"getpriority":             5137,
+		"getrandom":               5313,
+		"getresgid":               5118,
+		"getresuid":               5116,
+		"getrlimit":               5095,
+		"getrusage":               5096,
+		"getsid":                  5122,
+		"getsockname":             5050,
+		"getsockopt":              5054,
+		"gettid":                  5178,
+		"gettimeofday":            5094,
+		"getuid":                  5100,
+		"getxattr":                5183,
+		"getxattrat":              5464,
+		"init_module":             5168,
+		"inotify_add_watch":       5244,
+		"inotify_init":            5243,
+		"inotify_init1":           5288,
+		"inotify_rm_watch":        5245,
+		"io_cancel":               5204,
+		"io_destroy":              5201,
+		"io_getevents":            5202,
+		"io_pgetevents":           5328,
+		"io_setup":                5200,
+		"io_submit":               5203,
+		"io_uring_enter":          5426,
+		"io_uring_register":       5427,
+		"io_uring_setup":          5425,
+		"ioctl":                   5015,
+		"ioprio_get":              5274,
+		"ioprio_set":              5273,
+		"kcmp":                    5306,
+		"kexec_load":              5270,
+		"keyctl":                  5241,
+		"kill":                    5060,
+		"landlock_add_rule":       5445,
+		"landlock_create_ruleset": 5444,
+		"landlock_restrict_self":  5446,
+		"lchown":                  5092,
+		"lgetxattr":               5184,
+		"link":                    5084,
+		"linkat":                  5255,
+		"listen":                  5049,
+		"listmount":               5458,
+		"listns":                  5470,
+		"listxattr":               5186,
+		"listxattrat":             5465,
+		"llistxattr":              5187,
+		"lookup_dcookie":          5206,
+		"lremovexattr":            5190,
+		"lseek":                   5008,
+		"lsetxattr":               5181,
+		"lsm_get_self_attr":       5459,
+		"lsm_list_modules":        5461,
+		"lsm_set_self_attr":       5460,
+		"lstat":                   5006,
+		"madvise":                 5027,
+		"map_shadow_stack":        5453,
+		"mbind":                   5227,
+		"membarrier":              5318,
+		"memfd_create":            5314,
+		"migrate_pages":           5246,
+		"mincore":                 5026,
+		"mkdir":                   5081,
+		"mkdirat":                 5248,
+		"mknod":                   5131,
+		"mknodat":                 5249,
+		"mlock":                   5146,
+		"mlock2":                  5319,
+		"mlockall":                5148,
+		"mmap":                    5009,
+		"mount":                   5160,
+		"mount_setattr":           5442,
+		"move_mount":              5429,
+		"move_pages":              5267,
+		"mprotect":                5010,
+		"mq_getsetattr":           5235,
+		"mq_notify":               5234,
+		"mq_open":                 5230,
+		"mq_timedreceive":         5233,
+		"mq_timedsend":            5232,
+		"mq_unlink":               5231,
+		"mremap":                  5024,
+		"mseal":                   5462,
+		"msgctl":                  5069,
+		"msgget":                  5066,
+		"msgrcv":                  5068,
+		"msgsnd":                  5067,
+		"msync":                   5025,
+		"munlock":                 5147,
+		"munlockall":              5149,
+		"munmap":                  5011,
+		"name_to_handle_at":       5298,
+		"nanosleep":               5034,
+		"newfstatat":              5252,
+		"nfsservctl":              5173,
+		"open":                    5002,
+		"open_by_handle_at":       5299,
+		"open_tree":               5428,
+		"open_tree_attr":          5467,
+		"openat":                  5247,
+		"openat2":                 5437,
+		"pause":                   5033,
+		"perf_event_open":         5292,
+		"personality":             5132,
+		"pidfd_getfd":             5438,
+		"pidfd_open":              5434,
+		"pidfd_send_signal":       5424,
+		"pipe":                    5021,
+		"pipe2":                   5287,
+		"pivot_root":              5151,
+		"pkey_alloc":              5324,
+		"pkey_free":               5325,
+		"pkey_mprotect":           5323,
+		"poll":                    5007,
+		"ppoll":                   5261,
+		"prctl":                   5153,
+		"pread64":                 5016,
+		"preadv":                  5289,
+		"preadv2":                 5321,
+		"prlimit64":               5297,
+		"process_madvise":         5440,
+		"process_mrelease":        5448,
+		"process_vm_readv":        5304,
+		"process_vm_writev":       5305,
+		"pselect6":                5260,
+		"ptrace":                  5099,
+		"putpmsg":                 5175,
+		"pwrite64":                5017,
+		"pwritev":                 5290,
+		"pwritev2":                5322,
+		"query_module":            5171,
+		"quotactl":                5172,
+		"quotactl_fd":             5443,
+		"read":                    5000,
+		"readahead":               5179,
+		"readlink":                5087,
+		"readlinkat":              5257,
+		"readv":                   5018,
+		"reboot":                  5164,
+		"recvfrom":                5044,
+		"recvmmsg":                5294,
+		"recvmsg":                 5046,
+		"remap_file_pages":        5210,
+		"removexattr":             5189,
+		"removexattrat":           5466,
+		"rename":                  5080,
+		"renameat":                5254,
+		"renameat2":               5311,
+		"request_key":             5240,
+		"reserved177":             5177,
+		"reserved193":             5193,
+		"restart_syscall":         5213,
+		"rmdir":                   5082,
+		"rseq":                    5327,
+		"rseq_slice_yield":        5471,
+		"rt_sigaction":            5013,
+		"rt_sigpending":           5125,
+		"rt_sigprocmask":          5014,
+		"rt_sigqueueinfo":         5127,
+		"rt_sigreturn":            5211,
+		"rt_sigsuspend":           5128,
+		"rt_sigtimedwait":         5126,
+		"rt_tgsigqueueinfo":       5291,
+		"sched_get_priority_max":  5143,
+		"sched_get_priority_min":  5144,
+		"sched_getaffinity":       5196,
+		"sched_getattr":           5310,
+		"sched_getparam":          5140,
+		"sched_getscheduler":      5142,
+		"sched_rr_get_interval":   5145,
+		"sched_setaffinity":       5195,
+		"sched_setattr":           5309,
+		"sched_setparam":          5139,
+		"sched_setscheduler":      5141,
+		"sched_yield":             5023,
+		"seccomp":                 5312,
+		"semctl":                  5064,
+		"semget":                  5062,
+		"semop":                   5063,
+		"semtimedop":              5214,
+		"sendfile":                5039,
+		"sendmmsg":                5302,
+		"sendmsg":                 5045,
+		"sendto":                  5043,
+		"set_mempolicy":           5229,
+		"set_mempolicy_home_node": 5450,
+		"set_robust_list":         5268,
+		"set_thread_area":         5242,
+		"set_tid_address":         5212,
+		"setdomainname":           5166,
+		"setfsgid":                5121,
+		"setfsuid":                5120,
+		"setgid":                  5104,
+		"setgroups":               5114,
+		"sethostname":             5165,
+		"setitimer":               5036,
+		"setns":                   5303,
+		"setpgid":                 5107,
+		"setpriority":             5138,
+		"setregid":                5112,
+		"setresgid":               5117,
+		"setresuid":               5115,
+		"setreuid":                5111,
+		"setrlimit":               5155,
+		"setsid":                  5110,
+		"setsockopt":              5053,
+		"settimeofday":            5159,
+		"setuid":                  5103,
+		"setxattr":                5180,
+		"setxattrat":              5463,
+		"shmat":                   5029,
+		"shmctl":                  5030,
+		"shmdt":                   5065,
+		"shmget":                  5028,
+		"shutdown":                5047,
+		"sigaltstack":             5129,
+		"signalfd":                5276,
+		"signalfd4":               5283,
+		"socket":                  5040,
+		"socketpair":              5052,
+		"splice":                  5263,
+		"stat":                    5004,
+		"statfs":                  5134,
+		"statmount":               5457,
+		"statx":                   5326,
+		"swapoff":                 5163,
+		"swapon":                  5162,
+		"symlink":                 5086,
+		"symlinkat":               5256,
+		"sync":                    5157,
+		"sync_file_range":         5264,
+		"syncfs":                  5301,
+		"sysfs":                   5136,
+		"sysinfo":                 5097,
+		"syslog":                  5101,
+		"sysmips":                 5199,
+		"tee":                     5265,
+		"tgkill":                  5225,
+		"timer_create":            5216,
+		"timer_delete":            5220,
+		"timer_getoverrun":        5219,
+		"timer_gettime":           5218,
+		"timer_settime":           5217,
+		"timerfd":                 5277,
+		"timerfd_create":          5280,
+		"timerfd_gettime":         5281,
+		"timerfd_settime":         5282,
+		"times":                   5098,
+		"tkill":                   5192,
+		"truncate":                5074,
+		"umask":                   5093,
+		"umount2":                 5161,
+		"uname":                   5061,
+		"unlink":                  5085,
+		"unlinkat":                5253,
+		"unshare":                 5262,
+		"userfaultfd":             5317,
+		"ustat":                   5133,
+		"utime":                   5130,
+		"utimensat":               5275,
+		"utimes":                  5226,
+		"vhangup":                 5150,
+		"vmsplice":                5266,
+		"vserver":                 5236,
+		"wait4":                   5059,
+		"waitid":                  5237,
+		"write":                   5001,
+		"writev":                  5019,
+	},
+}
@@ -0,0 +1,75 @@
+// +build linux
+
+package libcontainer
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"syscall"
+
+	"github.com/opencontainers/runc/libcontainer/configs"
+	"github.com/opencontainers/runc/libcontainer/utils"
+)
+
+// seccompFdName is the name of the seccomp listener fd in
+// SeccompListenerState.Fds.
+const seccompFdName = "seccompFd"
+
+// SeccompListenerState is the message sent to the seccomp agent listening on
+// the unix socket at configs.Seccomp.ListenerPath. The listener fd of the
+// filter is passed along with it using SCM_RIGHTS.
+type SeccompListenerState struct {
+	// Version is the version of the specification the container config
+	// was generated from.
+	Version string `json:"ociVersion"`
+	// Fds names the file descriptors passed along with the message, in
+	// the order they were passed.
+	Fds []string `json:"fds"`
+	// Pid is the process that loaded the seccomp filter.
+	Pid int `json:"pid"`
+	// Metadata is configs.Seccomp.ListenerMetadata.
+	Metadata string `json:"metadata,omitempty"`
+	// State is the state of the container.
+	State configs.HookState `json:"state"`
+}
+
+// sendSeccompListener sends the seccomp listener fd of the process pid to the
+// seccomp agent, and closes it.
+func sendSeccompListener(config *initConfig, pid int, fd int) error {
+	defer syscall.Close(fd)
+	seccomp := config.Config.Seccomp
+	if seccomp == nil || seccomp.ListenerPath == "" {
+		return fmt.Errorf("no seccomp listener path configured")
+	}
+	data, err := json.Marshal(SeccompListenerState{
+		Version:  config.Config.Version,
+		Fds:      []string{seccompFdName},
+		Pid:      pid,
+		Metadata: seccomp.ListenerMetadata,
+		State: configs.HookState{
+			Version:    config.Config.Version,
+			ID:         config.ContainerId,
+			Pid:        pid,
+			Root:       config.Config.Rootfs,
+			BundlePath: utils.SearchLabels(config.Config.Labels, "bundle"),
+		},
+	})
+	if err != nil {
+		return err
+	}
+	conn, err := net.DialUnix("unix", nil, &net.UnixAddr{Name: seccomp.ListenerPath, Net: "unix"})
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	oob := syscall.UnixRights(fd)
+	n, oobn, err := conn.WriteMsgUnix(data, oob, nil)
+	if err != nil {
+		return err
+	}
+	if n != len(data) || oobn != len(oob) {
+		return fmt.Errorf("short write of seccomp listener state to %s", seccomp.ListenerPath)
+	}
+	return nil
+}
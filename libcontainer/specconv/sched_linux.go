@@ -0,0 +1,77 @@
+// +build linux
+
+package specconv
+
+import (
+	"fmt"
+
+	"github.com/opencontainers/runc/libcontainer/configs"
+)
+
+var schedPolicyMap = map[LinuxSchedulerPolicy]configs.SchedPolicy{
+	SchedOther:    configs.SchedOther,
+	SchedFIFO:     configs.SchedFIFO,
+	SchedRR:       configs.SchedRR,
+	SchedBatch:    configs.SchedBatch,
+	SchedIdle:     configs.SchedIdle,
+	SchedDeadline: configs.SchedDeadline,
+}
+
+var ioprioClassMap = map[IOPriorityClass]configs.IOPrioClass{
+	IOPRIO_CLASS_RT:   configs.IOPrioClassRT,
+	IOPRIO_CLASS_BE:   configs.IOPrioClassBE,
+	IOPRIO_CLASS_IDLE: configs.IOPrioClassIdle,
+}
+
+// CreateLibcontainerScheduler converts the scheduling attributes of a process
+// to the ones of libcontainer, checking they are in range for the policy.
+func CreateLibcontainerScheduler(s Scheduler) (*configs.Scheduler, error) {
+	policy, ok := schedPolicyMap[s.Policy]
+	if !ok {
+		return nil, fmt.Errorf("Wrong scheduling policy: %s", s.Policy)
+	}
+	if s.Nice < -20 || s.Nice > 19 {
+		return nil, fmt.Errorf("nice value %d is out of range [-20, 19]", s.Nice)
+	}
+	switch policy {
+	case configs.SchedFIFO, configs.SchedRR:
+		if s.Priority < 1 || s.Priority > 99 {
+			return nil, fmt.Errorf("priority %d is out of range [1, 99] for %s", s.Priority, s.Policy)
+		}
+	default:
+		if s.Priority != 0 {
+			return nil, fmt.Errorf("priority can only be set for SCHED_FIFO and SCHED_RR")
+		}
+	}
+	if policy == configs.SchedDeadline {
+		if s.Runtime == 0 || s.Deadline < s.Runtime || (s.Period != 0 && s.Period < s.Deadline) {
+			return nil, fmt.Errorf("SCHED_DEADLINE requires 0 < runtime <= deadline <= period")
+		}
+	} else if s.Runtime != 0 || s.Deadline != 0 || s.Period != 0 {
+		return nil, fmt.Errorf("runtime, deadline and period can only be set for SCHED_DEADLINE")
+	}
+	return &configs.Scheduler{
+		Policy:   policy,
+		Nice:     int(s.Nice),
+		Priority: int(s.Priority),
+		Runtime:  s.Runtime,
+		Deadline: s.Deadline,
+		Period:   s.Period,
+	}, nil
+}
+
+// CreateLibcontainerIOPriority converts the I/O priority of a process to the
+// one of libcontainer.
+func CreateLibcontainerIOPriority(p LinuxIOPriority) (*configs.IOPriority, error) {
+	class, ok := ioprioClassMap[p.Class]
+	if !ok {
+		return nil, fmt.Errorf("Wrong io priority class: %s", p.Class)
+	}
+	if p.Priority < 0 || p.Priority > 7 {
+		return nil, fmt.Errorf("io priority level %d is out of range [0, 7]", p.Priority)
+	}
+	return &configs.IOPriority{
+		Class: class,
+		Level: p.Priority,
+	}, nil
+}
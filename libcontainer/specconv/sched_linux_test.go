@@ -0,0 +1,89 @@
+// +build linux
+
+package specconv
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/opencontainers/runc/libcontainer/configs"
+)
+
+func TestCreateLibcontainerScheduler(t *testing.T) {
+	s, err := CreateLibcontainerScheduler(Scheduler{Policy: SchedFIFO, Priority: 50, Nice: -5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Policy != configs.SchedFIFO || s.Priority != 50 || s.Nice != -5 {
+		t.Errorf("Expected SCHED_FIFO with priority 50 and nice -5, got %+v", s)
+	}
+
+	s, err = CreateLibcontainerScheduler(Scheduler{Policy: SchedDeadline, Runtime: 10, Deadline: 20, Period: 30})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Policy != configs.SchedDeadline || s.Runtime != 10 || s.Deadline != 20 || s.Period != 30 {
+		t.Errorf("Expected SCHED_DEADLINE with runtime 10, deadline 20 and period 30, got %+v", s)
+	}
+
+	for _, invalid := range []Scheduler{
+		{Policy: "SCHED_BOGUS"},
+		// SCHED_ISO is not implemented by Linux.
+		{Policy: SchedISO},
+		{Policy: SchedOther, Nice: 20},
+		{Policy: SchedOther, Nice: -21},
+		{Policy: SchedOther, Priority: 1},
+		{Policy: SchedRR},
+		{Policy: SchedRR, Priority: 100},
+		{Policy: SchedDeadline},
+		{Policy: SchedDeadline, Runtime: 20, Deadline: 10},
+		{Policy: SchedDeadline, Runtime: 10, Deadline: 30, Period: 20},
+		{Policy: SchedBatch, Runtime: 10},
+	} {
+		if _, err := CreateLibcontainerScheduler(invalid); err == nil {
+			t.Errorf("Expected an error for %+v", invalid)
+		}
+	}
+}
+
+func TestCreateLibcontainerIOPriority(t *testing.T) {
+	p, err := CreateLibcontainerIOPriority(LinuxIOPriority{Class: IOPRIO_CLASS_IDLE, Priority: 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Class != configs.IOPrioClassIdle || p.Level != 7 {
+		t.Errorf("Expected the idle class with level 7, got %+v", p)
+	}
+
+	for _, invalid := range []LinuxIOPriority{
+		{Class: "IOPRIO_CLASS_BOGUS"},
+		{Class: IOPRIO_CLASS_BE, Priority: -1},
+		{Class: IOPRIO_CLASS_RT, Priority: 8},
+	} {
+		if _, err := CreateLibcontainerIOPriority(invalid); err == nil {
+			t.Errorf("Expected an error for %+v", invalid)
+		}
+	}
+}
+
+func TestDecodeProcessScheduling(t *testing.T) {
+	var spec Spec
+	data := `{"process": {"args": ["sh"], "scheduler": {"policy": "SCHED_RR", "priority": 1}, "ioPriority": {"class": "IOPRIO_CLASS_BE", "priority": 2}, "execCPUAffinity": {"initial": "0", "final": "1-3"}}}`
+	if err := json.Unmarshal([]byte(data), &spec); err != nil {
+		t.Fatal(err)
+	}
+
+	p := spec.Process
+	if len(p.Args) != 1 || p.Args[0] != "sh" {
+		t.Errorf("Expected the args of the process to be decoded, got %v", p.Args)
+	}
+	if p.Scheduler == nil || p.Scheduler.Policy != SchedRR || p.Scheduler.Priority != 1 {
+		t.Errorf("Expected SCHED_RR with priority 1, got %+v", p.Scheduler)
+	}
+	if p.IOPriority == nil || p.IOPriority.Class != IOPRIO_CLASS_BE || p.IOPriority.Priority != 2 {
+		t.Errorf("Expected the best-effort class with level 2, got %+v", p.IOPriority)
+	}
+	if a := p.ExecCPUAffinity; a == nil || a.Initial != "0" || a.Final != "1-3" {
+		t.Errorf("Expected the initial and final affinities 0 and 1-3, got %+v", a)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"syscall"
@@ -22,6 +23,37 @@ import (
 
 const wildcard = -1
 
+// systemdPropertyPrefix prefixes the annotations setting properties of the
+// systemd unit of the container, such as "org.systemd.property.CPUWeight".
+const systemdPropertyPrefix = "org.systemd.property."
+
+// The annotations making runc generate /etc/hosts, /etc/hostname and
+// /etc/resolv.conf for the container, bind mounted over the ones of the rootfs
+// unless the spec mounts something on them. The files are generated when
+// etcFilesAnnotation is "ro" or "rw", to bind mount them read-only or
+// read-write. The other annotations are comma separated lists: the
+// hostname=address entries added to /etc/hosts, and the nameservers, search
+// domains and options of /etc/resolv.conf, which derives from the one of the
+// host when none of them is set.
+const (
+	etcFilesAnnotation            = "org.opencontainers.runc.etcfiles"
+	etcFilesHostsAnnotation       = "org.opencontainers.runc.etcfiles.hosts"
+	etcFilesNameserversAnnotation = "org.opencontainers.runc.etcfiles.nameservers"
+	etcFilesSearchAnnotation      = "org.opencontainers.runc.etcfiles.search"
+	etcFilesOptionsAnnotation     = "org.opencontainers.runc.etcfiles.options"
+)
+
+// The annotations assembling the root filesystem at the path of the root from
+// layers with overlayfs. The read-only layers are separated by colons, the
+// topmost first. The upper and work directories receive the changes made by
+// the container; when they are omitted, changes are kept in memory and lost
+// with the container. Relative paths are relative to the bundle.
+const (
+	overlayLowerDirsAnnotation = "org.opencontainers.runc.overlay.lowerdirs"
+	overlayUpperDirAnnotation  = "org.opencontainers.runc.overlay.upperdir"
+	overlayWorkDirAnnotation   = "org.opencontainers.runc.overlay.workdir"
+)
+
 var namespaceMapping = map[specs.NamespaceType]configs.NamespaceType{
 	specs.PIDNamespace:     configs.NEWPID,
 	specs.NetworkNamespace: configs.NEWNET,
@@ -29,6 +61,7 @@ var namespaceMapping = map[specs.NamespaceType]configs.NamespaceType{
 	specs.UserNamespace:    configs.NEWUSER,
 	specs.IPCNamespace:     configs.NEWIPC,
 	specs.UTSNamespace:     configs.NEWUTS,
+	TimeNamespace:          configs.NEWTIME,
 }
 
 var mountPropagationMapping = map[string]int{
@@ -145,7 +178,11 @@ type CreateOpts struct {
 	CgroupName       string
 	UseSystemdCgroup bool
 	NoPivotRoot      bool
-	Spec             *specs.Spec
+	Init             bool
+	Spec             *Spec
+	// EtcFilesDir is the directory the /etc files of the container are
+	// generated in, when its annotations ask for them.
+	EtcFilesDir string
 }
 
 // CreateLibcontainerConfig creates a new libcontainer configuration from a
@@ -168,11 +205,15 @@ func CreateLibcontainerConfig(opts *CreateOpts) (*configs.Config, error) {
 	config := &configs.Config{
 		Rootfs:      rootfsPath,
 		NoPivotRoot: opts.NoPivotRoot,
+		Init:        opts.Init,
 		Readonlyfs:  spec.Root.Readonly,
 		Hostname:    spec.Hostname,
-		Labels: []string{
-			"bundle=" + cwd,
-		},
+		Labels:      createLabels(cwd, spec.Annotations),
+	}
+	if lower := spec.Annotations[overlayLowerDirsAnnotation]; lower != "" {
+		config.RootfsOverlay = createRootfsOverlay(cwd, lower, spec.Annotations)
+	} else if spec.Annotations[overlayUpperDirAnnotation] != "" || spec.Annotations[overlayWorkDirAnnotation] != "" {
+		return nil, fmt.Errorf("the overlay upper and work directories require the %s annotation", overlayLowerDirsAnnotation)
 	}
 
 	exists := false
@@ -187,6 +228,15 @@ func CreateLibcontainerConfig(opts *CreateOpts) (*configs.Config, error) {
 		}
 		config.Namespaces.Add(t, ns.Path)
 	}
+	for clock, offset := range spec.Linux.TimeOffsets {
+		if config.TimeOffsets == nil {
+			config.TimeOffsets = make(map[string]configs.TimeOffset)
+		}
+		config.TimeOffsets[clock] = configs.TimeOffset{
+			Secs:     offset.Secs,
+			Nanosecs: offset.Nanosecs,
+		}
+	}
 	if config.Namespaces.Contains(configs.NEWNET) {
 		config.Networks = []*configs.Network{
 			{
@@ -195,7 +245,12 @@ func CreateLibcontainerConfig(opts *CreateOpts) (*configs.Config, error) {
 		}
 	}
 	for _, m := range spec.Mounts {
-		config.Mounts = append(config.Mounts, createLibcontainerMount(cwd, m))
+		config.Mounts = append(config.Mounts, CreateLibcontainerMount(cwd, m))
+	}
+	if mode := spec.Annotations[etcFilesAnnotation]; mode != "" {
+		if err := createEtcFiles(mode, opts.EtcFilesDir, spec.Annotations, config); err != nil {
+			return nil, err
+		}
 	}
 	if err := createDevices(spec, config); err != nil {
 		return nil, err
@@ -234,8 +289,11 @@ func CreateLibcontainerConfig(opts *CreateOpts) (*configs.Config, error) {
 	return config, nil
 }
 
-func createLibcontainerMount(cwd string, m specs.Mount) *configs.Mount {
-	flags, pgflags, data := parseMountOptions(m.Options)
+// CreateLibcontainerMount converts the OCI mount m to a libcontainer mount. The
+// relative source of a bind mount is resolved against cwd.
+func CreateLibcontainerMount(cwd string, m specs.Mount) *configs.Mount {
+	recAttrSet, recAttrClr, idmap, options := parseMountAttrOptions(m.Options)
+	flags, pgflags, data := parseMountOptions(options)
 	source := m.Source
 	if m.Type == "bind" {
 		if !filepath.IsAbs(source) {
@@ -249,10 +307,123 @@ func createLibcontainerMount(cwd string, m specs.Mount) *configs.Mount {
 		Data:             data,
 		Flags:            flags,
 		PropagationFlags: pgflags,
+		RecAttrSet:       recAttrSet,
+		RecAttrClr:       recAttrClr,
+		IDMapping:        idmap,
+	}
+}
+
+// createLabels returns the labels of the config of a container: the path of
+// its bundle followed by the annotations of its spec, sorted by key.
+func createLabels(bundle string, annotations map[string]string) []string {
+	labels := []string{"bundle=" + bundle}
+	var keys []string
+	for key := range annotations {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		labels = append(labels, key+"="+annotations[key])
+	}
+	return labels
+}
+
+// createEtcFiles sets the /etc files of the container from the annotations,
+// and bind mounts the ones the spec does not mount anything on from dir.
+func createEtcFiles(mode, dir string, annotations map[string]string, config *configs.Config) error {
+	flags := syscall.MS_BIND | syscall.MS_NOSUID | syscall.MS_NODEV | syscall.MS_NOEXEC
+	switch mode {
+	case "ro":
+		flags |= syscall.MS_RDONLY
+	case "rw":
+	default:
+		return fmt.Errorf("invalid %s annotation %q, expected ro or rw", etcFilesAnnotation, mode)
+	}
+	if dir == "" {
+		return fmt.Errorf("the %s annotation is not supported by this command", etcFilesAnnotation)
+	}
+	list := func(key string) []string {
+		if v := annotations[key]; v != "" {
+			return strings.Split(v, ",")
+		}
+		return nil
+	}
+	config.EtcFiles = &configs.EtcFiles{
+		Dir:         dir,
+		Nameservers: list(etcFilesNameserversAnnotation),
+		Search:      list(etcFilesSearchAnnotation),
+		Options:     list(etcFilesOptionsAnnotation),
+	}
+	for _, h := range list(etcFilesHostsAnnotation) {
+		parts := strings.SplitN(h, "=", 2)
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid host %q in the %s annotation, expected hostname=address", h, etcFilesHostsAnnotation)
+		}
+		config.EtcFiles.ExtraHosts = append(config.EtcFiles.ExtraHosts, configs.HostEntry{
+			Hostname: parts[0],
+			IP:       parts[1],
+		})
+	}
+
+	mounted := make(map[string]bool)
+	for _, m := range config.Mounts {
+		mounted[filepath.Clean(m.Destination)] = true
+	}
+	for _, name := range []string{"hosts", "hostname", "resolv.conf"} {
+		dest := filepath.Join("/etc", name)
+		if mounted[dest] {
+			continue
+		}
+		config.Mounts = append(config.Mounts, &configs.Mount{
+			Source:      filepath.Join(dir, name),
+			Destination: dest,
+			Device:      "bind",
+			Flags:       flags,
+		})
+	}
+	return nil
+}
+
+// createRootfsOverlay returns the overlay root filesystem with the layers
+// lower, and the upper and work directories set by the annotations.
+func createRootfsOverlay(cwd, lower string, annotations map[string]string) *configs.RootfsOverlay {
+	abs := func(dir string) string {
+		if dir != "" && !filepath.IsAbs(dir) {
+			return filepath.Join(cwd, dir)
+		}
+		return dir
+	}
+	o := &configs.RootfsOverlay{
+		UpperDir: abs(annotations[overlayUpperDirAnnotation]),
+		WorkDir:  abs(annotations[overlayWorkDirAnnotation]),
 	}
+	for _, dir := range strings.Split(lower, ":") {
+		o.LowerDirs = append(o.LowerDirs, abs(dir))
+	}
+	return o
+}
+
+// createSystemdProperties returns the properties of the systemd unit set by
+// the annotations, sorted by name.
+func createSystemdProperties(annotations map[string]string) []configs.SystemdProperty {
+	var names []string
+	for key := range annotations {
+		if strings.HasPrefix(key, systemdPropertyPrefix) && len(key) > len(systemdPropertyPrefix) {
+			names = append(names, strings.TrimPrefix(key, systemdPropertyPrefix))
+		}
+	}
+	sort.Strings(names)
+	var properties []configs.SystemdProperty
+	for _, name := range names {
+		properties = append(properties, configs.SystemdProperty{
+			Name:  name,
+			Value: annotations[systemdPropertyPrefix+name],
+		})
+	}
+	return properties
 }
 
-func createCgroupConfig(name string, useSystemdCgroup bool, spec *specs.Spec) (*configs.Cgroup, error) {
+func createCgroupConfig(name string, useSystemdCgroup bool, spec *Spec) (*configs.Cgroup, error) {
 	var (
 		err          error
 		myCgroupPath string
@@ -285,6 +456,7 @@ func createCgroupConfig(name string, useSystemdCgroup bool, spec *specs.Spec) (*
 			c.ScopePrefix = parts[1]
 			c.Name = parts[2]
 		}
+		c.SystemdProperties = createSystemdProperties(spec.Annotations)
 	} else {
 		if myCgroupPath == "" {
 			myCgroupPath, err = cgroups.GetThisCgroupDir("devices")
@@ -455,7 +627,7 @@ func stringToDeviceRune(s string) (rune, error) {
 	}
 }
 
-func createDevices(spec *specs.Spec, config *configs.Config) error {
+func createDevices(spec *Spec, config *configs.Config) error {
 	// add whitelisted devices
 	config.Devices = []*configs.Device{
 		{
@@ -540,7 +712,7 @@ func createDevices(spec *specs.Spec, config *configs.Config) error {
 	return nil
 }
 
-func setupUserNamespace(spec *specs.Spec, config *configs.Config) error {
+func setupUserNamespace(spec *Spec, config *configs.Config) error {
 	if len(spec.Linux.UIDMappings) == 0 {
 		return nil
 	}
@@ -572,6 +744,53 @@ func setupUserNamespace(spec *specs.Spec, config *configs.Config) error {
 	return nil
 }
 
+// parseMountAttrOptions parses the options applied with mount_setattr(2): the
+// recursive attributes set and cleared, and the idmapping. It returns them
+// along with the other options.
+func parseMountAttrOptions(options []string) (uint64, uint64, *configs.MountIDMapping, []string) {
+	var (
+		set, clr uint64
+		idmap    *configs.MountIDMapping
+		rest     []string
+	)
+	recAttrs := map[string]struct {
+		clear bool
+		attr  uint64
+	}{
+		"rro":          {false, configs.MountAttrRdonly},
+		"rrw":          {true, configs.MountAttrRdonly},
+		"rnosuid":      {false, configs.MountAttrNosuid},
+		"rsuid":        {true, configs.MountAttrNosuid},
+		"rnodev":       {false, configs.MountAttrNodev},
+		"rdev":         {true, configs.MountAttrNodev},
+		"rnoexec":      {false, configs.MountAttrNoexec},
+		"rexec":        {true, configs.MountAttrNoexec},
+		"rnosymfollow": {false, configs.MountAttrNosymfollow},
+		"rsymfollow":   {true, configs.MountAttrNosymfollow},
+	}
+	for _, o := range options {
+		if a, exists := recAttrs[o]; exists {
+			if a.clear {
+				set &= ^a.attr
+				clr |= a.attr
+			} else {
+				clr &= ^a.attr
+				set |= a.attr
+			}
+			continue
+		}
+		switch o {
+		case "idmap":
+			idmap = &configs.MountIDMapping{}
+		case "ridmap":
+			idmap = &configs.MountIDMapping{Recursive: true}
+		default:
+			rest = append(rest, o)
+		}
+	}
+	return set, clr, idmap, rest
+}
+
 // parseMountOptions parses the string and returns the flags, propagation
 // flags and any mount data that it contains.
 func parseMountOptions(options []string) (int, []int, string) {
@@ -642,7 +861,7 @@ func parseMountOptions(options []string) (int, []int, string) {
 	return flag, pgflag, strings.Join(data, ",")
 }
 
-func setupSeccomp(config *specs.Seccomp) (*configs.Seccomp, error) {
+func setupSeccomp(config *Seccomp) (*configs.Seccomp, error) {
 	if config == nil {
 		return nil, nil
 	}
@@ -672,6 +891,17 @@ func setupSeccomp(config *specs.Seccomp) (*configs.Seccomp, error) {
 		return nil, err
 	}
 	newConfig.DefaultAction = newDefaultAction
+	newConfig.DefaultErrnoRet = config.DefaultErrnoRet
+	newConfig.ListenerPath = config.ListenerPath
+	newConfig.ListenerMetadata = config.ListenerMetadata
+
+	for _, flag := range config.Flags {
+		newFlag, err := seccomp.ConvertStringToFlag(string(flag))
+		if err != nil {
+			return nil, err
+		}
+		newConfig.Flags = append(newConfig.Flags, newFlag)
+	}
 
 	// Loop through all syscall blocks and convert them to libcontainer format
 	for _, call := range config.Syscalls {
@@ -681,9 +911,10 @@ func setupSeccomp(config *specs.Seccomp) (*configs.Seccomp, error) {
 		}
 
 		newCall := configs.Syscall{
-			Name:   call.Name,
-			Action: newAction,
-			Args:   []*configs.Arg{},
+			Name:     call.Name,
+			Action:   newAction,
+			ErrnoRet: call.ErrnoRet,
+			Args:     []*configs.Arg{},
 		}
 
 		// Loop through all the arguments of the syscall and convert them
@@ -709,7 +940,7 @@ func setupSeccomp(config *specs.Seccomp) (*configs.Seccomp, error) {
 	return newConfig, nil
 }
 
-func createHooks(rspec *specs.Spec, config *configs.Config) {
+func createHooks(rspec *Spec, config *configs.Config) {
 	config.Hooks = &configs.Hooks{}
 	for _, h := range rspec.Hooks.Prestart {
 		cmd := createCommandHook(h)
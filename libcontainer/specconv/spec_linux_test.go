@@ -3,16 +3,21 @@
 package specconv
 
 import (
+	"encoding/json"
+	"os"
+	"path/filepath"
 	"strings"
+	"syscall"
 	"testing"
 
+	"github.com/opencontainers/runc/libcontainer/configs"
 	"github.com/opencontainers/runtime-spec/specs-go"
 )
 
 func TestLinuxCgroupsPathSpecified(t *testing.T) {
 	cgroupsPath := "/user/cgroups/path/id"
 
-	spec := &specs.Spec{}
+	spec := &Spec{}
 	spec.Linux.CgroupsPath = &cgroupsPath
 
 	cgroup, err := createCgroupConfig("ContainerID", false, spec)
@@ -26,7 +31,7 @@ func TestLinuxCgroupsPathSpecified(t *testing.T) {
 }
 
 func TestLinuxCgroupsPathNotSpecified(t *testing.T) {
-	spec := &specs.Spec{}
+	spec := &Spec{}
 
 	cgroup, err := createCgroupConfig("ContainerID", false, spec)
 	if err != nil {
@@ -37,3 +42,288 @@ func TestLinuxCgroupsPathNotSpecified(t *testing.T) {
 		t.Errorf("Wrong cgroupsPath, expected it to have suffix '%s' got '%s'", "/ContainerID", cgroup.Path)
 	}
 }
+
+func TestSystemdPropertiesFromAnnotations(t *testing.T) {
+	spec := &Spec{}
+	spec.Annotations = map[string]string{
+		"org.systemd.property.TimeoutStopUSec": "uint64 123456789",
+		"org.systemd.property.CollectMode":     "'inactive-or-failed'",
+		"org.systemd.property.":                "true",
+		"com.example.key":                      "value",
+	}
+
+	cgroup, err := createCgroupConfig("ContainerID", true, spec)
+	if err != nil {
+		t.Fatalf("Couldn't create Cgroup config: %v", err)
+	}
+
+	expected := []configs.SystemdProperty{
+		{Name: "CollectMode", Value: "'inactive-or-failed'"},
+		{Name: "TimeoutStopUSec", Value: "uint64 123456789"},
+	}
+	if len(cgroup.SystemdProperties) != len(expected) {
+		t.Fatalf("expected systemd properties %v, got %v", expected, cgroup.SystemdProperties)
+	}
+	for i, p := range expected {
+		if cgroup.SystemdProperties[i] != p {
+			t.Errorf("expected systemd property %v, got %v", p, cgroup.SystemdProperties[i])
+		}
+	}
+}
+
+func TestCreateLabels(t *testing.T) {
+	labels := createLabels("/bundle", map[string]string{
+		"service":  "web",
+		"owner":    "alice",
+		"revision": "a=b",
+	})
+	expected := []string{"bundle=/bundle", "owner=alice", "revision=a=b", "service=web"}
+	if len(labels) != len(expected) {
+		t.Fatalf("expected labels %v, got %v", expected, labels)
+	}
+	for i, l := range expected {
+		if labels[i] != l {
+			t.Errorf("expected label %q, got %q", l, labels[i])
+		}
+	}
+}
+
+func TestSetupSeccomp(t *testing.T) {
+	enosys := uint(38)
+	conf := &Seccomp{
+		Seccomp:         specs.Seccomp{DefaultAction: specs.ActErrno},
+		DefaultErrnoRet: &enosys,
+		Flags:           []SeccompFlag{SeccompFlagLog},
+		Syscalls: []Syscall{
+			{Syscall: specs.Syscall{Name: "clone3", Action: specs.ActErrno}, ErrnoRet: &enosys},
+			{Syscall: specs.Syscall{Name: "ptrace", Action: ActLog}},
+			{Syscall: specs.Syscall{Name: "kexec_load", Action: ActKillProcess}},
+		},
+	}
+
+	seccomp, err := setupSeccomp(conf)
+	if err != nil {
+		t.Fatalf("Couldn't create Seccomp config: %v", err)
+	}
+
+	if seccomp.DefaultErrnoRet == nil || *seccomp.DefaultErrnoRet != enosys {
+		t.Errorf("Expected default errno %d, got %v", enosys, seccomp.DefaultErrnoRet)
+	}
+	if len(seccomp.Flags) != 1 || seccomp.Flags[0] != configs.FlagLog {
+		t.Errorf("Expected flags [%d], got %v", configs.FlagLog, seccomp.Flags)
+	}
+	if len(seccomp.Syscalls) != 3 {
+		t.Fatalf("Expected 3 syscalls, got %d", len(seccomp.Syscalls))
+	}
+	if ret := seccomp.Syscalls[0].ErrnoRet; ret == nil || *ret != enosys {
+		t.Errorf("Expected clone3 errno %d, got %v", enosys, ret)
+	}
+	if seccomp.Syscalls[1].Action != configs.Log {
+		t.Errorf("Expected ptrace action %d, got %d", configs.Log, seccomp.Syscalls[1].Action)
+	}
+	if seccomp.Syscalls[2].Action != configs.KillProcess {
+		t.Errorf("Expected kexec_load action %d, got %d", configs.KillProcess, seccomp.Syscalls[2].Action)
+	}
+}
+
+func TestDecodeSeccomp(t *testing.T) {
+	var spec Spec
+	data := `{"linux": {"seccomp": {"defaultAction": "SCMP_ACT_ERRNO", "defaultErrnoRet": 38, "flags": ["SECCOMP_FILTER_FLAG_LOG"], "syscalls": [{"name": "clone3", "action": "SCMP_ACT_ERRNO", "errnoRet": 38}]}}}`
+	if err := json.Unmarshal([]byte(data), &spec); err != nil {
+		t.Fatal(err)
+	}
+
+	s := spec.Linux.Seccomp
+	if s == nil || s.DefaultAction != specs.ActErrno || s.DefaultErrnoRet == nil || *s.DefaultErrnoRet != 38 {
+		t.Fatalf("Expected the default action and errno to be decoded, got %+v", s)
+	}
+	if len(s.Flags) != 1 || s.Flags[0] != SeccompFlagLog {
+		t.Errorf("Expected flags [%s], got %v", SeccompFlagLog, s.Flags)
+	}
+	if len(s.Syscalls) != 1 || s.Syscalls[0].Name != "clone3" || s.Syscalls[0].ErrnoRet == nil || *s.Syscalls[0].ErrnoRet != 38 {
+		t.Errorf("Expected clone3 to be decoded with its errno, got %+v", s.Syscalls)
+	}
+}
+
+func TestSetupSeccompNotify(t *testing.T) {
+	conf := &Seccomp{
+		Seccomp:          specs.Seccomp{DefaultAction: specs.ActAllow},
+		ListenerPath:     "/run/seccomp-agent.sock",
+		ListenerMetadata: "foo",
+		Syscalls: []Syscall{
+			{Syscall: specs.Syscall{Name: "mount", Action: ActNotify}},
+		},
+	}
+
+	seccomp, err := setupSeccomp(conf)
+	if err != nil {
+		t.Fatalf("Couldn't create Seccomp config: %v", err)
+	}
+
+	if seccomp.ListenerPath != conf.ListenerPath || seccomp.ListenerMetadata != conf.ListenerMetadata {
+		t.Errorf("Expected listener %q with metadata %q, got %q with %q", conf.ListenerPath, conf.ListenerMetadata, seccomp.ListenerPath, seccomp.ListenerMetadata)
+	}
+	if seccomp.Syscalls[0].Action != configs.Notify {
+		t.Errorf("Expected mount action %d, got %d", configs.Notify, seccomp.Syscalls[0].Action)
+	}
+}
+
+func TestSetupSeccompInvalidFlag(t *testing.T) {
+	conf := &Seccomp{
+		Seccomp: specs.Seccomp{DefaultAction: specs.ActAllow},
+		Flags:   []SeccompFlag{"SECCOMP_FILTER_FLAG_BOGUS"},
+	}
+
+	if _, err := setupSeccomp(conf); err == nil {
+		t.Error("Expected error for invalid seccomp flag")
+	}
+}
+
+func TestTimeNamespace(t *testing.T) {
+	spec := &Spec{}
+	spec.Root.Path = "rootfs"
+	spec.Linux.Namespaces = []specs.Namespace{
+		{Type: specs.MountNamespace},
+		{Type: TimeNamespace},
+	}
+	spec.Linux.TimeOffsets = map[string]LinuxTimeOffset{
+		"monotonic": {Secs: -3600},
+		"boottime":  {Secs: 42, Nanosecs: 500},
+	}
+
+	config, err := CreateLibcontainerConfig(&CreateOpts{
+		CgroupName: "ContainerID",
+		Spec:       spec,
+	})
+	if err != nil {
+		t.Fatalf("Couldn't create libcontainer config: %v", err)
+	}
+
+	if !config.Namespaces.Contains(configs.NEWTIME) {
+		t.Errorf("Expected the config to contain a TIME namespace, got %v", config.Namespaces)
+	}
+	if len(config.TimeOffsets) != 2 {
+		t.Fatalf("Expected 2 time offsets, got %v", config.TimeOffsets)
+	}
+	if offset := config.TimeOffsets["monotonic"]; offset.Secs != -3600 || offset.Nanosecs != 0 {
+		t.Errorf("Expected a monotonic offset of -3600s, got %+v", offset)
+	}
+	if offset := config.TimeOffsets["boottime"]; offset.Secs != 42 || offset.Nanosecs != 500 {
+		t.Errorf("Expected a boottime offset of 42s 500ns, got %+v", offset)
+	}
+}
+
+func TestMountAttrOptions(t *testing.T) {
+	m := CreateLibcontainerMount("/", specs.Mount{
+		Destination: "/data",
+		Type:        "bind",
+		Source:      "/var/lib",
+		Options:     []string{"rbind", "ro", "rro", "rnosuid", "rexec", "ridmap", "size=1k"},
+	})
+	if m.Flags != syscall.MS_BIND|syscall.MS_REC|syscall.MS_RDONLY {
+		t.Errorf("Expected the flags of a read-only rbind, got %#x", m.Flags)
+	}
+	if m.RecAttrSet != configs.MountAttrRdonly|configs.MountAttrNosuid {
+		t.Errorf("Expected rdonly and nosuid to be set recursively, got %#x", m.RecAttrSet)
+	}
+	if m.RecAttrClr != configs.MountAttrNoexec {
+		t.Errorf("Expected noexec to be cleared recursively, got %#x", m.RecAttrClr)
+	}
+	if m.IDMapping == nil || !m.IDMapping.Recursive {
+		t.Errorf("Expected a recursive idmapping, got %+v", m.IDMapping)
+	}
+	if m.Data != "size=1k" {
+		t.Errorf("Expected only size=1k to be left as mount data, got %q", m.Data)
+	}
+}
+
+func TestRootOverlay(t *testing.T) {
+	spec := &Spec{}
+	spec.Root.Path = "rootfs"
+	spec.Annotations = map[string]string{
+		overlayLowerDirsAnnotation: "layers/1:/var/lib/layers/0",
+		overlayUpperDirAnnotation:  "upper",
+		overlayWorkDirAnnotation:   "/var/lib/work",
+	}
+
+	config, err := CreateLibcontainerConfig(&CreateOpts{
+		CgroupName: "ContainerID",
+		Spec:       spec,
+	})
+	if err != nil {
+		t.Fatalf("Couldn't create libcontainer config: %v", err)
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	o := config.RootfsOverlay
+	if o == nil {
+		t.Fatal("Expected an overlay rootfs")
+	}
+	if len(o.LowerDirs) != 2 || o.LowerDirs[0] != filepath.Join(cwd, "layers/1") || o.LowerDirs[1] != "/var/lib/layers/0" {
+		t.Errorf("Expected lower directories relative to the bundle, got %v", o.LowerDirs)
+	}
+	if o.UpperDir != filepath.Join(cwd, "upper") || o.WorkDir != "/var/lib/work" {
+		t.Errorf("Expected upper and work directories relative to the bundle, got %q and %q", o.UpperDir, o.WorkDir)
+	}
+}
+
+func TestEtcFiles(t *testing.T) {
+	spec := &Spec{}
+	spec.Mounts = []specs.Mount{
+		{Destination: "/etc/hostname", Type: "bind", Source: "/srv/hostname", Options: []string{"bind"}},
+	}
+	spec.Annotations = map[string]string{
+		etcFilesAnnotation:            "ro",
+		etcFilesHostsAnnotation:       "db=172.17.0.3,cache=172.17.0.4",
+		etcFilesNameserversAnnotation: "1.1.1.1",
+		etcFilesSearchAnnotation:      "a.example,b.example",
+	}
+
+	config, err := CreateLibcontainerConfig(&CreateOpts{
+		CgroupName:  "ContainerID",
+		Spec:        spec,
+		EtcFilesDir: "/run/runc/ContainerID/etc",
+	})
+	if err != nil {
+		t.Fatalf("Couldn't create libcontainer config: %v", err)
+	}
+
+	e := config.EtcFiles
+	if e == nil || e.Dir != "/run/runc/ContainerID/etc" {
+		t.Fatalf("Expected the /etc files to be generated in the given directory, got %+v", e)
+	}
+	if len(e.ExtraHosts) != 2 || e.ExtraHosts[1] != (configs.HostEntry{Hostname: "cache", IP: "172.17.0.4"}) {
+		t.Errorf("Expected two extra hosts, got %+v", e.ExtraHosts)
+	}
+	if len(e.Nameservers) != 1 || len(e.Search) != 2 || e.Options != nil {
+		t.Errorf("Expected a nameserver and two search domains, got %+v", e)
+	}
+	if len(config.Mounts) != 3 {
+		t.Fatalf("Expected the hosts and resolv.conf mounts to be added, got %d mounts", len(config.Mounts))
+	}
+	for _, m := range config.Mounts[1:] {
+		if m.Source != filepath.Join(e.Dir, filepath.Base(m.Destination)) {
+			t.Errorf("Expected %s to be mounted from the directory of the files, got %s", m.Destination, m.Source)
+		}
+		if m.Flags&syscall.MS_RDONLY == 0 {
+			t.Errorf("Expected %s to be mounted read-only", m.Destination)
+		}
+	}
+
+	for _, annotations := range []map[string]string{
+		{etcFilesAnnotation: "yes"},
+		{etcFilesAnnotation: "rw", etcFilesHostsAnnotation: "db"},
+	} {
+		spec.Annotations = annotations
+		if _, err := CreateLibcontainerConfig(&CreateOpts{CgroupName: "ContainerID", Spec: spec, EtcFilesDir: "/run/etc"}); err == nil {
+			t.Errorf("Expected an error for the annotations %v", annotations)
+		}
+	}
+	spec.Annotations = map[string]string{etcFilesAnnotation: "rw"}
+	if _, err := CreateLibcontainerConfig(&CreateOpts{CgroupName: "ContainerID", Spec: spec}); err == nil {
+		t.Error("Expected an error without a directory for the /etc files")
+	}
+}
@@ -0,0 +1,147 @@
+// +build linux
+
+package specconv
+
+import "github.com/opencontainers/runtime-spec/specs-go"
+
+// Spec is the specification runc reads from the bundle. It is the one of the
+// vendored runtime-spec, extended with the settings of later versions of the
+// specification that runc supports. The fields below shadow the ones of the
+// same name of specs.Spec.
+type Spec struct {
+	specs.Spec
+	// Process is the container's main process.
+	Process Process `json:"process"`
+	// Linux is platform specific configuration for Linux based containers.
+	Linux Linux `json:"linux" platform:"linux"`
+}
+
+// Process is specs.Process with the scheduling attributes, the I/O priority
+// and the CPU affinity of later versions of the specification.
+type Process struct {
+	specs.Process
+	// Scheduler specifies the scheduling attributes for a process
+	Scheduler *Scheduler `json:"scheduler,omitempty" platform:"linux"`
+	// IOPriority contains the I/O priority settings for the process.
+	IOPriority *LinuxIOPriority `json:"ioPriority,omitempty" platform:"linux"`
+	// ExecCPUAffinity specifies CPU affinity for exec processes.
+	ExecCPUAffinity *CPUAffinity `json:"execCPUAffinity,omitempty" platform:"linux"`
+}
+
+// LinuxSchedulerPolicy represents different scheduling policies used with the Linux Scheduler
+type LinuxSchedulerPolicy string
+
+const (
+	// SchedOther is the default scheduling policy
+	SchedOther LinuxSchedulerPolicy = "SCHED_OTHER"
+	// SchedFIFO is the First-In-First-Out scheduling policy
+	SchedFIFO LinuxSchedulerPolicy = "SCHED_FIFO"
+	// SchedRR is the Round-Robin scheduling policy
+	SchedRR LinuxSchedulerPolicy = "SCHED_RR"
+	// SchedBatch is the Batch scheduling policy
+	SchedBatch LinuxSchedulerPolicy = "SCHED_BATCH"
+	// SchedISO is the Isolation scheduling policy
+	SchedISO LinuxSchedulerPolicy = "SCHED_ISO"
+	// SchedIdle is the Idle scheduling policy
+	SchedIdle LinuxSchedulerPolicy = "SCHED_IDLE"
+	// SchedDeadline is the Deadline scheduling policy
+	SchedDeadline LinuxSchedulerPolicy = "SCHED_DEADLINE"
+)
+
+// Scheduler represents the scheduling attributes for a process. It is based on
+// the Linux sched_setattr(2) syscall.
+type Scheduler struct {
+	// Policy represents the scheduling policy (e.g., SCHED_FIFO, SCHED_RR, SCHED_OTHER).
+	Policy LinuxSchedulerPolicy `json:"policy"`
+	// Nice is the nice value for the process, which affects its priority.
+	Nice int32 `json:"nice,omitempty"`
+	// Priority represents the static priority of the process.
+	Priority int32 `json:"priority,omitempty"`
+	// Runtime is the amount of time in nanoseconds during which the process
+	// is allowed to run in a given period.
+	Runtime uint64 `json:"runtime,omitempty"`
+	// Deadline is the absolute deadline for the process to complete its execution.
+	Deadline uint64 `json:"deadline,omitempty"`
+	// Period is the length of the period in nanoseconds used for determining the process runtime.
+	Period uint64 `json:"period,omitempty"`
+}
+
+// IOPriorityClass represents an I/O scheduling class.
+type IOPriorityClass string
+
+// Possible values for IOPriorityClass.
+const (
+	IOPRIO_CLASS_RT   IOPriorityClass = "IOPRIO_CLASS_RT"
+	IOPRIO_CLASS_BE   IOPriorityClass = "IOPRIO_CLASS_BE"
+	IOPRIO_CLASS_IDLE IOPriorityClass = "IOPRIO_CLASS_IDLE"
+)
+
+// LinuxIOPriority represents ioprio_set(2) configuration.
+type LinuxIOPriority struct {
+	Class    IOPriorityClass `json:"class"`
+	Priority int             `json:"priority"`
+}
+
+// CPUAffinity specifies process' CPU affinity.
+type CPUAffinity struct {
+	Initial string `json:"initial,omitempty"`
+	Final   string `json:"final,omitempty"`
+}
+
+// Linux is specs.Linux with the settings of later versions of the
+// specification.
+type Linux struct {
+	specs.Linux
+	// Seccomp specifies the seccomp security settings for the container.
+	Seccomp *Seccomp `json:"seccomp,omitempty"`
+	// TimeOffsets specifies the offset for supporting time namespaces.
+	TimeOffsets map[string]LinuxTimeOffset `json:"timeOffsets,omitempty"`
+}
+
+// TimeNamespace for isolating the clocks
+const TimeNamespace specs.NamespaceType = "time"
+
+// LinuxTimeOffset specifies the offset for Time Namespace
+type LinuxTimeOffset struct {
+	// Secs is the offset of clock (in secs) in the container
+	Secs int64 `json:"secs,omitempty"`
+	// Nanosecs is the additional offset for Secs (in nanosecs)
+	Nanosecs uint32 `json:"nanosecs,omitempty"`
+}
+
+// Seccomp is specs.Seccomp with the default errno return value, the filter
+// flags and the seccomp agent of later versions of the specification.
+type Seccomp struct {
+	specs.Seccomp
+	DefaultErrnoRet *uint         `json:"defaultErrnoRet,omitempty"`
+	Flags           []SeccompFlag `json:"flags,omitempty"`
+	// ListenerPath is the unix socket of the seccomp agent the listener fd
+	// of the filter is sent to, along with ListenerMetadata.
+	ListenerPath     string    `json:"listenerPath,omitempty"`
+	ListenerMetadata string    `json:"listenerMetadata,omitempty"`
+	Syscalls         []Syscall `json:"syscalls,omitempty"`
+}
+
+// SeccompFlag is a flag to pass to seccomp(2) when loading the filter
+type SeccompFlag string
+
+// Define flags for loading Seccomp filters
+const (
+	SeccompFlagTsync     SeccompFlag = "SECCOMP_FILTER_FLAG_TSYNC"
+	SeccompFlagLog       SeccompFlag = "SECCOMP_FILTER_FLAG_LOG"
+	SeccompFlagSpecAllow SeccompFlag = "SECCOMP_FILTER_FLAG_SPEC_ALLOW"
+)
+
+// Define the actions for Seccomp rules missing from specs
+const (
+	ActKillProcess specs.Action = "SCMP_ACT_KILL_PROCESS"
+	ActLog         specs.Action = "SCMP_ACT_LOG"
+	ActNotify      specs.Action = "SCMP_ACT_NOTIFY"
+)
+
+// Syscall is specs.Syscall with the errno return value of later versions of
+// the specification.
+type Syscall struct {
+	specs.Syscall
+	ErrnoRet *uint `json:"errnoRet,omitempty"`
+}
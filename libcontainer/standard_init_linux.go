@@ -4,7 +4,6 @@ package libcontainer
 
 import (
 	"fmt"
-	"io"
 	"os"
 	"syscall"
 
@@ -17,7 +16,7 @@ import (
 )
 
 type linuxStandardInit struct {
-	pipe      io.ReadWriter
+	pipe      *os.File
 	parentPid int
 	config    *initConfig
 }
@@ -117,6 +116,9 @@ func (l *linuxStandardInit) Init() error {
 			return err
 		}
 	}
+	if err := setupScheduler(l.config); err != nil {
+		return err
+	}
 	// Tell our parent that we're ready to Execv. This must be done before the
 	// Seccomp rules have been applied, because we need to be able to read and
 	// write to a socket.
@@ -127,7 +129,11 @@ func (l *linuxStandardInit) Init() error {
 	// do this before dropping capabilities; otherwise do it as late as possible
 	// just before execve so as few syscalls take place after it as possible.
 	if l.config.Config.Seccomp != nil && !l.config.NoNewPrivileges {
-		if err := seccomp.InitSeccomp(l.config.Config.Seccomp); err != nil {
+		seccompFd, err := seccomp.InitSeccomp(l.config.Config.Seccomp)
+		if err != nil {
+			return err
+		}
+		if err := syncParentSeccomp(l.pipe, seccompFd); err != nil {
 			return err
 		}
 	}
@@ -146,10 +152,19 @@ func (l *linuxStandardInit) Init() error {
 		return syscall.Kill(syscall.Getpid(), syscall.SIGKILL)
 	}
 	if l.config.Config.Seccomp != nil && l.config.NoNewPrivileges {
-		if err := seccomp.InitSeccomp(l.config.Config.Seccomp); err != nil {
+		seccompFd, err := seccomp.InitSeccomp(l.config.Config.Seccomp)
+		if err != nil {
+			return err
+		}
+		if err := syncParentSeccomp(l.pipe, seccompFd); err != nil {
 			return err
 		}
 	}
 
+	if l.config.Config.Init {
+		// The seccomp filter is inherited by the process, but it already
+		// applies to the init, which must be allowed to start it.
+		return runInit(l.pipe, l.config.Args, os.Environ(), l.config.Console != "")
+	}
 	return system.Execv(l.config.Args[0], l.config.Args[0:], os.Environ())
 }
@@ -4,10 +4,13 @@ package libcontainer
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/Sirupsen/logrus"
+	"github.com/opencontainers/runc/libcontainer/cgroups"
 	"github.com/opencontainers/runc/libcontainer/configs"
 	"github.com/opencontainers/runc/libcontainer/utils"
 )
@@ -37,15 +40,26 @@ type containerState interface {
 }
 
 func destroy(c *linuxContainer) error {
-	if !c.config.Namespaces.Contains(configs.NEWPID) {
-		if err := killCgroupProcesses(c.cgroupManager); err != nil {
-			logrus.Warn(err)
-		}
+	var err error
+	// Without a pid namespace the processes of the container do not die with
+	// its init, the cgroups are all that is left to find them. With one, the
+	// processes left in the cgroups keep them from being removed, but they
+	// are only killed when no other container runs in the cgroups.
+	if !c.config.Namespaces.Contains(configs.NEWPID) || c.ownsCgroups() {
+		err = killCgroupProcesses(c.cgroupManager)
+	}
+	if perr := c.closePerfCounters(); perr != nil {
+		logrus.Warn(perr)
+	}
+	if cerr := c.cgroupManager.Destroy(); err == nil {
+		err = cerr
 	}
-	err := c.cgroupManager.Destroy()
 	if rerr := os.RemoveAll(c.root); err == nil {
 		err = rerr
 	}
+	if oerr := cleanupRootfsOverlay(c.config); err == nil {
+		err = oerr
+	}
 	c.initProcess = nil
 	if herr := runPoststopHooks(c); err == nil {
 		err = herr
@@ -54,6 +68,68 @@ func destroy(c *linuxContainer) error {
 	return err
 }
 
+// ownsCgroups reports whether the cgroups of the container are its own: they
+// were created for it, are not the root cgroups and neither hold nor are held
+// by the cgroups of another container of the same root.
+func (c *linuxContainer) ownsCgroups() bool {
+	if c.config.Cgroups == nil || c.config.Cgroups.Paths != nil {
+		return false
+	}
+	paths := c.cgroupManager.GetPaths()
+	for name, path := range paths {
+		if mnt, err := cgroups.FindCgroupMountpoint(name); err == nil && filepath.Clean(mnt) == filepath.Clean(path) {
+			return false
+		}
+	}
+	root := filepath.Dir(c.root)
+	dirs, err := ioutil.ReadDir(root)
+	if err != nil {
+		return false
+	}
+	for _, dir := range dirs {
+		if !dir.IsDir() || dir.Name() == c.id {
+			continue
+		}
+		// The lock of this container is held, so the other containers are
+		// not locked, see cpusetAllocator. Their cgroups do not change once
+		// they are created.
+		state, err := readStateFile(filepath.Join(root, dir.Name(), stateFilename))
+		if err != nil {
+			continue
+		}
+		for name, path := range state.CgroupPaths {
+			if own, ok := paths[name]; ok && (isSubpath(own, path) || isSubpath(path, own)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// isSubpath reports whether path is dir or a path below it.
+func isSubpath(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, "../")
+}
+
+// DestroyNetworks removes what is left on the host of networks, the networks
+// of a stopped container. The host interfaces of veth networks are only left
+// when the network namespace of the container outlives it, and their names
+// may have been reused since then, so it is up to the caller to check that no
+// other container uses them.
+func DestroyNetworks(networks []*configs.Network) error {
+	for _, n := range networks {
+		s, err := getStrategy(n.Type)
+		if err != nil {
+			return err
+		}
+		if err := s.destroy(n); err != nil {
+			return newSystemErrorWithCausef(err, "removing network interface %q", n.HostInterfaceName)
+		}
+	}
+	return nil
+}
+
 func runPoststopHooks(c *linuxContainer) error {
 	if c.config.Hooks != nil {
 		s := configs.HookState{
@@ -62,11 +138,7 @@ func runPoststopHooks(c *linuxContainer) error {
 			Root:       c.config.Rootfs,
 			BundlePath: utils.SearchLabels(c.config.Labels, "bundle"),
 		}
-		for _, hook := range c.config.Hooks.Poststop {
-			if err := hook.Run(s); err != nil {
-				return err
-			}
-		}
+		return c.runHooks("poststop", c.config.Hooks.Poststop, s)
 	}
 	return nil
 }
@@ -2,7 +2,15 @@
 
 package libcontainer
 
-import "testing"
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opencontainers/runc/libcontainer/configs"
+)
 
 func TestStateStatus(t *testing.T) {
 	states := map[containerState]Status{
@@ -77,3 +85,47 @@ func TestRestoredStateTransition(t *testing.T) {
 		t.Fatal("expected stateTransitionError")
 	}
 }
+
+func TestContainerOwnsCgroups(t *testing.T) {
+	root, err := ioutil.TempDir("", "libcontainer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	other := filepath.Join(root, "other")
+	if err := os.Mkdir(other, 0700); err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(&State{
+		StateVersion: stateVersion,
+		CgroupPaths:  map[string]string{"memory": "/cgroups/memory/pod/other"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(other, stateFilename), data, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	for path, owned := range map[string]bool{
+		"/cgroups/memory/pod/own":         true,
+		"/cgroups/memory/pod/other":       false,
+		"/cgroups/memory/pod":             false,
+		"/cgroups/memory/pod/other/child": false,
+	} {
+		c := &linuxContainer{
+			id:            "own",
+			root:          filepath.Join(root, "own"),
+			config:        &configs.Config{Cgroups: &configs.Cgroup{}},
+			cgroupManager: &mockCgroupManager{paths: map[string]string{"memory": path}},
+		}
+		if c.ownsCgroups() != owned {
+			t.Errorf("expected the ownership of %s to be %v", path, owned)
+		}
+		// Cgroups joined by path belong to whoever created them.
+		c.config.Cgroups.Paths = map[string]string{"memory": path}
+		if c.ownsCgroups() {
+			t.Errorf("expected the joined cgroup %s not to be owned", path)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+// +build linux
+
+package libcontainer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+)
+
+// stateVersion is the version of the format of the state.json written by this
+// libcontainer. States written before the format was versioned are version 0.
+const stateVersion = 1
+
+// stateMigrations[i] migrates a state from version i to version i+1. States
+// are migrated as generic JSON documents so that the fields renamed or
+// removed since they were written are still at hand.
+var stateMigrations = []func(state map[string]interface{}) error{
+	migrateDeprecatedCgroupFields,
+}
+
+// readStateFile reads the state stored at path and migrates it to the current
+// format.
+func readStateFile(path string) (*State, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return migrateState(data)
+}
+
+// migrateState decodes a stored state, migrating it to the current format.
+func migrateState(data []byte) (*State, error) {
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	version := 0
+	if v, ok := doc["state_version"].(float64); ok {
+		version = int(v)
+	}
+	if version > stateVersion {
+		return nil, fmt.Errorf("state version %d is newer than the supported version %d", version, stateVersion)
+	}
+	if version < stateVersion {
+		for i := version; i < stateVersion; i++ {
+			if err := stateMigrations[i](doc); err != nil {
+				return nil, fmt.Errorf("migrating state from version %d: %v", i, err)
+			}
+		}
+		doc["state_version"] = stateVersion
+		var err error
+		if data, err = json.Marshal(doc); err != nil {
+			return nil, err
+		}
+	}
+	var state *State
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// migrateDeprecatedCgroupFields moves the rules of the deprecated
+// allow_all_devices, allowed_devices and denied_devices fields of the cgroup
+// configuration to devices, and the deprecated name and parent to path for
+// cgroups not managed by systemd, which still names its units after them.
+func migrateDeprecatedCgroupFields(state map[string]interface{}) error {
+	config, _ := state["config"].(map[string]interface{})
+	cgroup, _ := config["cgroups"].(map[string]interface{})
+	if cgroup == nil {
+		return nil
+	}
+	if devices, _ := cgroup["devices"].([]interface{}); len(devices) == 0 {
+		if _, ok := cgroup["allow_all_devices"]; ok || cgroup["allowed_devices"] != nil || cgroup["denied_devices"] != nil {
+			allowAll, _ := cgroup["allow_all_devices"].(bool)
+			except, _ := cgroup["allowed_devices"].([]interface{})
+			if allowAll {
+				except, _ = cgroup["denied_devices"].([]interface{})
+			}
+			devices = []interface{}{map[string]interface{}{
+				"type":        'a',
+				"major":       -1,
+				"minor":       -1,
+				"permissions": "rwm",
+				"allow":       allowAll,
+			}}
+			for _, d := range except {
+				dev, ok := d.(map[string]interface{})
+				if !ok {
+					return fmt.Errorf("invalid device rule %v", d)
+				}
+				rule := make(map[string]interface{})
+				for k, v := range dev {
+					rule[k] = v
+				}
+				rule["allow"] = !allowAll
+				devices = append(devices, rule)
+			}
+			cgroup["devices"] = devices
+		}
+	}
+	delete(cgroup, "allow_all_devices")
+	delete(cgroup, "allowed_devices")
+	delete(cgroup, "denied_devices")
+
+	name, _ := cgroup["name"].(string)
+	parent, _ := cgroup["parent"].(string)
+	if path, _ := cgroup["path"].(string); path != "" || (name == "" && parent == "") {
+		return nil
+	}
+	paths, _ := state["cgroup_paths"].(map[string]interface{})
+	for _, p := range paths {
+		if p, ok := p.(string); ok && strings.HasSuffix(p, ".scope") {
+			return nil
+		}
+	}
+	cgroup["path"] = filepath.Join(parent, name)
+	delete(cgroup, "name")
+	delete(cgroup, "parent")
+	return nil
+}
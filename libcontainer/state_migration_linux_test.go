@@ -0,0 +1,89 @@
+// +build linux
+
+package libcontainer
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/opencontainers/runc/libcontainer/configs"
+)
+
+func TestMigrateStateDeprecatedDevices(t *testing.T) {
+	for _, test := range []struct {
+		cgroup   string
+		expected []*configs.Device
+	}{
+		{
+			`{"allowed_devices": [{"type": 99, "major": 1, "minor": 3, "permissions": "rwm"}]}`,
+			[]*configs.Device{
+				{Type: 'a', Major: -1, Minor: -1, Permissions: "rwm"},
+				{Type: 'c', Major: 1, Minor: 3, Permissions: "rwm", Allow: true},
+			},
+		},
+		{
+			`{"allow_all_devices": true, "allowed_devices": [{"type": 99, "major": 1, "minor": 3}], "denied_devices": [{"type": 98, "major": 8, "minor": 0, "permissions": "w"}]}`,
+			[]*configs.Device{
+				{Type: 'a', Major: -1, Minor: -1, Permissions: "rwm", Allow: true},
+				{Type: 'b', Major: 8, Minor: 0, Permissions: "w"},
+			},
+		},
+		{
+			// Devices take precedence over the deprecated fields.
+			`{"allowed_devices": [{"type": 99, "major": 1, "minor": 3}], "devices": [{"type": 97, "major": -1, "minor": -1, "permissions": "rwm", "allow": true}]}`,
+			[]*configs.Device{
+				{Type: 'a', Major: -1, Minor: -1, Permissions: "rwm", Allow: true},
+			},
+		},
+	} {
+		state, err := migrateState([]byte(`{"id": "test", "config": {"cgroups": ` + test.cgroup + `}}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		r := state.Config.Cgroups.Resources
+		if r.AllowAllDevices || r.AllowedDevices != nil || r.DeniedDevices != nil {
+			t.Errorf("expected the deprecated device fields of %s to be cleared, got %+v", test.cgroup, r)
+		}
+		if !reflect.DeepEqual(r.Devices, test.expected) {
+			got, _ := json.Marshal(r.Devices)
+			t.Errorf("expected the devices of %s to migrate to %+v, got %s", test.cgroup, test.expected, got)
+		}
+		if state.StateVersion != stateVersion {
+			t.Errorf("expected state version %d, got %d", stateVersion, state.StateVersion)
+		}
+	}
+}
+
+func TestMigrateStateDeprecatedCgroupName(t *testing.T) {
+	state, err := migrateState([]byte(`{"config": {"cgroups": {"parent": "/runc", "name": "test"}}, "cgroup_paths": {"memory": "/sys/fs/cgroup/memory/runc/test"}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c := state.Config.Cgroups; c.Path != "/runc/test" || c.Name != "" || c.Parent != "" {
+		t.Errorf("expected name and parent to migrate to path /runc/test, got %+v", c)
+	}
+
+	// systemd still names the unit of the container after them.
+	state, err = migrateState([]byte(`{"config": {"cgroups": {"parent": "system.slice", "name": "test", "scope_prefix": "runc"}}, "cgroup_paths": {"memory": "/sys/fs/cgroup/memory/system.slice/runc-test.scope"}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c := state.Config.Cgroups; c.Path != "" || c.Name != "test" || c.Parent != "system.slice" {
+		t.Errorf("expected name and parent of systemd cgroups to be kept, got %+v", c)
+	}
+}
+
+func TestMigrateStateCurrentVersion(t *testing.T) {
+	// States of the current version are not migrated.
+	state, err := migrateState([]byte(`{"state_version": 1, "config": {"cgroups": {"allowed_devices": [{"type": 99, "major": 1, "minor": 3}]}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := state.Config.Cgroups.Resources; len(r.AllowedDevices) != 1 || r.Devices != nil {
+		t.Errorf("expected a current state to be kept as is, got %+v", r)
+	}
+	if _, err := migrateState([]byte(`{"state_version": 1000}`)); err == nil {
+		t.Error("expected a state of a newer version to be rejected")
+	}
+}
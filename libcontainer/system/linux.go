@@ -141,3 +141,21 @@ func Prctl(option int, arg2, arg3, arg4, arg5 uintptr) (err error) {
 	}
 	return
 }
+
+// Symlinkat creates a symbolic link named name in the directory dirfd,
+// pointing to target.
+func Symlinkat(target string, dirfd int, name string) error {
+	t, err := syscall.BytePtrFromString(target)
+	if err != nil {
+		return err
+	}
+	n, err := syscall.BytePtrFromString(name)
+	if err != nil {
+		return err
+	}
+	_, _, e1 := syscall.Syscall(syscall.SYS_SYMLINKAT, uintptr(unsafe.Pointer(t)), uintptr(dirfd), uintptr(unsafe.Pointer(n)))
+	if e1 != 0 {
+		return e1
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+// +build linux
+
+package system
+
+import (
+	"runtime"
+	"syscall"
+	"unsafe"
+)
+
+// mountSyscalls are the numbers of the new mount API syscalls, which the
+// syscall package does not define. They are the same on the architectures
+// sharing the generic syscall numbers, but mips offsets them by the base of
+// its ABI.
+var mountSyscalls = map[string]struct {
+	openTree, moveMount, mountSetattr uintptr
+}{
+	"386":      {428, 429, 442},
+	"amd64":    {428, 429, 442},
+	"arm":      {428, 429, 442},
+	"arm64":    {428, 429, 442},
+	"mips":     {4428, 4429, 4442},
+	"mipsle":   {4428, 4429, 4442},
+	"mips64":   {5428, 5429, 5442},
+	"mips64le": {5428, 5429, 5442},
+	"ppc64":    {428, 429, 442},
+	"ppc64le":  {428, 429, 442},
+	"s390x":    {428, 429, 442},
+}[runtime.GOARCH]
+
+var (
+	sysOpenTree     = mountSyscalls.openTree
+	sysMoveMount    = mountSyscalls.moveMount
+	sysMountSetattr = mountSyscalls.mountSetattr
+)
+
+const (
+	OPEN_TREE_CLONE   = 0x1
+	OPEN_TREE_CLOEXEC = syscall.O_CLOEXEC
+
+	MOVE_MOUNT_F_EMPTY_PATH = 0x4
+	MOVE_MOUNT_T_EMPTY_PATH = 0x40
+
+	MOUNT_ATTR_IDMAP = 0x00100000
+
+	AT_FDCWD      = -0x64
+	AT_EMPTY_PATH = 0x1000
+	AT_RECURSIVE  = 0x8000
+)
+
+// MountAttr is struct mount_attr, from linux/mount.h.
+type MountAttr struct {
+	AttrSet     uint64
+	AttrClr     uint64
+	Propagation uint64
+	UsernsFd    uint64
+}
+
+// OpenTree calls open_tree(2) and returns the fd of the mount, detached from
+// any mount namespace if OPEN_TREE_CLONE is set.
+func OpenTree(dirfd int, path string, flags int) (int, error) {
+	if sysOpenTree == 0 {
+		return -1, syscall.ENOSYS
+	}
+	p, err := syscall.BytePtrFromString(path)
+	if err != nil {
+		return -1, err
+	}
+	fd, _, e1 := syscall.Syscall(sysOpenTree, uintptr(dirfd), uintptr(unsafe.Pointer(p)), uintptr(flags))
+	if e1 != 0 {
+		return -1, e1
+	}
+	return int(fd), nil
+}
+
+// MoveMount calls move_mount(2) to attach the mount at fromPath to toPath.
+func MoveMount(fromDirfd int, fromPath string, toDirfd int, toPath string, flags int) error {
+	if sysMoveMount == 0 {
+		return syscall.ENOSYS
+	}
+	from, err := syscall.BytePtrFromString(fromPath)
+	if err != nil {
+		return err
+	}
+	to, err := syscall.BytePtrFromString(toPath)
+	if err != nil {
+		return err
+	}
+	_, _, e1 := syscall.Syscall6(sysMoveMount, uintptr(fromDirfd), uintptr(unsafe.Pointer(from)), uintptr(toDirfd), uintptr(unsafe.Pointer(to)), uintptr(flags), 0)
+	if e1 != 0 {
+		return e1
+	}
+	return nil
+}
+
+// MountSetattr calls mount_setattr(2) to change the attributes of the mount
+// at path, and of its submounts if flags has AT_RECURSIVE.
+func MountSetattr(dirfd int, path string, flags int, attr *MountAttr) error {
+	if sysMountSetattr == 0 {
+		return syscall.ENOSYS
+	}
+	p, err := syscall.BytePtrFromString(path)
+	if err != nil {
+		return err
+	}
+	_, _, e1 := syscall.Syscall6(sysMountSetattr, uintptr(dirfd), uintptr(unsafe.Pointer(p)), uintptr(flags), uintptr(unsafe.Pointer(attr)), unsafe.Sizeof(*attr), 0)
+	if e1 != 0 {
+		return e1
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+// +build linux
+
+package system
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+const (
+	PERF_TYPE_SOFTWARE = 1
+
+	PERF_COUNT_SW_CPU_CLOCK        = 0
+	PERF_COUNT_SW_TASK_CLOCK       = 1
+	PERF_COUNT_SW_PAGE_FAULTS      = 2
+	PERF_COUNT_SW_CONTEXT_SWITCHES = 3
+	PERF_COUNT_SW_CPU_MIGRATIONS   = 4
+	PERF_COUNT_SW_PAGE_FAULTS_MIN  = 5
+	PERF_COUNT_SW_PAGE_FAULTS_MAJ  = 6
+
+	PERF_FLAG_PID_CGROUP = 0x4
+	PERF_FLAG_FD_CLOEXEC = 0x8
+)
+
+// PerfEventAttr is struct perf_event_attr as of PERF_ATTR_SIZE_VER0, with its
+// bit fields in Flags.
+type PerfEventAttr struct {
+	Type         uint32
+	Size         uint32
+	Config       uint64
+	SamplePeriod uint64
+	SampleType   uint64
+	ReadFormat   uint64
+	Flags        uint64
+	WakeupEvents uint32
+	BpType       uint32
+	Config1      uint64
+}
+
+// PerfEventOpen opens a perf event counter as configured in attr, for the
+// process or, with PERF_FLAG_PID_CGROUP, the cgroup directory fd pid, on the
+// CPU cpu.
+func PerfEventOpen(attr *PerfEventAttr, pid, cpu, groupFd int, flags uintptr) (int, error) {
+	attr.Size = uint32(unsafe.Sizeof(*attr))
+	fd, _, err := syscall.Syscall6(syscall.SYS_PERF_EVENT_OPEN, uintptr(unsafe.Pointer(attr)), uintptr(pid), uintptr(cpu), uintptr(groupFd), flags, 0)
+	if err != 0 {
+		return -1, err
+	}
+	return int(fd), nil
+}
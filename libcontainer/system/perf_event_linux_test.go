@@ -0,0 +1,64 @@
+// +build linux
+
+package system
+
+import (
+	"syscall"
+	"testing"
+	"unsafe"
+)
+
+// perfAttrSizeVer0 is PERF_ATTR_SIZE_VER0, the size of the first version of
+// struct perf_event_attr.
+const perfAttrSizeVer0 = 64
+
+func TestPerfEventAttrLayout(t *testing.T) {
+	var attr PerfEventAttr
+	if size := unsafe.Sizeof(attr); size != perfAttrSizeVer0 {
+		t.Fatalf("expected PerfEventAttr to be %d bytes, got %d", perfAttrSizeVer0, size)
+	}
+	for name, offset := range map[string][2]uintptr{
+		"Type":         {unsafe.Offsetof(attr.Type), 0},
+		"Size":         {unsafe.Offsetof(attr.Size), 4},
+		"Config":       {unsafe.Offsetof(attr.Config), 8},
+		"SamplePeriod": {unsafe.Offsetof(attr.SamplePeriod), 16},
+		"SampleType":   {unsafe.Offsetof(attr.SampleType), 24},
+		"ReadFormat":   {unsafe.Offsetof(attr.ReadFormat), 32},
+		"Flags":        {unsafe.Offsetof(attr.Flags), 40},
+		"WakeupEvents": {unsafe.Offsetof(attr.WakeupEvents), 48},
+		"BpType":       {unsafe.Offsetof(attr.BpType), 52},
+		"Config1":      {unsafe.Offsetof(attr.Config1), 56},
+	} {
+		if offset[0] != offset[1] {
+			t.Errorf("expected %s at offset %d, got %d", name, offset[1], offset[0])
+		}
+	}
+}
+
+func TestPerfEventOpen(t *testing.T) {
+	attr := &PerfEventAttr{
+		Type:   PERF_TYPE_SOFTWARE,
+		Config: PERF_COUNT_SW_TASK_CLOCK,
+	}
+	fd, err := PerfEventOpen(attr, 0, -1, -1, PERF_FLAG_FD_CLOEXEC)
+	if err == syscall.EACCES || err == syscall.EPERM || err == syscall.ENOSYS || err == syscall.ENOENT {
+		t.Skipf("perf_event_open is not permitted: %v", err)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer syscall.Close(fd)
+	if attr.Size != perfAttrSizeVer0 {
+		t.Errorf("expected the size of the attributes to be set to %d, got %d", perfAttrSizeVer0, attr.Size)
+	}
+	for i := 0; i < 1000; i++ {
+		syscall.Getpid()
+	}
+	var count uint64
+	if _, err := syscall.Read(fd, (*[8]byte)(unsafe.Pointer(&count))[:]); err != nil {
+		t.Fatal(err)
+	}
+	if count == 0 {
+		t.Error("expected the task clock of the test to be counted")
+	}
+}
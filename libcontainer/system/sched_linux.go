@@ -0,0 +1,84 @@
+// +build linux
+
+package system
+
+import (
+	"fmt"
+	"runtime"
+	"syscall"
+	"unsafe"
+)
+
+// sysSchedSetattr is the number of sched_setattr, which the syscall package
+// does not define on every architecture.
+var sysSchedSetattr = map[string]uintptr{
+	"386":      351,
+	"amd64":    314,
+	"arm":      380,
+	"arm64":    274,
+	"mips":     4349,
+	"mipsle":   4349,
+	"mips64":   5310,
+	"mips64le": 5310,
+	"ppc64":    355,
+	"ppc64le":  355,
+	"s390x":    345,
+}[runtime.GOARCH]
+
+// SchedAttr is struct sched_attr as of SCHED_ATTR_SIZE_VER0.
+type SchedAttr struct {
+	Size     uint32
+	Policy   uint32
+	Flags    uint64
+	Nice     int32
+	Priority uint32
+	Runtime  uint64
+	Deadline uint64
+	Period   uint64
+}
+
+// SchedSetattr sets the scheduling policy and attributes of the thread pid, or
+// of the calling thread if pid is 0.
+func SchedSetattr(pid int, attr *SchedAttr) error {
+	if sysSchedSetattr == 0 {
+		return fmt.Errorf("sched_setattr is not supported on %s", runtime.GOARCH)
+	}
+	attr.Size = uint32(unsafe.Sizeof(*attr))
+	if _, _, err := syscall.RawSyscall(sysSchedSetattr, uintptr(pid), uintptr(unsafe.Pointer(attr)), 0); err != 0 {
+		return err
+	}
+	return nil
+}
+
+// IOPRIO_WHO_PROCESS selects a single thread in IoprioSet.
+const IOPRIO_WHO_PROCESS = 1
+
+// IoprioSet sets the I/O scheduling class and level of the given target.
+func IoprioSet(which, who, class, level int) error {
+	ioprio := class<<13 | level
+	if _, _, err := syscall.RawSyscall(syscall.SYS_IOPRIO_SET, uintptr(which), uintptr(who), uintptr(ioprio)); err != 0 {
+		return err
+	}
+	return nil
+}
+
+// SchedSetaffinity restricts the thread pid, or the calling thread if pid is
+// 0, to the given CPUs.
+func SchedSetaffinity(pid int, cpus []int) error {
+	// The mask is an array of unsigned longs.
+	const bits = int(unsafe.Sizeof(uintptr(0))) * 8
+	var mask []uintptr
+	for _, cpu := range cpus {
+		for cpu/bits >= len(mask) {
+			mask = append(mask, 0)
+		}
+		mask[cpu/bits] |= 1 << uint(cpu%bits)
+	}
+	if len(mask) == 0 {
+		return fmt.Errorf("cannot set an empty cpu affinity")
+	}
+	if _, _, err := syscall.RawSyscall(syscall.SYS_SCHED_SETAFFINITY, uintptr(pid), uintptr(len(mask)*bits/8), uintptr(unsafe.Pointer(&mask[0]))); err != 0 {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+// +build linux
+
+package utils
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"syscall"
+)
+
+// maxFdsPerMessage bounds the number of file descriptors accepted along with
+// a single message.
+const maxFdsPerMessage = 16
+
+// SendFds writes msg to the unix socket, passing the given file descriptors
+// along with it using SCM_RIGHTS.
+func SendFds(socket *os.File, msg []byte, fds ...int) error {
+	oob := syscall.UnixRights(fds...)
+	for {
+		err := syscall.Sendmsg(int(socket.Fd()), msg, oob, nil, 0)
+		if err == syscall.EINTR {
+			continue
+		}
+		return err
+	}
+}
+
+// FdReader reads from a unix socket and collects the file descriptors passed
+// along with the data, which a plain read(2) would silently close.
+type FdReader struct {
+	socket *os.File
+	fds    []int
+}
+
+// NewFdReader returns an FdReader reading from socket.
+func NewFdReader(socket *os.File) *FdReader {
+	return &FdReader{socket: socket}
+}
+
+func (r *FdReader) Read(p []byte) (int, error) {
+	oob := make([]byte, syscall.CmsgSpace(4*maxFdsPerMessage))
+	for {
+		n, oobn, _, _, err := syscall.Recvmsg(int(r.socket.Fd()), p, oob, syscall.MSG_CMSG_CLOEXEC)
+		if err == syscall.EINTR {
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+		if oobn > 0 {
+			msgs, err := syscall.ParseSocketControlMessage(oob[:oobn])
+			if err != nil {
+				return 0, err
+			}
+			for i := range msgs {
+				fds, err := syscall.ParseUnixRights(&msgs[i])
+				if err != nil {
+					return 0, err
+				}
+				r.fds = append(r.fds, fds...)
+			}
+		}
+		if n == 0 && len(p) > 0 {
+			return 0, io.EOF
+		}
+		return n, nil
+	}
+}
+
+// TakeFd returns the oldest file descriptor received and not taken yet. The
+// caller is responsible for closing it.
+func (r *FdReader) TakeFd() (int, error) {
+	if len(r.fds) == 0 {
+		return -1, fmt.Errorf("no file descriptor was received")
+	}
+	fd := r.fds[0]
+	r.fds = r.fds[1:]
+	return fd, nil
+}
+
+// Close closes the file descriptors received and not taken. It does not close
+// the socket.
+func (r *FdReader) Close() error {
+	for _, fd := range r.fds {
+		syscall.Close(fd)
+	}
+	r.fds = nil
+	return nil
+}
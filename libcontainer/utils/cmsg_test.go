@@ -0,0 +1,68 @@
+// +build linux
+
+package utils
+
+import (
+	"encoding/json"
+	"os"
+	"syscall"
+	"testing"
+)
+
+func TestSendFdsFdReader(t *testing.T) {
+	fds, err := syscall.Socketpair(syscall.AF_LOCAL, syscall.SOCK_STREAM|syscall.SOCK_CLOEXEC, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parent := os.NewFile(uintptr(fds[0]), "parent")
+	defer parent.Close()
+	child := os.NewFile(uintptr(fds[1]), "child")
+	defer child.Close()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+
+	if err := WriteJSON(child, map[string]int{"type": 0}); err != nil {
+		t.Fatal(err)
+	}
+	if err := SendFds(child, []byte(`{"type":1}`), int(w.Fd())); err != nil {
+		t.Fatal(err)
+	}
+	child.Close()
+
+	reader := NewFdReader(parent)
+	defer reader.Close()
+	dec := json.NewDecoder(reader)
+	var types []int
+	for {
+		var msg map[string]int
+		if err := dec.Decode(&msg); err != nil {
+			break
+		}
+		types = append(types, msg["type"])
+	}
+	if len(types) != 2 || types[0] != 0 || types[1] != 1 {
+		t.Fatalf("expected messages of type [0 1], got %v", types)
+	}
+
+	fd, err := reader.TakeFd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	received := os.NewFile(uintptr(fd), "received")
+	defer received.Close()
+	if _, err := received.Write([]byte("x")); err != nil {
+		t.Fatal(err)
+	}
+	buf := make([]byte, 1)
+	if _, err := r.Read(buf); err != nil || buf[0] != 'x' {
+		t.Fatalf("expected to read x from the received fd, got %q (%v)", buf, err)
+	}
+	if _, err := reader.TakeFd(); err == nil {
+		t.Fatal("expected no more fds to be received")
+	}
+}
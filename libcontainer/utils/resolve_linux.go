@@ -0,0 +1,284 @@
+// +build linux
+
+package utils
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"unsafe"
+)
+
+const (
+	// _O_PATH is missing from the syscall package.
+	_O_PATH = 0x200000
+
+	resolveNoMagiclinks = 0x02
+	resolveInRoot       = 0x10
+
+	// maxSymlinkLimit is the number of symlinks followed before a
+	// resolution fails with ELOOP, as in the kernel.
+	maxSymlinkLimit = 40
+
+	// openat2Retries bounds the number of times openat2 is retried when a
+	// concurrent rename in the root makes it fail with EAGAIN.
+	openat2Retries = 32
+)
+
+// sysOpenat2 is the number of openat2(2), which the syscall package does not
+// define. It is the same on the architectures sharing the generic syscall
+// numbers, but mips offsets it by the base of its ABI. Other architectures
+// always take the userspace walk.
+var sysOpenat2 = map[string]uintptr{
+	"386":      437,
+	"amd64":    437,
+	"arm":      437,
+	"arm64":    437,
+	"mips":     4437,
+	"mipsle":   4437,
+	"mips64":   5437,
+	"mips64le": 5437,
+	"ppc64":    437,
+	"ppc64le":  437,
+	"s390x":    437,
+}[runtime.GOARCH]
+
+// openHow is struct open_how, from linux/openat2.h.
+type openHow struct {
+	flags   uint64
+	mode    uint64
+	resolve uint64
+}
+
+// noOpenat2 is set once openat2 is known not to be usable.
+var noOpenat2 int32
+
+// OpenInRoot opens unsafePath as if root was the root directory: absolute
+// symlinks and ".." components never lead outside of root, and no magic links
+// are followed. The file returned is an O_PATH descriptor, which can be used
+// as a mount target with ProcfdPath.
+func OpenInRoot(root, unsafePath string) (*os.File, error) {
+	rootDir, err := os.OpenFile(root, _O_PATH|syscall.O_DIRECTORY|syscall.O_CLOEXEC, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer rootDir.Close()
+
+	name := strings.TrimPrefix(path.Clean("/"+unsafePath), "/")
+	if name == "" {
+		name = "."
+	}
+	if atomic.LoadInt32(&noOpenat2) == 0 {
+		fd, err := openat2InRoot(int(rootDir.Fd()), name)
+		switch err {
+		case nil:
+			return os.NewFile(uintptr(fd), filepath.Join(root, name)), nil
+		case syscall.ENOSYS, syscall.EPERM, syscall.E2BIG:
+			atomic.StoreInt32(&noOpenat2, 1)
+		case syscall.EAGAIN:
+		default:
+			return nil, &os.PathError{Op: "openat2", Path: filepath.Join(root, name), Err: err}
+		}
+	}
+	return walkInRoot(rootDir, root, name)
+}
+
+func openat2InRoot(dirfd int, name string) (int, error) {
+	if sysOpenat2 == 0 {
+		return -1, syscall.ENOSYS
+	}
+	p, err := syscall.BytePtrFromString(name)
+	if err != nil {
+		return -1, err
+	}
+	how := openHow{
+		flags:   _O_PATH | syscall.O_CLOEXEC,
+		resolve: resolveInRoot | resolveNoMagiclinks,
+	}
+	for i := 0; ; i++ {
+		fd, _, errno := syscall.Syscall6(sysOpenat2, uintptr(dirfd), uintptr(unsafe.Pointer(p)), uintptr(unsafe.Pointer(&how)), unsafe.Sizeof(how), 0, 0)
+		if errno == syscall.EINTR || (errno == syscall.EAGAIN && i < openat2Retries) {
+			continue
+		}
+		if errno != 0 {
+			return -1, errno
+		}
+		return int(fd), nil
+	}
+}
+
+// walkInRoot resolves name one component at a time without letting the kernel
+// follow any symlink, for kernels without openat2. ".." is resolved against
+// the directories walked through rather than by the kernel, so that a
+// directory moved out of root during the walk cannot be escaped through.
+func walkInRoot(rootDir *os.File, root, name string) (*os.File, error) {
+	rootFd, err := syscall.Dup(int(rootDir.Fd()))
+	if err != nil {
+		return nil, err
+	}
+	syscall.CloseOnExec(rootFd)
+	dirs := []int{rootFd}
+	defer func() {
+		for _, fd := range dirs {
+			syscall.Close(fd)
+		}
+	}()
+
+	var (
+		remaining = name
+		links     = 0
+	)
+	for remaining != "" {
+		part := remaining
+		remaining = ""
+		if i := strings.IndexByte(part, '/'); i >= 0 {
+			part, remaining = part[:i], part[i+1:]
+		}
+		switch part {
+		case "", ".":
+			continue
+		case "..":
+			if len(dirs) > 1 {
+				syscall.Close(dirs[len(dirs)-1])
+				dirs = dirs[:len(dirs)-1]
+			}
+			continue
+		}
+		fd, err := syscall.Openat(dirs[len(dirs)-1], part, _O_PATH|syscall.O_NOFOLLOW|syscall.O_CLOEXEC, 0)
+		if err != nil {
+			return nil, &os.PathError{Op: "openat", Path: filepath.Join(root, name), Err: err}
+		}
+		var st syscall.Stat_t
+		if err := syscall.Fstat(fd, &st); err != nil {
+			syscall.Close(fd)
+			return nil, err
+		}
+		if st.Mode&syscall.S_IFMT != syscall.S_IFLNK {
+			dirs = append(dirs, fd)
+			continue
+		}
+		links++
+		target, err := readlinkat(fd, "")
+		syscall.Close(fd)
+		if err != nil {
+			return nil, err
+		}
+		if links > maxSymlinkLimit {
+			return nil, &os.PathError{Op: "openat", Path: filepath.Join(root, name), Err: syscall.ELOOP}
+		}
+		if path.IsAbs(target) {
+			for _, fd := range dirs[1:] {
+				syscall.Close(fd)
+			}
+			dirs = dirs[:1]
+		}
+		remaining = target + "/" + remaining
+	}
+
+	fd := dirs[len(dirs)-1]
+	if len(dirs) == 1 {
+		if fd, err = syscall.Dup(fd); err != nil {
+			return nil, err
+		}
+		syscall.CloseOnExec(fd)
+	} else {
+		dirs = dirs[:len(dirs)-1]
+	}
+	return os.NewFile(uintptr(fd), filepath.Join(root, name)), nil
+}
+
+func readlinkat(dirfd int, name string) (string, error) {
+	p, err := syscall.BytePtrFromString(name)
+	if err != nil {
+		return "", err
+	}
+	for size := 128; ; size *= 2 {
+		buf := make([]byte, size)
+		n, _, errno := syscall.Syscall6(syscall.SYS_READLINKAT, uintptr(dirfd), uintptr(unsafe.Pointer(p)), uintptr(unsafe.Pointer(&buf[0])), uintptr(size), 0, 0)
+		if errno != 0 {
+			return "", errno
+		}
+		if int(n) < size {
+			return string(buf[:n]), nil
+		}
+	}
+}
+
+// MkdirAllInRoot creates the directory unsafePath in root along with any
+// missing parent, resolving it as OpenInRoot does, and returns it opened.
+func MkdirAllInRoot(root, unsafePath string, mode os.FileMode) (*os.File, error) {
+	name := path.Clean("/" + unsafePath)
+	dir, err := OpenInRoot(root, name)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return nil, err
+		}
+		parent, err := MkdirAllInRoot(root, path.Dir(name), mode)
+		if err != nil {
+			return nil, err
+		}
+		err = syscall.Mkdirat(int(parent.Fd()), path.Base(name), uint32(mode.Perm()))
+		parent.Close()
+		if err != nil && err != syscall.EEXIST {
+			return nil, &os.PathError{Op: "mkdirat", Path: filepath.Join(root, name), Err: err}
+		}
+		// A dangling symlink makes the directory still missing.
+		if dir, err = OpenInRoot(root, name); err != nil {
+			return nil, err
+		}
+	}
+	fi, err := dir.Stat()
+	if err != nil {
+		dir.Close()
+		return nil, err
+	}
+	if !fi.IsDir() {
+		dir.Close()
+		return nil, &os.PathError{Op: "mkdir", Path: filepath.Join(root, name), Err: syscall.ENOTDIR}
+	}
+	return dir, nil
+}
+
+// CreateInRoot opens the file unsafePath in root, resolving it as OpenInRoot
+// does. If it does not exist, it is created as a regular file along with any
+// missing parent directory.
+func CreateInRoot(root, unsafePath string, mode os.FileMode) (*os.File, error) {
+	name := path.Clean("/" + unsafePath)
+	f, err := OpenInRoot(root, name)
+	if err == nil || !os.IsNotExist(err) {
+		return f, err
+	}
+	parent, err := MkdirAllInRoot(root, path.Dir(name), 0755)
+	if err != nil {
+		return nil, err
+	}
+	defer parent.Close()
+	fd, err := syscall.Openat(int(parent.Fd()), path.Base(name), syscall.O_RDONLY|syscall.O_CREAT|syscall.O_EXCL|syscall.O_NOFOLLOW|syscall.O_CLOEXEC, uint32(mode.Perm()))
+	if err != nil {
+		return nil, &os.PathError{Op: "openat", Path: filepath.Join(root, name), Err: err}
+	}
+	return os.NewFile(uintptr(fd), filepath.Join(root, name)), nil
+}
+
+// ProcfdPath returns the magic link of f in /proc/self/fd. Passing it to
+// mount(2) or chmod(2) operates on the very file f refers to, whatever
+// happened to its path since it was opened.
+func ProcfdPath(f *os.File) string {
+	return fmt.Sprintf("/proc/self/fd/%d", f.Fd())
+}
+
+// WithProcfd opens unsafePath in root with OpenInRoot and calls fn with its
+// ProcfdPath.
+func WithProcfd(root, unsafePath string, fn func(procfd string) error) error {
+	f, err := OpenInRoot(root, unsafePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return fn(ProcfdPath(f))
+}
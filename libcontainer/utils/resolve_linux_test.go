@@ -0,0 +1,143 @@
+// +build linux
+
+package utils
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+)
+
+// newResolveRoot returns a directory containing symlinks trying to escape it.
+func newResolveRoot(t *testing.T) string {
+	root, err := ioutil.TempDir("", "resolve")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, dir := range []string{"etc", "a/b"} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	links := map[string]string{
+		"abs":       "/etc",
+		"rel":       "../../../../../../etc",
+		"a/b/up":    "../..",
+		"a/chain":   "../abs",
+		"a/loop":    "loop",
+		"dangling":  "/missing/dir",
+		"self-root": "/proc/self/root",
+	}
+	for name, target := range links {
+		if err := os.Symlink(target, filepath.Join(root, name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+// resolvePath returns the path OpenInRoot, or the userspace walker if
+// walk is set, resolves name to.
+func resolvePath(t *testing.T, root, name string, walk bool) (string, error) {
+	var (
+		f   *os.File
+		err error
+	)
+	if walk {
+		rootDir, rerr := os.Open(root)
+		if rerr != nil {
+			t.Fatal(rerr)
+		}
+		defer rootDir.Close()
+		f, err = walkInRoot(rootDir, root, name)
+	} else {
+		f, err = OpenInRoot(root, name)
+	}
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	return os.Readlink(ProcfdPath(f))
+}
+
+func TestOpenInRoot(t *testing.T) {
+	root := newResolveRoot(t)
+	defer os.RemoveAll(root)
+
+	tests := map[string]string{
+		"/":           root,
+		"/etc":        filepath.Join(root, "etc"),
+		"../../etc":   filepath.Join(root, "etc"),
+		"/abs":        filepath.Join(root, "etc"),
+		"/rel":        filepath.Join(root, "etc"),
+		"/a/b/up/etc": filepath.Join(root, "etc"),
+		"/a/chain":    filepath.Join(root, "etc"),
+	}
+	for _, walk := range []bool{false, true} {
+		for name, want := range tests {
+			got, err := resolvePath(t, root, name, walk)
+			if err != nil {
+				t.Errorf("resolving %q (walk %v): %v", name, walk, err)
+				continue
+			}
+			if got != want {
+				t.Errorf("resolving %q (walk %v): got %q, want %q", name, walk, got, want)
+			}
+		}
+	}
+}
+
+func TestOpenInRootErrors(t *testing.T) {
+	root := newResolveRoot(t)
+	defer os.RemoveAll(root)
+
+	for _, walk := range []bool{false, true} {
+		if _, err := resolvePath(t, root, "/dangling", walk); !os.IsNotExist(err) {
+			t.Errorf("resolving a dangling symlink (walk %v): expected ENOENT, got %v", walk, err)
+		}
+		if _, err := resolvePath(t, root, "/a/loop", walk); err == nil || err.(*os.PathError).Err != syscall.ELOOP {
+			t.Errorf("resolving a symlink loop (walk %v): expected ELOOP, got %v", walk, err)
+		}
+		// The target of the magic link does not exist in the root.
+		if got, err := resolvePath(t, root, "/self-root/etc", walk); err == nil {
+			t.Errorf("resolving a magic link (walk %v): expected an error, got %q", walk, got)
+		}
+	}
+}
+
+func TestMkdirAllInRoot(t *testing.T) {
+	root := newResolveRoot(t)
+	defer os.RemoveAll(root)
+
+	dir, err := MkdirAllInRoot(root, "/abs/x/y", 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir.Close()
+	if _, err := os.Stat(filepath.Join(root, "etc/x/y")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := MkdirAllInRoot(root, "/dangling", 0755); err == nil {
+		t.Fatal("expected an error creating a directory over a dangling symlink")
+	}
+}
+
+func TestCreateInRoot(t *testing.T) {
+	root := newResolveRoot(t)
+	defer os.RemoveAll(root)
+
+	f, err := CreateInRoot(root, "/rel/x/file", 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	fi, err := os.Stat(filepath.Join(root, "etc/x/file"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !fi.Mode().IsRegular() {
+		t.Fatalf("expected a regular file, got %v", fi.Mode())
+	}
+}
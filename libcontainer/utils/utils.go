@@ -100,3 +100,22 @@ func SearchLabels(labels []string, query string) string {
 	}
 	return ""
 }
+
+// Annotations returns the bundle path and the annotations of the container
+// stored in the labels of its config by runc, which are the key-value pairs
+// following the bundle.
+func Annotations(labels []string) (bundle string, annotations map[string]string) {
+	annotations = make(map[string]string)
+	for _, l := range labels {
+		parts := strings.SplitN(l, "=", 2)
+		if len(parts) < 2 {
+			continue
+		}
+		if parts[0] == "bundle" && bundle == "" {
+			bundle = parts[1]
+			continue
+		}
+		annotations[parts[0]] = parts[1]
+	}
+	return bundle, annotations
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
 	"syscall"
 	"testing"
 )
@@ -52,6 +53,17 @@ func TestSearchLabels(t *testing.T) {
 	}
 }
 
+func TestAnnotations(t *testing.T) {
+	bundle, annotations := Annotations([]string{"bundle=/path", "owner=alice", "invalid", "revision=a=b", "bundle=/other"})
+	if bundle != "/path" {
+		t.Errorf("expected bundle '/path'; got '%s'", bundle)
+	}
+	expected := map[string]string{"owner": "alice", "revision": "a=b", "bundle": "/other"}
+	if !reflect.DeepEqual(annotations, expected) {
+		t.Errorf("expected annotations %v; got %v", expected, annotations)
+	}
+}
+
 func TestResolveRootfs(t *testing.T) {
 	dir := "rootfs"
 	os.Mkdir(dir, 0600)
@@ -7,6 +7,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 	"text/tabwriter"
 	"time"
 
@@ -31,6 +32,53 @@ type containerState struct {
 	Bundle string `json:"bundle"`
 	// Created is the unix timestamp for the creation time of the container in UTC
 	Created time.Time `json:"created"`
+	// Annotations are the annotations of the container's spec
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// listFilter selects the containers with the given status, when its key is
+// "status", or the containers with an annotation of the key set to the value.
+type listFilter struct {
+	key   string
+	value string
+}
+
+func parseListFilters(args []string) ([]listFilter, error) {
+	var filters []listFilter
+	for _, arg := range args {
+		parts := strings.SplitN(arg, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("invalid filter %q, expected key=value", arg)
+		}
+		filters = append(filters, listFilter{key: parts[0], value: parts[1]})
+	}
+	return filters, nil
+}
+
+func (f listFilter) match(c containerState) bool {
+	if f.key == "status" {
+		return c.Status == f.value
+	}
+	value, ok := c.Annotations[f.key]
+	return ok && value == f.value
+}
+
+// filterContainers returns the containers matched by all the filters.
+func filterContainers(s []containerState, filters []listFilter) []containerState {
+	var out []containerState
+	for _, c := range s {
+		matched := true
+		for _, f := range filters {
+			if !f.match(c) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			out = append(out, c)
+		}
+	}
+	return out
 }
 
 var listCommand = cli.Command{
@@ -51,12 +99,26 @@ in json format:
 			Name:  "quiet, q",
 			Usage: "display only container IDs",
 		},
+		cli.StringSliceFlag{
+			Name:  "filter",
+			Value: &cli.StringSlice{},
+			Usage: `display only the containers with the given status, with status=<status>, or
+the given annotation, with <key>=<value>.  When repeated, only the containers
+matching all the filters are displayed:
+
+    # runc list --filter status=running --filter owner=alice`,
+		},
 	},
 	Action: func(context *cli.Context) {
+		filters, err := parseListFilters(context.StringSlice("filter"))
+		if err != nil {
+			fatal(err)
+		}
 		s, err := getContainers(context)
 		if err != nil {
 			fatal(err)
 		}
+		s = filterContainers(s, filters)
 
 		if context.Bool("quiet") {
 			for _, item := range s {
@@ -120,12 +182,14 @@ func getContainers(context *cli.Context) ([]containerState, error) {
 			if err != nil {
 				return nil, err
 			}
+			bundle, annotations := utils.Annotations(state.Config.Labels)
 			s = append(s, containerState{
 				ID:             state.BaseState.ID,
 				InitProcessPid: state.BaseState.InitProcessPid,
 				Status:         containerStatus.String(),
-				Bundle:         utils.SearchLabels(state.Config.Labels, "bundle"),
-				Created:        state.BaseState.Created})
+				Bundle:         bundle,
+				Created:        state.BaseState.Created,
+				Annotations:    annotations})
 		}
 	}
 	return s, nil
@@ -39,7 +39,13 @@ To start a new instance of a container:
 Where "<container-id>" is your name for the instance of the container that you
 are starting. The name you provide for the container instance must be unique on
 your host. Providing the bundle directory using "-b" is optional. The default
-value for "bundle" is the current directory.`
+value for "bundle" is the current directory.
+
+The root directory given with "--root" is created searchable, but not listable,
+by other users (mode 0711), so that the root of a user namespace can reach the
+/etc files runc generates for its container in the state directory. An existing
+root directory keeps its permissions, and has to be made searchable for such
+containers.`
 )
 
 func main() {
@@ -87,11 +93,14 @@ func main() {
 	app.Commands = []cli.Command{
 		checkpointCommand,
 		deleteCommand,
+		deviceCommand,
 		eventsCommand,
 		execCommand,
+		gcCommand,
 		initCommand,
 		killCommand,
 		listCommand,
+		mountCommand,
 		pauseCommand,
 		psCommand,
 		restoreCommand,
@@ -99,6 +108,7 @@ func main() {
 		specCommand,
 		startCommand,
 		stateCommand,
+		umountCommand,
 	}
 	app.Before = func(context *cli.Context) error {
 		if context.GlobalBool("debug") {
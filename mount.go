@@ -0,0 +1,86 @@
+// +build linux
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/codegangsta/cli"
+	"github.com/opencontainers/runc/libcontainer/specconv"
+	"github.com/opencontainers/runtime-spec/specs-go"
+)
+
+var mountCommand = cli.Command{
+	Name:  "mount",
+	Usage: "mount bind mounts a host path into a running container",
+	ArgsUsage: `<container-id> <source> <destination>
+
+Where "<container-id>" is the name for the instance of the container,
+"<source>" is the path on the host to bind mount and "<destination>" is the
+path inside the rootfs of the container to mount it on.
+
+For example, to bind mount /srv/data read-only at /data in the container
+"ubuntu01":
+
+       # runc mount --options rbind,ro ubuntu01 /srv/data /data`,
+	Description: `The mount command bind mounts a path of the host into the mount namespace of
+a running container. The destination is created if it does not exist, and the
+mount is added to the configuration of the container.`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "options, o",
+			Value: "rbind",
+			Usage: "comma separated mount options, as in the mounts of config.json",
+		},
+	},
+	Action: func(context *cli.Context) {
+		container, err := getContainer(context)
+		if err != nil {
+			fatal(err)
+		}
+		source, dest := context.Args().Get(1), context.Args().Get(2)
+		if source == "" || dest == "" {
+			fatal(fmt.Errorf("source and destination of the mount must be specified"))
+		}
+		cwd, err := os.Getwd()
+		if err != nil {
+			fatal(err)
+		}
+		m := specconv.CreateLibcontainerMount(cwd, specs.Mount{
+			Type:        "bind",
+			Source:      source,
+			Destination: dest,
+			Options:     strings.Split(context.String("options"), ","),
+		})
+		if err := container.AddMount(m); err != nil {
+			fatal(err)
+		}
+	},
+}
+
+var umountCommand = cli.Command{
+	Name:  "umount",
+	Usage: "umount removes a mount added with runc mount from a running container",
+	ArgsUsage: `<container-id> <destination>
+
+Where "<container-id>" is the name for the instance of the container and
+"<destination>" is the path of the mount inside the container.`,
+	Description: `The umount command lazily unmounts the mount at the destination in the mount
+namespace of a running container, and removes it from the configuration of the
+container.`,
+	Action: func(context *cli.Context) {
+		container, err := getContainer(context)
+		if err != nil {
+			fatal(err)
+		}
+		dest := context.Args().Get(1)
+		if dest == "" {
+			fatal(fmt.Errorf("destination of the mount must be specified"))
+		}
+		if err := container.RemoveMount(dest); err != nil {
+			fatal(err)
+		}
+	},
+}
@@ -0,0 +1,149 @@
+// +build linux
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+
+	"github.com/codegangsta/cli"
+	"github.com/opencontainers/runc/libcontainer"
+	"github.com/opencontainers/runc/libcontainer/specconv"
+	"github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// notifySocketDir is the directory the notify socket is mounted on in the
+// container.
+const notifySocketDir = "/run/notify"
+
+const notifySocketName = "notify.sock"
+
+// notifySocket is the socket runc listens on in the state directory of the
+// container in place of the NOTIFY_SOCKET of systemd, to relay the sd_notify(3)
+// messages of the container.
+type notifySocket struct {
+	// host is the NOTIFY_SOCKET runc is started with.
+	host string
+	// dir is the directory of the socket, bind mounted in the container.
+	dir    string
+	socket *net.UnixConn
+}
+
+// newNotifySocket returns the notify socket for the container id, or nil if
+// runc is not started with a NOTIFY_SOCKET.
+func newNotifySocket(context *cli.Context, host, id string) (*notifySocket, error) {
+	if host == "" {
+		return nil, nil
+	}
+	root, err := filepath.Abs(context.GlobalString("root"))
+	if err != nil {
+		return nil, err
+	}
+	return &notifySocket{
+		host: host,
+		dir:  filepath.Join(root, id, "notify"),
+	}, nil
+}
+
+// setupSpec mounts the directory of the socket in the container, and points
+// the NOTIFY_SOCKET of its process to it.
+func (s *notifySocket) setupSpec(spec *specconv.Spec) {
+	spec.Mounts = append(spec.Mounts, specs.Mount{
+		Destination: notifySocketDir,
+		Type:        "bind",
+		Source:      s.dir,
+		Options:     []string{"bind", "nosuid", "nodev", "noexec"},
+	})
+	spec.Process.Env = append(spec.Process.Env, "NOTIFY_SOCKET="+filepath.Join(notifySocketDir, notifySocketName))
+}
+
+// listen creates the socket, once the state directory of the container is.
+// Any user of the container may send to it, as for the one of systemd. The
+// state directory of a container with a user namespace can be searched by any
+// user, so that its root can bind mount the directory of the socket.
+func (s *notifySocket) listen() error {
+	if err := os.Mkdir(s.dir, 0755); err != nil {
+		return err
+	}
+	path := filepath.Join(s.dir, notifySocketName)
+	socket, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	if err != nil {
+		return err
+	}
+	if err := os.Chmod(path, 0777); err != nil {
+		socket.Close()
+		return err
+	}
+	s.socket = socket
+	return nil
+}
+
+// forward relays the messages received on the socket to systemd until it is
+// closed. READY=1 is sent along with the host pid of the container's process
+// as MAINPID, the one set by the container being meaningless on the host, and
+// ready is closed then.
+func (s *notifySocket) forward(pid int, ready chan<- struct{}) {
+	buf := make([]byte, 4096)
+	for {
+		n, err := s.socket.Read(buf)
+		if err != nil {
+			return
+		}
+		var (
+			msg     [][]byte
+			isReady bool
+		)
+		for _, line := range bytes.Split(buf[:n], []byte("\n")) {
+			if bytes.HasPrefix(line, []byte("MAINPID=")) {
+				continue
+			}
+			if bytes.Equal(line, []byte("READY=1")) {
+				isReady = true
+			}
+			msg = append(msg, line)
+		}
+		if isReady {
+			msg = append(msg, []byte(fmt.Sprintf("MAINPID=%d", pid)))
+		}
+		if err := s.send(bytes.Join(msg, []byte("\n"))); err != nil {
+			continue
+		}
+		if isReady && ready != nil {
+			close(ready)
+			ready = nil
+		}
+	}
+}
+
+func (s *notifySocket) send(msg []byte) error {
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: s.host, Net: "unixgram"})
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	_, err = conn.Write(msg)
+	return err
+}
+
+// waitReady waits for the container to send READY=1, and fails if its process
+// exits first.
+func (s *notifySocket) waitReady(process *libcontainer.Process, ready <-chan struct{}) error {
+	exited := make(chan struct{})
+	go func() {
+		process.Wait()
+		close(exited)
+	}()
+	select {
+	case <-ready:
+		return nil
+	case <-exited:
+		return fmt.Errorf("container exited before notifying it was ready")
+	}
+}
+
+func (s *notifySocket) Close() error {
+	return s.socket.Close()
+}
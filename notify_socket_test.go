@@ -0,0 +1,86 @@
+// +build linux
+
+package main
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestNotifySocketForward(t *testing.T) {
+	dir, err := ioutil.TempDir("", "notify")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	host, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: filepath.Join(dir, "host.sock"), Net: "unixgram"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer host.Close()
+	s := &notifySocket{
+		host: filepath.Join(dir, "host.sock"),
+		dir:  filepath.Join(dir, "container"),
+	}
+	if err := s.listen(); err != nil {
+		t.Fatal(err)
+	}
+	ready := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		s.forward(1234, ready)
+		close(done)
+	}()
+
+	container, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: filepath.Join(s.dir, notifySocketName), Net: "unixgram"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer container.Close()
+	relay := func(msg string) string {
+		if _, err := container.Write([]byte(msg)); err != nil {
+			t.Fatal(err)
+		}
+		buf := make([]byte, 4096)
+		host.SetReadDeadline(time.Now().Add(5 * time.Second))
+		n, err := host.Read(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(buf[:n])
+	}
+
+	// The pid set by the container is meaningless on the host.
+	if msg := relay("STATUS=starting\nMAINPID=1"); msg != "STATUS=starting" {
+		t.Errorf("expected the MAINPID of the container to be dropped, got %q", msg)
+	}
+	select {
+	case <-ready:
+		t.Fatal("expected the container not to be ready before READY=1")
+	default:
+	}
+	if msg := relay("MAINPID=1\nREADY=1"); msg != "READY=1\nMAINPID=1234" {
+		t.Errorf("expected READY=1 to be sent with the host pid of the container, got %q", msg)
+	}
+	select {
+	case <-ready:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the container to be ready after READY=1")
+	}
+	// A later READY=1 does not close ready twice.
+	if msg := relay("READY=1"); msg != "READY=1\nMAINPID=1234" {
+		t.Errorf("expected READY=1 to be sent with the host pid of the container, got %q", msg)
+	}
+
+	s.Close()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected forwarding to stop once the socket is closed")
+	}
+}
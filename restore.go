@@ -11,7 +11,6 @@ import (
 	"github.com/opencontainers/runc/libcontainer"
 	"github.com/opencontainers/runc/libcontainer/configs"
 	"github.com/opencontainers/runc/libcontainer/specconv"
-	"github.com/opencontainers/runtime-spec/specs-go"
 )
 
 var restoreCommand = cli.Command{
@@ -97,11 +96,16 @@ using the runc checkpoint command.`,
 		if err != nil {
 			fatal(err)
 		}
+		etcDir, err := etcFilesDir(context, id)
+		if err != nil {
+			fatal(err)
+		}
 		config, err := specconv.CreateLibcontainerConfig(&specconv.CreateOpts{
 			CgroupName:       id,
 			UseSystemdCgroup: context.GlobalBool("systemd-cgroup"),
 			NoPivotRoot:      context.Bool("no-pivot"),
 			Spec:             spec,
+			EtcFilesDir:      etcDir,
 		})
 		if err != nil {
 			fatal(err)
@@ -114,7 +118,7 @@ using the runc checkpoint command.`,
 	},
 }
 
-func restoreContainer(context *cli.Context, spec *specs.Spec, config *configs.Config, imagePath string) (code int, err error) {
+func restoreContainer(context *cli.Context, spec *specconv.Spec, config *configs.Config, imagePath string) (code int, err error) {
 	var (
 		rootuid = 0
 		id      = context.Args().First()
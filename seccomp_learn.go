@@ -0,0 +1,180 @@
+// +build linux
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"syscall"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/opencontainers/runc/libcontainer/seccomp"
+	"github.com/opencontainers/runc/libcontainer/specconv"
+	"github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// seccompLearner is the seccomp agent of a container started with
+// --seccomp-learn. Its filter notifies every syscall but sendmsg, which runc
+// init needs to hand the listener over, and the agent lets them all continue
+// after recording them.
+type seccompLearner struct {
+	native   string
+	dir      string
+	listener *net.UnixListener
+	learner  *seccomp.Learner
+	wg       sync.WaitGroup
+}
+
+// newSeccompLearner replaces the seccomp profile of spec with a learning one,
+// and starts receiving the listeners of the container processes.
+func newSeccompLearner(spec *specconv.Spec) (*seccompLearner, error) {
+	arches, err := seccomp.LearnArches()
+	if err != nil {
+		return nil, err
+	}
+	profile := &specconv.Seccomp{
+		Seccomp: specs.Seccomp{
+			DefaultAction: specconv.ActNotify,
+		},
+		Syscalls: []specconv.Syscall{
+			{Syscall: specs.Syscall{Name: "sendmsg", Action: specs.ActAllow}},
+		},
+	}
+	for _, arch := range arches {
+		name, err := seccomp.ConvertArchToString(arch)
+		if err != nil {
+			return nil, err
+		}
+		profile.Architectures = append(profile.Architectures, specs.Arch(name))
+	}
+
+	dir, err := ioutil.TempDir("", "runc-seccomp-learn")
+	if err != nil {
+		return nil, err
+	}
+	profile.ListenerPath = filepath.Join(dir, "agent.sock")
+	listener, err := net.ListenUnix("unix", &net.UnixAddr{Name: profile.ListenerPath, Net: "unix"})
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+	spec.Linux.Seccomp = profile
+
+	l := &seccompLearner{
+		native:   arches[0],
+		dir:      dir,
+		listener: listener,
+		learner:  seccomp.NewLearner(),
+	}
+	l.wg.Add(1)
+	go l.accept()
+	return l, nil
+}
+
+func (l *seccompLearner) accept() {
+	defer l.wg.Done()
+	for {
+		conn, err := l.listener.AcceptUnix()
+		if err != nil {
+			return
+		}
+		if err := l.receive(conn); err != nil {
+			logrus.Errorf("receiving seccomp listener: %v", err)
+		}
+		conn.Close()
+	}
+}
+
+// receive watches the listener fds sent on conn by runc.
+func (l *seccompLearner) receive(conn *net.UnixConn) error {
+	buf := make([]byte, 4096)
+	oob := make([]byte, syscall.CmsgSpace(4))
+	_, oobn, _, _, err := conn.ReadMsgUnix(buf, oob)
+	if err != nil {
+		return err
+	}
+	msgs, err := syscall.ParseSocketControlMessage(oob[:oobn])
+	if err != nil {
+		return err
+	}
+	for i := range msgs {
+		fds, err := syscall.ParseUnixRights(&msgs[i])
+		if err != nil {
+			return err
+		}
+		for _, fd := range fds {
+			syscall.CloseOnExec(fd)
+			l.learner.Watch(fd)
+		}
+	}
+	return nil
+}
+
+// finish stops the agent and writes the profiles learnt. The profile of the
+// native architecture is written to path, and those of the compat
+// architectures seen to path suffixed with the name of the architecture, as
+// a syscall allowed for one architecture must not be allowed for the others.
+// Each profile denies with EPERM every syscall that was not seen.
+func (l *seccompLearner) finish(path string) error {
+	l.listener.Close()
+	l.wg.Wait()
+	l.learner.Stop()
+	os.RemoveAll(l.dir)
+
+	learnt := l.learner.Syscalls()
+	if _, ok := learnt[l.native]; !ok {
+		learnt[l.native] = nil
+	}
+	for arch, names := range learnt {
+		p := path
+		if arch != l.native {
+			p = path + "." + arch
+		}
+		if err := writeLearntProfile(p, arch, names); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeLearntProfile writes to path the profile allowing the syscalls names
+// on the architecture arch only.
+func writeLearntProfile(path, arch string, names []string) error {
+	name, err := seccomp.ConvertArchToString(arch)
+	if err != nil {
+		return err
+	}
+	profile := specs.Seccomp{
+		DefaultAction: specs.ActErrno,
+		Architectures: []specs.Arch{specs.Arch(name)},
+	}
+	seen := map[string]bool{
+		"sendmsg": true,
+	}
+	for _, name := range names {
+		seen[name] = true
+	}
+	names = names[:0]
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		profile.Syscalls = append(profile.Syscalls, specs.Syscall{Name: name, Action: specs.ActAllow})
+	}
+
+	data, err := json.MarshalIndent(profile, "", "\t")
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("writing seccomp profile: %v", err)
+	}
+	return nil
+}
@@ -9,7 +9,7 @@ import (
 	"github.com/codegangsta/cli"
 	"github.com/coreos/go-systemd/activation"
 	"github.com/opencontainers/runc/libcontainer"
-	"github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/opencontainers/runc/libcontainer/specconv"
 )
 
 // default action is to start a container
@@ -49,6 +49,10 @@ command(s) that get executed on start, edit the args parameter of the spec. See
 			Value: "",
 			Usage: "specify the file to write the process id to",
 		},
+		cli.BoolFlag{
+			Name:  "wait-ready",
+			Usage: "with --detach, wait for the container to send READY=1 to its NOTIFY_SOCKET before detaching; without it, a detached container gets no NOTIFY_SOCKET, and the messages it sends after READY=1 are not relayed",
+		},
 		cli.BoolFlag{
 			Name:  "no-subreaper",
 			Usage: "disable the use of the subreaper used to reap reparented processes",
@@ -57,6 +61,23 @@ command(s) that get executed on start, edit the args parameter of the spec. See
 			Name:  "no-pivot",
 			Usage: "do not use pivot root to jail process inside rootfs.  This should be used whenever the rootfs is on top of a ramdisk",
 		},
+		cli.BoolFlag{
+			Name:  "init",
+			Usage: "run the container's process as a child of an init that forwards it signals and reaps zombies; the init runs under the container's seccomp profile, which must allow the syscalls of the go runtime",
+		},
+		cli.IntFlag{
+			Name:  "exclusive-cpus",
+			Usage: "reserve the given number of CPUs for the container alone, out of the CPUs shared by the containers of the same root",
+		},
+		cli.BoolFlag{
+			Name:  "numa-local",
+			Usage: "with --exclusive-cpus, reserve CPUs of a single NUMA node and restrict the container's memory to that node",
+		},
+		cli.StringFlag{
+			Name:  "seccomp-learn",
+			Value: "",
+			Usage: "record the syscalls made in the container instead of applying its seccomp profile, and write a profile allowing them to the given file on exit, with those of each compat architecture in the file suffixed with its name",
+		},
 	},
 	Action: func(context *cli.Context) {
 		bundle := context.String("bundle")
@@ -70,16 +91,40 @@ command(s) that get executed on start, edit the args parameter of the spec. See
 			fatal(err)
 		}
 
-		notifySocket := os.Getenv("NOTIFY_SOCKET")
-		if notifySocket != "" {
-			setupSdNotify(spec, notifySocket)
+		// Nothing relays the messages of a detached container once runc
+		// exits, so the container only gets a NOTIFY_SOCKET if runc waits
+		// for it to be ready.
+		var notifySocket *notifySocket
+		if !context.Bool("detach") || context.Bool("wait-ready") {
+			notifySocket, err = newNotifySocket(context, os.Getenv("NOTIFY_SOCKET"), context.Args().First())
+			if err != nil {
+				fatal(err)
+			}
+		}
+		if notifySocket != nil {
+			notifySocket.setupSpec(spec)
 		}
 
 		if os.Geteuid() != 0 {
 			fatalf("runc should be run as root")
 		}
 
-		status, err := startContainer(context, spec)
+		var learner *seccompLearner
+		if path := context.String("seccomp-learn"); path != "" {
+			if context.Bool("detach") {
+				fatalf("--seccomp-learn cannot be used with --detach")
+			}
+			if learner, err = newSeccompLearner(spec); err != nil {
+				fatal(err)
+			}
+		}
+
+		status, err := startContainer(context, spec, notifySocket)
+		if learner != nil {
+			if lerr := learner.finish(context.String("seccomp-learn")); lerr != nil && err == nil {
+				err = lerr
+			}
+		}
 		if err != nil {
 			fatal(err)
 		}
@@ -110,7 +155,7 @@ var initCommand = cli.Command{
 	},
 }
 
-func startContainer(context *cli.Context, spec *specs.Spec) (int, error) {
+func startContainer(context *cli.Context, spec *specconv.Spec, notifySocket *notifySocket) (int, error) {
 	id := context.Args().First()
 	if id == "" {
 		return -1, errEmptyID
@@ -119,6 +164,12 @@ func startContainer(context *cli.Context, spec *specs.Spec) (int, error) {
 	if err != nil {
 		return -1, err
 	}
+	if notifySocket != nil {
+		if err := notifySocket.listen(); err != nil {
+			destroy(container)
+			return -1, err
+		}
+	}
 	detach := context.Bool("detach")
 	// Support on-demand socket activation by passing file descriptors into the container init process.
 	listenFDs := []*os.File{}
@@ -133,6 +184,8 @@ func startContainer(context *cli.Context, spec *specs.Spec) (int, error) {
 		console:         context.String("console"),
 		detach:          detach,
 		pidFile:         context.String("pid-file"),
+		notifySocket:    notifySocket,
+		waitReady:       context.Bool("wait-ready"),
 	}
 	return r.run(&spec.Process)
 }
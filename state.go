@@ -30,6 +30,8 @@ type cState struct {
 	Status string `json:"status"`
 	// Created is the unix timestamp for the creation time of the container in UTC
 	Created time.Time `json:"created"`
+	// Annotations are the annotations of the container's spec
+	Annotations map[string]string `json:"annotations,omitempty"`
 }
 
 var stateCommand = cli.Command{
@@ -40,11 +42,25 @@ var stateCommand = cli.Command{
 Where "<container-id>" is your name for the instance of the container.`,
 	Description: `The state command outputs current state information for the
 instance of a container.`,
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "raw",
+			Usage: "output the state stored by runc for the container as is, in the format of the runc that stored it",
+		},
+	},
 	Action: func(context *cli.Context) {
 		container, err := getContainer(context)
 		if err != nil {
 			fatal(err)
 		}
+		if context.Bool("raw") {
+			data, err := container.RawState()
+			if err != nil {
+				fatal(err)
+			}
+			os.Stdout.Write(data)
+			return
+		}
 		containerStatus, err := container.Status()
 		if err != nil {
 			fatal(err)
@@ -53,14 +69,16 @@ instance of a container.`,
 		if err != nil {
 			fatal(err)
 		}
+		bundle, annotations := utils.Annotations(state.Config.Labels)
 		cs := cState{
 			Version:        state.BaseState.Config.Version,
 			ID:             state.BaseState.ID,
 			InitProcessPid: state.BaseState.InitProcessPid,
 			Status:         containerStatus.String(),
-			Bundle:         utils.SearchLabels(state.Config.Labels, "bundle"),
+			Bundle:         bundle,
 			Rootfs:         state.BaseState.Config.Rootfs,
-			Created:        state.BaseState.Created}
+			Created:        state.BaseState.Created,
+			Annotations:    annotations}
 		data, err := json.MarshalIndent(cs, "", "  ")
 		if err != nil {
 			fatal(err)
@@ -70,7 +70,7 @@ func getDefaultImagePath(context *cli.Context) string {
 
 // newProcess returns a new libcontainer Process with the arguments from the
 // spec and stdio from the current process.
-func newProcess(p specs.Process) (*libcontainer.Process, error) {
+func newProcess(p specconv.Process) (*libcontainer.Process, error) {
 	lp := &libcontainer.Process{
 		Args: p.Args,
 		Env:  p.Env,
@@ -89,6 +89,24 @@ func newProcess(p specs.Process) (*libcontainer.Process, error) {
 		}
 		lp.Rlimits = append(lp.Rlimits, rl)
 	}
+	if p.Scheduler != nil {
+		s, err := specconv.CreateLibcontainerScheduler(*p.Scheduler)
+		if err != nil {
+			return nil, err
+		}
+		lp.Scheduler = s
+	}
+	if p.IOPriority != nil {
+		ioprio, err := specconv.CreateLibcontainerIOPriority(*p.IOPriority)
+		if err != nil {
+			return nil, err
+		}
+		lp.IOPriority = ioprio
+	}
+	if a := p.ExecCPUAffinity; a != nil {
+		lp.InitialCPUAffinity = a.Initial
+		lp.CPUAffinity = a.Final
+	}
 	return lp, nil
 }
 
@@ -108,13 +126,6 @@ func dupStdio(process *libcontainer.Process, rootuid int) error {
 	return nil
 }
 
-// If systemd is supporting sd_notify protocol, this function will add support
-// for sd_notify protocol from within the container.
-func setupSdNotify(spec *specs.Spec, notifySocket string) {
-	spec.Mounts = append(spec.Mounts, specs.Mount{Destination: notifySocket, Type: "bind", Source: notifySocket, Options: []string{"bind"}})
-	spec.Process.Env = append(spec.Process.Env, fmt.Sprintf("NOTIFY_SOCKET=%s", notifySocket))
-}
-
 func destroy(container libcontainer.Container) {
 	if err := container.Destroy(); err != nil {
 		logrus.Error(err)
@@ -165,16 +176,38 @@ func createPidFile(path string, process *libcontainer.Process) error {
 	return os.Rename(tmpName, path)
 }
 
-func createContainer(context *cli.Context, id string, spec *specs.Spec) (libcontainer.Container, error) {
+// etcFilesDir returns the directory the /etc files of the container id are
+// generated in, in its state directory.
+func etcFilesDir(context *cli.Context, id string) (string, error) {
+	root, err := filepath.Abs(context.GlobalString("root"))
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(root, id, "etc"), nil
+}
+
+func createContainer(context *cli.Context, id string, spec *specconv.Spec) (libcontainer.Container, error) {
+	etcDir, err := etcFilesDir(context, id)
+	if err != nil {
+		return nil, err
+	}
 	config, err := specconv.CreateLibcontainerConfig(&specconv.CreateOpts{
 		CgroupName:       id,
 		UseSystemdCgroup: context.GlobalBool("systemd-cgroup"),
 		NoPivotRoot:      context.Bool("no-pivot"),
+		Init:             context.Bool("init"),
 		Spec:             spec,
+		EtcFilesDir:      etcDir,
 	})
 	if err != nil {
 		return nil, err
 	}
+	if n := context.Int("exclusive-cpus"); n != 0 {
+		config.Cgroups.Resources.ExclusiveCpus = n
+		config.Cgroups.Resources.ExclusiveCpusNumaLocal = context.Bool("numa-local")
+	} else if context.Bool("numa-local") {
+		return nil, fmt.Errorf("--numa-local requires --exclusive-cpus")
+	}
 
 	if _, err := os.Stat(config.Rootfs); err != nil {
 		if os.IsNotExist(err) {
@@ -198,14 +231,18 @@ type runner struct {
 	pidFile         string
 	console         string
 	container       libcontainer.Container
+	notifySocket    *notifySocket
+	waitReady       bool
+	subCgroup       *libcontainer.SubCgroup
 }
 
-func (r *runner) run(config *specs.Process) (int, error) {
+func (r *runner) run(config *specconv.Process) (int, error) {
 	process, err := newProcess(*config)
 	if err != nil {
 		r.destroy()
 		return -1, err
 	}
+	process.SubCgroup = r.subCgroup
 	if len(r.listenFDs) > 0 {
 		process.Env = append(process.Env, fmt.Sprintf("LISTEN_FDS=%d", len(r.listenFDs)), "LISTEN_PID=1")
 		process.ExtraFiles = append(process.ExtraFiles, r.listenFDs...)
@@ -240,6 +277,25 @@ func (r *runner) run(config *specs.Process) (int, error) {
 			return -1, err
 		}
 	}
+	if r.notifySocket != nil {
+		pid, err := process.Pid()
+		if err != nil {
+			r.terminate(process)
+			r.destroy()
+			tty.Close()
+			return -1, err
+		}
+		ready := make(chan struct{})
+		go r.notifySocket.forward(pid, ready)
+		defer r.notifySocket.Close()
+		if r.detach && r.waitReady {
+			if err := r.notifySocket.waitReady(process, ready); err != nil {
+				r.destroy()
+				tty.Close()
+				return -1, err
+			}
+		}
+	}
 	if r.detach {
 		tty.Close()
 		return 0, nil